@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+const (
+	// ChunkSize is the maximum size of the chunks a snapshot is streamed in,
+	// which stays below the default maximum size of gRPC messages
+	ChunkSize = 1 << 20
+
+	// openTimeout is the time given to open a snapshot or to detect that the
+	// database to restore is in use
+	openTimeout = time.Second
+)
+
+// Write writes a consistent snapshot of the database to w. The snapshot is
+// taken within a read transaction, so the database can be written meanwhile
+func Write(db kvdb.Backend, w io.Writer) error {
+	return db.Copy(w)
+}
+
+// Stream writes a consistent snapshot of the database in chunks of at most
+// ChunkSize bytes to the given send function
+func Stream(db kvdb.Backend, send func(chunk []byte) error) error {
+	bw := bufio.NewWriterSize(chunkWriter(send), ChunkSize)
+	if err := Write(db, bw); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// chunkWriter sends the written bytes in chunks of at most ChunkSize bytes
+type chunkWriter func(chunk []byte) error
+
+func (send chunkWriter) Write(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		end := min(n+ChunkSize, len(p))
+		if err := send(p[n:end]); err != nil {
+			return n, err
+		}
+		n = end
+	}
+
+	return n, nil
+}
+
+// WriteFile writes the file at the given path with the given write function.
+// The content is written to a temporary file which is renamed once complete,
+// so a partial file is never left at the path
+func WriteFile(path string, write func(w io.Writer) error) (err error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if err := f.Chmod(0600); err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Snapshot writes a consistent snapshot of the database to the given path
+func Snapshot(db kvdb.Backend, path string) error {
+	return WriteFile(path, func(w io.Writer) error {
+		return Write(db, w)
+	})
+}
+
+// Open opens the database file at the given path, e.g., a snapshot, which
+// must exist
+func Open(path string) (kvdb.Backend, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to find the database file: %w", err)
+	}
+
+	db, err := kvdb.Open(kvdb.BoltBackendName, path, true, openTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the database file %s: %w", path, err)
+	}
+
+	return db, nil
+}
+
+// OpenCopy writes the snapshot written by the given function, e.g., streamed
+// from a running daemon, to a temporary file and opens it. The returned
+// function closes the snapshot and deletes the file
+func OpenCopy(write func(w io.Writer) error) (kvdb.Backend, func(), error) {
+	dir, err := os.MkdirTemp("", "snapshot-*")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := filepath.Join(dir, "snapshot.db")
+	if err := WriteFile(path, write); err != nil {
+		_ = os.RemoveAll(dir)
+
+		return nil, nil, err
+	}
+
+	db, err := Open(path)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+
+		return nil, nil, err
+	}
+
+	return db, func() {
+		_ = db.Close()
+		_ = os.RemoveAll(dir)
+	}, nil
+}
+
+// Validate opens the snapshot at the given path and validates it with the
+// given function
+func Validate(path string, validate func(db kvdb.Backend) error) error {
+	db, err := Open(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return validate(db)
+}
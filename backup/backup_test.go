@@ -0,0 +1,170 @@
+package backup_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/backup"
+)
+
+var (
+	bucket = []byte("bucket")
+	key    = []byte("key")
+)
+
+func dbConfig(dir string) *kvdb.BoltBackendConfig {
+	return &kvdb.BoltBackendConfig{
+		DBPath:         dir,
+		DBFileName:     "test.db",
+		NoFreelistSync: true,
+		DBTimeout:      time.Second,
+	}
+}
+
+func newTestDB(t *testing.T, cfg *kvdb.BoltBackendConfig, value []byte) kvdb.Backend {
+	db, err := kvdb.GetBoltBackend(cfg)
+	require.NoError(t, err)
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		b, err := tx.CreateTopLevelBucket(bucket)
+		if err != nil {
+			return err
+		}
+
+		return b.Put(key, value)
+	}, func() {})
+	require.NoError(t, err)
+
+	return db
+}
+
+func readValue(t *testing.T, db kvdb.Backend) []byte {
+	var value []byte
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		value = append([]byte(nil), tx.ReadBucket(bucket).Get(key)...)
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	return value
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t, dbConfig(t.TempDir()), []byte("snapshot"))
+	defer db.Close()
+
+	// the snapshot is taken while the database is open
+	snapshotPath := filepath.Join(t.TempDir(), "snapshots", "snapshot.db")
+	require.NoError(t, backup.Snapshot(db, snapshotPath))
+
+	info, err := os.Stat(snapshotPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	restoreCfg := dbConfig(t.TempDir())
+	restored := newTestDB(t, restoreCfg, []byte("current"))
+
+	// the database cannot be restored while it is in use
+	_, err = backup.Restore(snapshotPath, restoreCfg, func(kvdb.Backend) error { return nil })
+	require.Error(t, err)
+	require.NoError(t, restored.Close())
+
+	// the database is left unchanged if the snapshot is invalid
+	errInvalid := errors.New("invalid")
+	_, err = backup.Restore(snapshotPath, restoreCfg, func(kvdb.Backend) error { return errInvalid })
+	require.ErrorIs(t, err, errInvalid)
+
+	replaced, err := backup.Restore(snapshotPath, restoreCfg, func(db kvdb.Backend) error {
+		require.Equal(t, []byte("snapshot"), readValue(t, db))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	restored, err = kvdb.GetBoltBackend(restoreCfg)
+	require.NoError(t, err)
+	require.Equal(t, []byte("snapshot"), readValue(t, restored))
+	require.NoError(t, restored.Close())
+
+	// the replaced database is kept
+	previous, err := backup.Open(replaced)
+	require.NoError(t, err)
+	require.Equal(t, []byte("current"), readValue(t, previous))
+	require.NoError(t, previous.Close())
+
+	// no temporary file is left behind
+	entries, err := os.ReadDir(restoreCfg.DBPath)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+	value := bytes.Repeat([]byte{1}, 3*backup.ChunkSize)
+	db := newTestDB(t, dbConfig(t.TempDir()), value)
+	defer db.Close()
+
+	var snapshot bytes.Buffer
+	err := backup.Stream(db, func(chunk []byte) error {
+		require.LessOrEqual(t, len(chunk), backup.ChunkSize)
+		snapshot.Write(chunk)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot.db")
+	require.NoError(t, os.WriteFile(snapshotPath, snapshot.Bytes(), 0600))
+	err = backup.Validate(snapshotPath, func(db kvdb.Backend) error {
+		require.Equal(t, value, readValue(t, db))
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestSchedulerRetention(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t, dbConfig(t.TempDir()), []byte("value"))
+	defer db.Close()
+
+	cfg := backup.DefaultConfig(t.TempDir())
+	cfg.Interval = time.Hour
+	cfg.Retention = 2
+	require.NoError(t, cfg.Validate())
+
+	// files which are not scheduled snapshots of the database are kept
+	other := filepath.Join(cfg.Dir, "test-manual.db")
+	require.NoError(t, os.WriteFile(other, nil, 0600))
+
+	scheduler := backup.NewScheduler(cfg, db, "test.db", zap.NewNop())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var paths []string
+	for i := 0; i < 4; i++ {
+		path, err := scheduler.Backup(start.Add(time.Duration(i) * time.Hour))
+		require.NoError(t, err)
+		paths = append(paths, path)
+	}
+	require.Equal(t, filepath.Join(cfg.Dir, "test-20240101T030000Z.db"), paths[3])
+
+	entries, err := os.ReadDir(cfg.Dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	require.ElementsMatch(t, []string{
+		filepath.Base(other),
+		filepath.Base(paths[2]),
+		filepath.Base(paths[3]),
+	}, names)
+}
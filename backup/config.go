@@ -0,0 +1,46 @@
+package backup
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultRetention = 7
+)
+
+type Config struct {
+	Dir       string        `long:"dir" description:"The directory in which the scheduled snapshots of the database are written"`
+	Interval  time.Duration `long:"interval" description:"The interval between two scheduled snapshots of the database; scheduled snapshots are disabled if 0"`
+	Retention uint32        `long:"retention" description:"The number of the latest scheduled snapshots to keep; the older ones are deleted"`
+}
+
+// DefaultConfig returns the config writing the snapshots in the given
+// directory, which has the scheduled snapshots disabled
+func DefaultConfig(dir string) *Config {
+	return &Config{
+		Dir:       dir,
+		Interval:  0,
+		Retention: defaultRetention,
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Interval < 0 {
+		return fmt.Errorf("the backup interval should not be negative")
+	}
+
+	if cfg.Interval == 0 {
+		return nil
+	}
+
+	if cfg.Dir == "" {
+		return fmt.Errorf("the backup directory should not be empty if scheduled snapshots are enabled")
+	}
+
+	if cfg.Retention == 0 {
+		return fmt.Errorf("the backup retention should be positive if scheduled snapshots are enabled")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// Restore replaces the database of the given config with the snapshot at the
+// given path, once the snapshot is validated with the given function. The
+// replaced database is kept next to the restored one and its path is returned,
+// which is empty if there was no database
+func Restore(snapshotPath string, dbCfg *kvdb.BoltBackendConfig, validate func(db kvdb.Backend) error) (string, error) {
+	dbFile := filepath.Join(dbCfg.DBPath, dbCfg.DBFileName)
+	restoreFile := dbFile + ".restore"
+
+	// the snapshot is copied next to the database first, so that it is
+	// validated as it is restored and the replacement is a rename
+	err := WriteFile(restoreFile, func(w io.Writer) error {
+		src, err := os.Open(snapshotPath)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		_, err = io.Copy(w, src)
+
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to copy the snapshot: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(restoreFile)
+	}()
+
+	if err := Validate(restoreFile, validate); err != nil {
+		return "", fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	if _, err := os.Stat(dbFile); errors.Is(err, os.ErrNotExist) {
+		return "", os.Rename(restoreFile, dbFile)
+	}
+
+	// the database cannot be opened while the daemon holds its lock
+	db, err := kvdb.Open(kvdb.BoltBackendName, dbFile, dbCfg.NoFreelistSync, openTimeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to open the database, the daemon should be stopped: %w", err)
+	}
+	if err := db.Close(); err != nil {
+		return "", err
+	}
+
+	replacedFile := fmt.Sprintf("%s.pre-restore-%s", dbFile, time.Now().UTC().Format(timestampLayout))
+	if err := os.Rename(dbFile, replacedFile); err != nil {
+		return "", err
+	}
+
+	return replacedFile, os.Rename(restoreFile, dbFile)
+}
@@ -0,0 +1,144 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// timestampLayout is the UTC timestamp in the names of the snapshots,
+	// which sorts the names in the order the snapshots are taken
+	timestampLayout = "20060102T150405Z"
+
+	snapshotExt = ".db"
+)
+
+// Scheduler periodically writes snapshots of a database and deletes the ones
+// exceeding the retention
+type Scheduler struct {
+	cfg    *Config
+	db     kvdb.Backend
+	name   string
+	logger *zap.Logger
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// NewScheduler creates the scheduler of the snapshots of the given database,
+// which are named after the database file and the time they are taken
+func NewScheduler(cfg *Config, db kvdb.Backend, dbFileName string, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		cfg:    cfg,
+		db:     db,
+		name:   strings.TrimSuffix(dbFileName, filepath.Ext(dbFileName)),
+		logger: logger,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start starts taking the scheduled snapshots, unless they are disabled
+func (s *Scheduler) Start() {
+	if s.cfg.Interval == 0 {
+		return
+	}
+
+	s.logger.Info("scheduled the snapshots of the database",
+		zap.String("dir", s.cfg.Dir), zap.Duration("interval", s.cfg.Interval),
+		zap.Uint32("retention", s.cfg.Retention))
+
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop stops taking the scheduled snapshots, waiting for an ongoing one
+func (s *Scheduler) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			path, err := s.Backup(time.Now())
+			if err != nil {
+				s.logger.Error("failed to back up the database", zap.Error(err))
+
+				continue
+			}
+			s.logger.Info("backed up the database", zap.String("path", path))
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Backup writes the snapshot of the database taken at the given time and
+// deletes the snapshots exceeding the retention, returning the snapshot path
+func (s *Scheduler) Backup(now time.Time) (string, error) {
+	path := filepath.Join(s.cfg.Dir, s.snapshotName(now))
+	if err := Snapshot(s.db, path); err != nil {
+		return "", err
+	}
+
+	if err := s.prune(); err != nil {
+		return path, fmt.Errorf("failed to delete the snapshots exceeding the retention: %w", err)
+	}
+
+	return path, nil
+}
+
+func (s *Scheduler) snapshotName(t time.Time) string {
+	return fmt.Sprintf("%s-%s%s", s.name, t.UTC().Format(timestampLayout), snapshotExt)
+}
+
+// prune deletes the oldest scheduled snapshots exceeding the retention. Only
+// the files named as scheduled snapshots of the database are considered
+func (s *Scheduler) prune() error {
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return err
+	}
+
+	prefix := s.name + "-"
+	var snapshots []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), snapshotExt)
+		if _, err := time.Parse(timestampLayout, timestamp); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, name)
+	}
+
+	if len(snapshots) <= int(s.cfg.Retention) {
+		return nil
+	}
+
+	sort.Strings(snapshots)
+	for _, name := range snapshots[:len(snapshots)-int(s.cfg.Retention)] {
+		if err := os.Remove(filepath.Join(s.cfg.Dir, name)); err != nil {
+			return err
+		}
+		s.logger.Debug("deleted the snapshot exceeding the retention", zap.String("name", name))
+	}
+
+	return nil
+}
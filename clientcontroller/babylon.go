@@ -2,6 +2,7 @@ package clientcontroller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -15,20 +16,24 @@ import (
 	btcctypes "github.com/babylonlabs-io/babylon/x/btccheckpoint/types"
 	btclctypes "github.com/babylonlabs-io/babylon/x/btclightclient/types"
 	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	epochingtypes "github.com/babylonlabs-io/babylon/x/epoching/types"
 	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
 	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	sttypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/cosmos/relayer/v2/relayer/provider"
 	"go.uber.org/zap"
 	protobuf "google.golang.org/protobuf/proto"
 
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
 
@@ -37,7 +42,7 @@ var _ ClientController = &BabylonController{}
 var emptyErrs = []*sdkErr.Error{}
 
 type BabylonController struct {
-	bbnClient *bbnclient.Client
+	endpoints *bbnEndpointPool
 	cfg       *fpcfg.BBNConfig
 	btcParams *chaincfg.Params
 	logger    *zap.Logger
@@ -48,30 +53,42 @@ func NewBabylonController(
 	btcParams *chaincfg.Params,
 	logger *zap.Logger,
 ) (*BabylonController, error) {
-	bbnConfig := fpcfg.BBNConfigToBabylonConfig(cfg)
-
-	bc, err := bbnclient.New(
-		&bbnConfig,
-		logger,
-	)
+	endpoints, err := newBBNEndpointPool(cfg, metrics.NewFpMetrics(), logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Babylon client: %w", err)
+		return nil, err
 	}
 
-	// makes sure that the key in config really exists and is a valid bech32 addr
-	// to allow using mustGetTxSigner
-	if _, err := bc.GetAddr(); err != nil {
-		return nil, err
+	if err := validateBBNAddresses(endpoints, cfg); err != nil {
+		return nil, errors.Join(err, endpoints.stop())
 	}
 
+	endpoints.start()
+
 	return &BabylonController{
-		bc,
+		endpoints,
 		cfg,
 		btcParams,
 		logger,
 	}, nil
 }
 
+// validateBBNAddresses makes sure that the key in config really exists and is
+// a valid bech32 addr to allow using mustGetTxSigner, and that the authz
+// granter, if any, is a valid bech32 addr
+func validateBBNAddresses(endpoints *bbnEndpointPool, cfg *fpcfg.BBNConfig) error {
+	if _, err := endpoints.activeClient().GetAddr(); err != nil {
+		return err
+	}
+
+	if cfg.AuthzGranter != "" {
+		if _, err := sdk.GetFromBech32(cfg.AuthzGranter, cfg.AccountPrefix); err != nil {
+			return fmt.Errorf("invalid authz granter address %s: %w", cfg.AuthzGranter, err)
+		}
+	}
+
+	return nil
+}
+
 func (bc *BabylonController) mustGetTxSigner() string {
 	signer := bc.GetKeyAddress()
 	prefix := bc.cfg.AccountPrefix
@@ -79,13 +96,36 @@ func (bc *BabylonController) mustGetTxSigner() string {
 	return sdk.MustBech32ifyAddressBytes(prefix, signer)
 }
 
+// mustGetFpSigner returns the address that acts on behalf of the finality
+// provider, which is the authz granter if configured or the tx signer otherwise
+func (bc *BabylonController) mustGetFpSigner() string {
+	if bc.cfg.AuthzGranter != "" {
+		return bc.cfg.AuthzGranter
+	}
+
+	return bc.mustGetTxSigner()
+}
+
+// wrapWithAuthzExec wraps the given msgs into a single authz MsgExec signed by
+// the configured key if an authz granter is set, otherwise the msgs are returned
+// as they are
+func (bc *BabylonController) wrapWithAuthzExec(msgs []sdk.Msg) []sdk.Msg {
+	if bc.cfg.AuthzGranter == "" {
+		return msgs
+	}
+
+	execMsg := authz.NewMsgExec(bc.GetKeyAddress(), msgs)
+
+	return []sdk.Msg{&execMsg}
+}
+
 func (bc *BabylonController) GetKeyAddress() sdk.AccAddress {
 	// get key address, retrieves address based on the key name which is configured in
 	// cfg *stakercfg.BBNConfig. If this fails, it means we have a misconfiguration problem
 	// and we should panic.
 	// This is checked at the start of BabylonController, so if it fails something is really wrong
 
-	keyRec, err := bc.bbnClient.GetKeyring().Key(bc.cfg.Key)
+	keyRec, err := bc.endpoints.activeClient().GetKeyring().Key(bc.cfg.Key)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to get key address: %s", err))
 	}
@@ -98,22 +138,37 @@ func (bc *BabylonController) GetKeyAddress() sdk.AccAddress {
 	return addr
 }
 
-func (bc *BabylonController) reliablySendMsg(msg sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*provider.RelayerTxResponse, error) {
-	return bc.reliablySendMsgs([]sdk.Msg{msg}, expectedErrs, unrecoverableErrs)
+func (bc *BabylonController) reliablySendMsg(ctx context.Context, msg sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*provider.RelayerTxResponse, error) {
+	return bc.reliablySendMsgs(ctx, []sdk.Msg{msg}, expectedErrs, unrecoverableErrs)
 }
 
-func (bc *BabylonController) reliablySendMsgs(msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*provider.RelayerTxResponse, error) {
-	return bc.bbnClient.ReliablySendMsgs(
-		context.Background(),
-		msgs,
-		expectedErrs,
-		unrecoverableErrs,
-	)
+func (bc *BabylonController) reliablySendMsgs(ctx context.Context, msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*provider.RelayerTxResponse, error) {
+	tx, err := bc.sendMsgs(ctx, msgs, expectedErrs, unrecoverableErrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.res, nil
+}
+
+// sendMsgs sends the given msgs in a tx and waits for its inclusion, and also
+// returns when the tx is accepted into the mempool
+func (bc *BabylonController) sendMsgs(ctx context.Context, msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*sentTx, error) {
+	return withBroadcastFailover(ctx, bc.endpoints, func(ep *bbnEndpoint) (*sentTx, error) {
+		return reliablySendMsgsWith(
+			ctx,
+			ep.provider,
+			msgs,
+			expectedErrs,
+			unrecoverableErrs,
+		)
+	})
 }
 
 // RegisterFinalityProvider registers a finality provider via a MsgCreateFinalityProvider to Babylon
 // it returns tx hash and error
 func (bc *BabylonController) RegisterFinalityProvider(
+	ctx context.Context,
 	fpPk *btcec.PublicKey,
 	pop []byte,
 	commission *sdkmath.LegacyDec,
@@ -138,7 +193,7 @@ func (bc *BabylonController) RegisterFinalityProvider(
 		Description: &sdkDescription,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
+	res, err := bc.reliablySendMsg(ctx, msg, emptyErrs, emptyErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +204,7 @@ func (bc *BabylonController) RegisterFinalityProvider(
 // CommitPubRandList commits a list of Schnorr public randomness via a MsgCommitPubRand to Babylon
 // it returns tx hash and error
 func (bc *BabylonController) CommitPubRandList(
+	ctx context.Context,
 	fpPk *btcec.PublicKey,
 	startHeight uint64,
 	numPubRand uint64,
@@ -156,7 +212,7 @@ func (bc *BabylonController) CommitPubRandList(
 	sig *schnorr.Signature,
 ) (*types.TxResponse, error) {
 	msg := &finalitytypes.MsgCommitPubRandList{
-		Signer:      bc.mustGetTxSigner(),
+		Signer:      bc.mustGetFpSigner(),
 		FpBtcPk:     bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
 		StartHeight: startHeight,
 		NumPubRand:  numPubRand,
@@ -171,7 +227,7 @@ func (bc *BabylonController) CommitPubRandList(
 		btcstakingtypes.ErrFpNotFound,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, unrecoverableErrs)
+	res, err := bc.reliablySendMsgs(ctx, bc.wrapWithAuthzExec([]sdk.Msg{msg}), emptyErrs, unrecoverableErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -181,6 +237,7 @@ func (bc *BabylonController) CommitPubRandList(
 
 // SubmitFinalitySig submits the finality signature via a MsgAddVote to Babylon
 func (bc *BabylonController) SubmitFinalitySig(
+	ctx context.Context,
 	fpPk *btcec.PublicKey,
 	block *types.BlockInfo,
 	pubRand *btcec.FieldVal,
@@ -188,13 +245,14 @@ func (bc *BabylonController) SubmitFinalitySig(
 	sig *btcec.ModNScalar,
 ) (*types.TxResponse, error) {
 	return bc.SubmitBatchFinalitySigs(
-		fpPk, []*types.BlockInfo{block}, []*btcec.FieldVal{pubRand},
+		ctx, fpPk, []*types.BlockInfo{block}, []*btcec.FieldVal{pubRand},
 		[][]byte{proof}, []*btcec.ModNScalar{sig},
 	)
 }
 
 // SubmitBatchFinalitySigs submits a batch of finality signatures to Babylon
 func (bc *BabylonController) SubmitBatchFinalitySigs(
+	ctx context.Context,
 	fpPk *btcec.PublicKey,
 	blocks []*types.BlockInfo,
 	pubRandList []*btcec.FieldVal,
@@ -213,7 +271,7 @@ func (bc *BabylonController) SubmitBatchFinalitySigs(
 		}
 
 		msg := &finalitytypes.MsgAddFinalitySig{
-			Signer:       bc.mustGetTxSigner(),
+			Signer:       bc.mustGetFpSigner(),
 			FpBtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
 			BlockHeight:  b.Height,
 			PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRandList[i]),
@@ -234,22 +292,22 @@ func (bc *BabylonController) SubmitBatchFinalitySigs(
 		finalitytypes.ErrDuplicatedFinalitySig,
 	}
 
-	res, err := bc.reliablySendMsgs(msgs, expectedErrs, unrecoverableErrs)
+	tx, err := bc.sendMsgs(ctx, bc.wrapWithAuthzExec(msgs), expectedErrs, unrecoverableErrs)
 	if err != nil {
 		return nil, err
 	}
 
-	if res == nil {
-		return &types.TxResponse{}, nil
+	if tx.res == nil {
+		return &types.TxResponse{BroadcastAt: tx.broadcastAt}, nil
 	}
 
-	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+	return &types.TxResponse{TxHash: tx.res.TxHash, Events: tx.res.Events, BroadcastAt: tx.broadcastAt}, nil
 }
 
 // UnjailFinalityProvider sends an unjail transaction to the consumer chain
-func (bc *BabylonController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+func (bc *BabylonController) UnjailFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types.TxResponse, error) {
 	msg := &finalitytypes.MsgUnjailFinalityProvider{
-		Signer:  bc.mustGetTxSigner(),
+		Signer:  bc.mustGetFpSigner(),
 		FpBtcPk: bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
 	}
 
@@ -259,7 +317,7 @@ func (bc *BabylonController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*typ
 		btcstakingtypes.ErrFpAlreadySlashed,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, unrecoverableErrs)
+	res, err := bc.reliablySendMsgs(ctx, bc.wrapWithAuthzExec([]sdk.Msg{msg}), emptyErrs, unrecoverableErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -268,9 +326,9 @@ func (bc *BabylonController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*typ
 }
 
 // QueryFinalityProviderSlashedOrJailed - returns if the fp has been slashed, jailed, err
-func (bc *BabylonController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (bool, bool, error) {
+func (bc *BabylonController) QueryFinalityProviderSlashedOrJailed(ctx context.Context, fpPk *btcec.PublicKey) (bool, bool, error) {
 	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
-	res, err := bc.bbnClient.QueryClient.FinalityProvider(fpPubKey.MarshalHex())
+	res, err := bc.queryFinalityProvider(ctx, fpPubKey.MarshalHex())
 	if err != nil {
 		return false, false, fmt.Errorf("failed to query the finality provider %s: %w", fpPubKey.MarshalHex(), err)
 	}
@@ -278,16 +336,32 @@ func (bc *BabylonController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.Pu
 	return res.FinalityProvider.SlashedBtcHeight > 0, res.FinalityProvider.Jailed, nil
 }
 
+// QueryFinalityProviderJailedUntil queries the signing info of the finality provider
+// for the time until which it is jailed
+func (bc *BabylonController) QueryFinalityProviderJailedUntil(ctx context.Context, fpPk *btcec.PublicKey) (time.Time, error) {
+	fpPkHex := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()
+	res, err := queryFinality(ctx, bc, func(ctx context.Context, queryClient finalitytypes.QueryClient) (*finalitytypes.QuerySigningInfoResponse, error) {
+		return queryClient.SigningInfo(ctx, &finalitytypes.QuerySigningInfoRequest{FpBtcPkHex: fpPkHex})
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query the signing info of the finality provider %s: %w", fpPkHex, err)
+	}
+
+	return res.SigningInfo.JailedUntil, nil
+}
+
 // QueryFinalityProviderVotingPower queries the voting power of the finality provider at a given height
-func (bc *BabylonController) QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
-	res, err := bc.bbnClient.QueryClient.FinalityProviderPowerAtHeight(
-		bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex(),
-		blockHeight,
-	)
+func (bc *BabylonController) QueryFinalityProviderVotingPower(ctx context.Context, fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	res, err := queryFinality(ctx, bc, func(ctx context.Context, queryClient finalitytypes.QueryClient) (*finalitytypes.QueryFinalityProviderPowerAtHeightResponse, error) {
+		return queryClient.FinalityProviderPowerAtHeight(ctx, &finalitytypes.QueryFinalityProviderPowerAtHeightRequest{
+			FpBtcPkHex: bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex(),
+			Height:     blockHeight,
+		})
+	})
 	if err != nil {
 		// voting power table not updated indicates that no fp has voting power
 		// therefore, it should be treated as the fp having 0 voting power
-		if strings.Contains(err.Error(), finalitytypes.ErrVotingPowerTableNotUpdated.Error()) {
+		if errors.Is(err, finalitytypes.ErrVotingPowerTableNotUpdated) {
 			bc.logger.Info("the voting power table not updated yet")
 
 			return 0, nil
@@ -300,9 +374,9 @@ func (bc *BabylonController) QueryFinalityProviderVotingPower(fpPk *btcec.Public
 }
 
 // QueryFinalityProviderHighestVotedHeight queries the highest voted height of the given finality provider
-func (bc *BabylonController) QueryFinalityProviderHighestVotedHeight(fpPk *btcec.PublicKey) (uint64, error) {
+func (bc *BabylonController) QueryFinalityProviderHighestVotedHeight(ctx context.Context, fpPk *btcec.PublicKey) (uint64, error) {
 	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
-	res, err := bc.bbnClient.QueryClient.FinalityProvider(fpPubKey.MarshalHex())
+	res, err := bc.queryFinalityProvider(ctx, fpPubKey.MarshalHex())
 	if err != nil {
 		return 0, fmt.Errorf("failed to query highest voted height for finality provider %s: %w", fpPubKey.MarshalHex(), err)
 	}
@@ -310,12 +384,12 @@ func (bc *BabylonController) QueryFinalityProviderHighestVotedHeight(fpPk *btcec
 	return uint64(res.FinalityProvider.HighestVotedHeight), nil
 }
 
-func (bc *BabylonController) QueryLatestFinalizedBlocks(count uint64) ([]*types.BlockInfo, error) {
-	return bc.queryLatestBlocks(nil, count, finalitytypes.QueriedBlockStatus_FINALIZED, true)
+func (bc *BabylonController) QueryLatestFinalizedBlocks(ctx context.Context, count uint64) ([]*types.BlockInfo, error) {
+	return bc.queryLatestBlocks(ctx, nil, count, finalitytypes.QueriedBlockStatus_FINALIZED, true)
 }
 
 // QueryLastCommittedPublicRand returns the last public randomness commitments
-func (bc *BabylonController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+func (bc *BabylonController) QueryLastCommittedPublicRand(ctx context.Context, fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
 	fpBtcPk := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
 
 	pagination := &sdkquery.PageRequest{
@@ -324,7 +398,12 @@ func (bc *BabylonController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey,
 		Reverse: true,
 	}
 
-	res, err := bc.bbnClient.QueryClient.ListPubRandCommit(fpBtcPk.MarshalHex(), pagination)
+	res, err := queryFinality(ctx, bc, func(ctx context.Context, queryClient finalitytypes.QueryClient) (*finalitytypes.QueryListPubRandCommitResponse, error) {
+		return queryClient.ListPubRandCommit(ctx, &finalitytypes.QueryListPubRandCommitRequest{
+			FpBtcPkHex: fpBtcPk.MarshalHex(),
+			Pagination: pagination,
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query committed public randomness: %w", err)
 	}
@@ -332,7 +411,7 @@ func (bc *BabylonController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey,
 	return res.PubRandCommitMap, nil
 }
 
-func (bc *BabylonController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+func (bc *BabylonController) QueryBlocks(ctx context.Context, startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
 	if endHeight < startHeight {
 		return nil, fmt.Errorf("the startHeight %v should not be higher than the endHeight %v", startHeight, endHeight)
 	}
@@ -341,10 +420,10 @@ func (bc *BabylonController) QueryBlocks(startHeight, endHeight uint64, limit ui
 		count = uint64(limit)
 	}
 
-	return bc.queryLatestBlocks(sdk.Uint64ToBigEndian(startHeight), count, finalitytypes.QueriedBlockStatus_ANY, false)
+	return bc.queryLatestBlocks(ctx, sdk.Uint64ToBigEndian(startHeight), count, finalitytypes.QueriedBlockStatus_ANY, false)
 }
 
-func (bc *BabylonController) queryLatestBlocks(startKey []byte, count uint64, status finalitytypes.QueriedBlockStatus, reverse bool) ([]*types.BlockInfo, error) {
+func (bc *BabylonController) queryLatestBlocks(ctx context.Context, startKey []byte, count uint64, status finalitytypes.QueriedBlockStatus, reverse bool) ([]*types.BlockInfo, error) {
 	var blocks []*types.BlockInfo
 	pagination := &sdkquery.PageRequest{
 		Limit:   count,
@@ -352,7 +431,12 @@ func (bc *BabylonController) queryLatestBlocks(startKey []byte, count uint64, st
 		Key:     startKey,
 	}
 
-	res, err := bc.bbnClient.QueryClient.ListBlocks(status, pagination)
+	res, err := queryFinality(ctx, bc, func(ctx context.Context, queryClient finalitytypes.QueryClient) (*finalitytypes.QueryListBlocksResponse, error) {
+		return queryClient.ListBlocks(ctx, &finalitytypes.QueryListBlocksRequest{
+			Status:     status,
+			Pagination: pagination,
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query finalized blocks: %w", err)
 	}
@@ -374,8 +458,10 @@ func getContextWithCancel(timeout time.Duration) (context.Context, context.Cance
 	return ctx, cancel
 }
 
-func (bc *BabylonController) QueryBlock(height uint64) (*types.BlockInfo, error) {
-	res, err := bc.bbnClient.QueryClient.Block(height)
+func (bc *BabylonController) QueryBlock(ctx context.Context, height uint64) (*types.BlockInfo, error) {
+	res, err := queryFinality(ctx, bc, func(ctx context.Context, queryClient finalitytypes.QueryClient) (*finalitytypes.QueryBlockResponse, error) {
+		return queryClient.Block(ctx, &finalitytypes.QueryBlockRequest{Height: height})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query indexed block at height %v: %w", height, err)
 	}
@@ -387,8 +473,10 @@ func (bc *BabylonController) QueryBlock(height uint64) (*types.BlockInfo, error)
 	}, nil
 }
 
-func (bc *BabylonController) QueryActivatedHeight() (uint64, error) {
-	res, err := bc.bbnClient.QueryClient.ActivatedHeight()
+func (bc *BabylonController) QueryActivatedHeight(ctx context.Context) (uint64, error) {
+	res, err := queryFinality(ctx, bc, func(ctx context.Context, queryClient finalitytypes.QueryClient) (*finalitytypes.QueryActivatedHeightResponse, error) {
+		return queryClient.ActivatedHeight(ctx, &finalitytypes.QueryActivatedHeightRequest{})
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to query activated height: %w", err)
 	}
@@ -396,8 +484,10 @@ func (bc *BabylonController) QueryActivatedHeight() (uint64, error) {
 	return res.Height, nil
 }
 
-func (bc *BabylonController) QueryFinalityActivationBlockHeight() (uint64, error) {
-	res, err := bc.bbnClient.QueryClient.FinalityParams()
+func (bc *BabylonController) QueryFinalityActivationBlockHeight(ctx context.Context) (uint64, error) {
+	res, err := queryFinality(ctx, bc, func(ctx context.Context, queryClient finalitytypes.QueryClient) (*finalitytypes.QueryParamsResponse, error) {
+		return queryClient.Params(ctx, &finalitytypes.QueryParamsRequest{})
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to query finality params to get finality activation block height: %w", err)
 	}
@@ -405,20 +495,22 @@ func (bc *BabylonController) QueryFinalityActivationBlockHeight() (uint64, error
 	return res.Params.FinalityActivationHeight, nil
 }
 
-func (bc *BabylonController) QueryBestBlock() (*types.BlockInfo, error) {
-	blocks, err := bc.queryLatestBlocks(nil, 1, finalitytypes.QueriedBlockStatus_ANY, true)
+func (bc *BabylonController) QueryBestBlock(ctx context.Context) (*types.BlockInfo, error) {
+	blocks, err := bc.queryLatestBlocks(ctx, nil, 1, finalitytypes.QueriedBlockStatus_ANY, true)
 	if err != nil || len(blocks) != 1 {
 		// try query comet block if the index block query is not available
-		return bc.queryCometBestBlock()
+		return bc.queryCometBestBlock(ctx)
 	}
 
 	return blocks[0], nil
 }
 
-func (bc *BabylonController) queryCometBestBlock() (*types.BlockInfo, error) {
-	ctx, cancel := getContextWithCancel(bc.cfg.Timeout)
+func (bc *BabylonController) queryCometBestBlock(ctx context.Context) (*types.BlockInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, bc.cfg.Timeout)
 	// this will return 20 items at max in the descending order (highest first)
-	chainInfo, err := bc.bbnClient.RPCClient.BlockchainInfo(ctx, 0, 0)
+	chainInfo, err := withFailover(ctx, bc.endpoints, func(c *bbnclient.Client) (*coretypes.ResultBlockchainInfo, error) {
+		return c.RPCClient.BlockchainInfo(ctx, 0, 0)
+	})
 	defer cancel()
 
 	if err != nil {
@@ -437,16 +529,38 @@ func (bc *BabylonController) queryCometBestBlock() (*types.BlockInfo, error) {
 	}, nil
 }
 
-func (bc *BabylonController) Close() error {
-	if !bc.bbnClient.IsRunning() {
-		return nil
+// QueryNodeStatus queries the sync status of the Babylon node in use
+func (bc *BabylonController) QueryNodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, bc.cfg.Timeout)
+	defer cancel()
+
+	res, err := withFailover(ctx, bc.endpoints, func(c *bbnclient.Client) (*coretypes.ResultStatus, error) {
+		return c.RPCClient.Status(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the node status: %w", err)
+	}
+
+	if res.SyncInfo.LatestBlockHeight < 0 {
+		return nil, fmt.Errorf("block height %v should be positive", res.SyncInfo.LatestBlockHeight)
 	}
 
-	return bc.bbnClient.Stop()
+	return &types.NodeStatus{
+		LatestHeight:    uint64(res.SyncInfo.LatestBlockHeight),
+		LatestBlockTime: res.SyncInfo.LatestBlockTime,
+		CatchingUp:      res.SyncInfo.CatchingUp,
+	}, nil
+}
+
+func (bc *BabylonController) Close() error {
+	return bc.endpoints.stop()
 }
 
 /*
 	Implementations for e2e tests only
+
+	They take no context, so they use the query functions of the Babylon
+	client, which bound the queries by the client's timeout only
 */
 
 func (bc *BabylonController) CreateBTCDelegation(
@@ -486,7 +600,7 @@ func (bc *BabylonController) CreateBTCDelegation(
 		DelegatorUnbondingSlashingSig: delUnbondingSlashingSig,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
+	res, err := bc.reliablySendMsg(context.Background(), msg, emptyErrs, emptyErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -500,7 +614,7 @@ func (bc *BabylonController) InsertBtcBlockHeaders(headers []bbntypes.BTCHeaderB
 		Headers: headers,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
+	res, err := bc.reliablySendMsg(context.Background(), msg, emptyErrs, emptyErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -515,7 +629,9 @@ func (bc *BabylonController) QueryFinalityProviders() ([]*btcstakingtypes.Finali
 	}
 
 	for {
-		res, err := bc.bbnClient.QueryClient.FinalityProviders(pagination)
+		res, err := withFailover(context.Background(), bc.endpoints, func(c *bbnclient.Client) (*btcstakingtypes.QueryFinalityProvidersResponse, error) {
+			return c.QueryClient.FinalityProviders(pagination)
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to query finality providers: %w", err)
 		}
@@ -530,9 +646,9 @@ func (bc *BabylonController) QueryFinalityProviders() ([]*btcstakingtypes.Finali
 	return fps, nil
 }
 
-func (bc *BabylonController) QueryFinalityProvider(fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+func (bc *BabylonController) QueryFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
 	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
-	res, err := bc.bbnClient.QueryClient.FinalityProvider(fpPubKey.MarshalHex())
+	res, err := bc.queryFinalityProvider(ctx, fpPubKey.MarshalHex())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query the finality provider %s: %w", fpPubKey.MarshalHex(), err)
 	}
@@ -540,7 +656,7 @@ func (bc *BabylonController) QueryFinalityProvider(fpPk *btcec.PublicKey) (*btcs
 	return res, nil
 }
 
-func (bc *BabylonController) EditFinalityProvider(fpPk *btcec.PublicKey,
+func (bc *BabylonController) EditFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey,
 	rate *sdkmath.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
 	var reqDesc proto.Description
 	if err := protobuf.Unmarshal(description, &reqDesc); err != nil {
@@ -548,7 +664,7 @@ func (bc *BabylonController) EditFinalityProvider(fpPk *btcec.PublicKey,
 	}
 	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
 
-	fpRes, err := bc.QueryFinalityProvider(fpPk)
+	fpRes, err := bc.QueryFinalityProvider(ctx, fpPk)
 	if err != nil {
 		return nil, err
 	}
@@ -587,7 +703,7 @@ func (bc *BabylonController) EditFinalityProvider(fpPk *btcec.PublicKey,
 		msg.Commission = rate
 	}
 
-	_, err = bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
+	_, err = bc.reliablySendMsg(ctx, msg, emptyErrs, emptyErrs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query the finality provider %s: %w", fpPk.SerializeCompressed(), err)
 	}
@@ -596,7 +712,9 @@ func (bc *BabylonController) EditFinalityProvider(fpPk *btcec.PublicKey,
 }
 
 func (bc *BabylonController) QueryBtcLightClientTip() (*btclctypes.BTCHeaderInfoResponse, error) {
-	res, err := bc.bbnClient.QueryClient.BTCHeaderChainTip()
+	res, err := withFailover(context.Background(), bc.endpoints, func(c *bbnclient.Client) (*btclctypes.QueryTipResponse, error) {
+		return c.QueryClient.BTCHeaderChainTip()
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query BTC tip: %w", err)
 	}
@@ -605,7 +723,9 @@ func (bc *BabylonController) QueryBtcLightClientTip() (*btclctypes.BTCHeaderInfo
 }
 
 func (bc *BabylonController) QueryCurrentEpoch() (uint64, error) {
-	res, err := bc.bbnClient.QueryClient.CurrentEpoch()
+	res, err := withFailover(context.Background(), bc.endpoints, func(c *bbnclient.Client) (*epochingtypes.QueryCurrentEpochResponse, error) {
+		return c.QueryClient.CurrentEpoch()
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to query BTC tip: %w", err)
 	}
@@ -614,7 +734,9 @@ func (bc *BabylonController) QueryCurrentEpoch() (uint64, error) {
 }
 
 func (bc *BabylonController) QueryVotesAtHeight(height uint64) ([]bbntypes.BIP340PubKey, error) {
-	res, err := bc.bbnClient.QueryClient.VotesAtHeight(height)
+	res, err := withFailover(context.Background(), bc.endpoints, func(c *bbnclient.Client) (*finalitytypes.QueryVotesAtHeightResponse, error) {
+		return c.QueryClient.VotesAtHeight(height)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query BTC delegations: %w", err)
 	}
@@ -638,7 +760,9 @@ func (bc *BabylonController) queryDelegationsWithStatus(status btcstakingtypes.B
 		Limit: limit,
 	}
 
-	res, err := bc.bbnClient.QueryClient.BTCDelegations(status, pagination)
+	res, err := withFailover(context.Background(), bc.endpoints, func(c *bbnclient.Client) (*btcstakingtypes.QueryBTCDelegationsResponse, error) {
+		return c.QueryClient.BTCDelegations(status, pagination)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query BTC delegations: %w", err)
 	}
@@ -648,13 +772,17 @@ func (bc *BabylonController) queryDelegationsWithStatus(status btcstakingtypes.B
 
 func (bc *BabylonController) QueryStakingParams() (*types.StakingParams, error) {
 	// query btc checkpoint params
-	ckptParamRes, err := bc.bbnClient.QueryClient.BTCCheckpointParams()
+	ckptParamRes, err := withFailover(context.Background(), bc.endpoints, func(c *bbnclient.Client) (*btcctypes.QueryParamsResponse, error) {
+		return c.QueryClient.BTCCheckpointParams()
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query params of the btccheckpoint module: %w", err)
 	}
 
 	// query btc staking params
-	stakingParamRes, err := bc.bbnClient.QueryClient.BTCStakingParams()
+	stakingParamRes, err := withFailover(context.Background(), bc.endpoints, func(c *bbnclient.Client) (*btcstakingtypes.QueryParamsResponse, error) {
+		return c.QueryClient.BTCStakingParams()
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query staking params: %w", err)
 	}
@@ -698,7 +826,7 @@ func (bc *BabylonController) SubmitCovenantSigs(
 		SlashingUnbondingTxSigs: unbondingSlashingSigs,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
+	res, err := bc.reliablySendMsg(context.Background(), msg, emptyErrs, emptyErrs)
 	if err != nil {
 		return nil, err
 	}
@@ -707,7 +835,7 @@ func (bc *BabylonController) SubmitCovenantSigs(
 }
 
 func (bc *BabylonController) GetBBNClient() *bbnclient.Client {
-	return bc.bbnClient
+	return bc.endpoints.activeClient()
 }
 
 func (bc *BabylonController) InsertSpvProofs(submitter string, proofs []*btcctypes.BTCSpvProof) (*provider.RelayerTxResponse, error) {
@@ -716,7 +844,7 @@ func (bc *BabylonController) InsertSpvProofs(submitter string, proofs []*btcctyp
 		Proofs:    proofs,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
+	res, err := bc.reliablySendMsg(context.Background(), msg, emptyErrs, emptyErrs)
 	if err != nil {
 		return nil, err
 	}
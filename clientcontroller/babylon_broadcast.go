@@ -0,0 +1,173 @@
+package clientcontroller
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	"github.com/avast/retry-go/v4"
+	bbnapp "github.com/babylonlabs-io/babylon/app"
+	bbnclient "github.com/babylonlabs-io/babylon/client/client"
+	bbncfg "github.com/babylonlabs-io/babylon/client/config"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
+	"github.com/cosmos/relayer/v2/relayer/provider"
+	"github.com/juju/fslock"
+	"go.uber.org/zap"
+)
+
+// the retries of sending a tx to the mempool, which are the same as those of
+// the Babylon client
+const (
+	sendTxAttempts   = 5
+	sendTxRetryDelay = 400 * time.Millisecond
+)
+
+// sentTx is the result of sending a tx to Babylon
+type sentTx struct {
+	// res is nil if the tx is rejected with an expected error
+	res *provider.RelayerTxResponse
+	// broadcastAt is when the tx is accepted into the mempool of the node,
+	// i.e., passes CheckTx; zero if it is rejected with an expected error
+	broadcastAt time.Time
+}
+
+// newBBNProvider creates the Cosmos provider sending the txs through the
+// Babylon node of the given config, the same way as the Babylon client. The
+// txs are not sent through the Babylon client, as it only returns once the
+// tx is included, so that its broadcast cannot be told from its inclusion
+func newBBNProvider(cfg *bbncfg.BabylonConfig, logger *zap.Logger) (*cosmos.CosmosProvider, error) {
+	p, err := cfg.ToCosmosProviderConfig().NewProvider(logger, "", true, "babylon")
+	if err != nil {
+		return nil, err
+	}
+
+	cp, ok := p.(*cosmos.CosmosProvider)
+	if !ok {
+		return nil, fmt.Errorf("unexpected provider type %T", p)
+	}
+	cp.PCfg.KeyDirectory = cfg.KeyDirectory
+
+	encCfg := bbnapp.GetEncodingConfig()
+	cp.Cdc = cosmos.Codec{
+		InterfaceRegistry: encCfg.InterfaceRegistry,
+		Marshaler:         encCfg.Codec,
+		TxConfig:          encCfg.TxConfig,
+		Amino:             encCfg.Amino,
+	}
+
+	// the rpc client is created without connecting to the node
+	if err := cp.Init(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// reliablySendMsgsWith sends the given msgs in a tx through the given
+// provider and waits for the tx to be included in a block, as the
+// ReliablySendMsgs of the Babylon client does, while also recording when the
+// tx is accepted into the mempool
+func reliablySendMsgsWith(
+	ctx context.Context,
+	cp *cosmos.CosmosProvider,
+	msgs []sdk.Msg,
+	expectedErrs []*sdkErr.Error,
+	unrecoverableErrs []*sdkErr.Error,
+) (*sentTx, error) {
+	var (
+		rlyResp     *provider.RelayerTxResponse
+		callbackErr error
+		included    = make(chan struct{})
+	)
+
+	// the callback is only invoked once the tx is accepted into the mempool
+	callback := func(rtr *provider.RelayerTxResponse, err error) {
+		rlyResp = rtr
+		callbackErr = err
+		close(included)
+	}
+
+	relayerMsgs := bbnclient.ToProviderMsgs(msgs)
+
+	accepted := false
+	if err := retry.Do(func() error {
+		var sendErr error
+		if lockErr := accessKeyWithLock(cp, func() {
+			sendErr = cp.SendMessagesToMempool(ctx, relayerMsgs, "", ctx, []func(*provider.RelayerTxResponse, error){callback})
+		}); lockErr != nil {
+			return retry.Unrecoverable(lockErr)
+		}
+
+		switch {
+		case sendErr == nil:
+			accepted = true
+
+			return nil
+		case errorContained(sendErr, unrecoverableErrs):
+			return retry.Unrecoverable(sendErr)
+		case errorContained(sendErr, expectedErrs):
+			return nil
+		default:
+			return sendErr
+		}
+	}, retry.Context(ctx), retry.Attempts(sendTxAttempts), retry.Delay(sendTxRetryDelay), retry.LastErrorOnly(true)); err != nil {
+		return nil, err
+	}
+
+	if !accepted {
+		return &sentTx{}, nil
+	}
+
+	tx := &sentTx{broadcastAt: time.Now()}
+	<-included
+
+	if callbackErr != nil {
+		if errorContained(callbackErr, expectedErrs) {
+			return tx, nil
+		}
+
+		return nil, callbackErr
+	}
+
+	tx.res = rlyResp
+	if rlyResp != nil && rlyResp.Code != 0 {
+		return tx, fmt.Errorf("transaction failed with code: %d", rlyResp.Code)
+	}
+
+	return tx, nil
+}
+
+// accessKeyWithLock runs the given function accessing the keyring while
+// holding the same file lock as the Babylon client, so that the txs signed
+// with the key by the concurrent clients are sent one at a time
+func accessKeyWithLock(cp *cosmos.CosmosProvider, accessFunc func()) error {
+	lockFilePath := filepath.Join(cp.PCfg.KeyDirectory, "keys.lock")
+	lock := fslock.New(lockFilePath)
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to acquire file system lock (%s): %w", lockFilePath, err)
+	}
+
+	accessFunc()
+
+	if err := lock.Unlock(); err != nil {
+		return fmt.Errorf("error unlocking file system lock (%s), please manually delete", lockFilePath)
+	}
+
+	return nil
+}
+
+// errorContained returns true if the message of the given error contains one
+// of the given errors
+func errorContained(err error, errList []*sdkErr.Error) bool {
+	for _, e := range errList {
+		if strings.Contains(err.Error(), e.Error()) {
+			return true
+		}
+	}
+
+	return false
+}
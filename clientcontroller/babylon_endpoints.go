@@ -0,0 +1,421 @@
+package clientcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	bbnclient "github.com/babylonlabs-io/babylon/client/client"
+	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// unhealthyRetryDelay is the time after which an endpoint that failed a call
+// is tried again when the endpoints are not health checked
+const unhealthyRetryDelay = 30 * time.Second
+
+// bbnEndpoint is a Babylon node endpoint along with its client, the provider
+// sending the txs through it and the result of the last health check
+type bbnEndpoint struct {
+	rpcAddr  string
+	client   *bbnclient.Client
+	provider *cosmos.CosmosProvider
+
+	healthy      bool
+	latestHeight int64
+	catchingUp   bool
+	latency      time.Duration
+	// retryAt is when the endpoint is tried again after failing a call if
+	// the endpoints are not health checked
+	retryAt time.Time
+}
+
+// bbnEndpointPool keeps track of the health of the configured Babylon
+// endpoints and selects the one in use
+type bbnEndpointPool struct {
+	mu        sync.RWMutex
+	endpoints []*bbnEndpoint
+	active    int
+	// healthChecked is whether the health check loop is running, which is
+	// otherwise replaced by trying the failed endpoints after retryDelay
+	healthChecked bool
+	retryDelay    time.Duration
+
+	cfg     *fpcfg.BBNConfig
+	metrics *metrics.FpMetrics
+	logger  *zap.Logger
+
+	wg       sync.WaitGroup
+	quit     chan struct{}
+	quitOnce sync.Once
+}
+
+func newBBNEndpointPool(
+	cfg *fpcfg.BBNConfig,
+	metrics *metrics.FpMetrics,
+	logger *zap.Logger,
+) (*bbnEndpointPool, error) {
+	rpcAddrs := append([]string{cfg.RPCAddr}, cfg.BackupRPCAddrs...)
+	endpoints := make([]*bbnEndpoint, 0, len(rpcAddrs))
+	// the clients created so far are stopped if one cannot be created
+	stopClients := func(err error) error {
+		for _, ep := range endpoints {
+			if ep.client.IsRunning() {
+				err = errors.Join(err, ep.client.Stop())
+			}
+		}
+
+		return err
+	}
+	for i, rpcAddr := range rpcAddrs {
+		bbnConfig := fpcfg.BBNConfigToBabylonConfig(cfg)
+		bbnConfig.RPCAddr = rpcAddr
+		// the backup grpc servers, if any, pair with the backup rpc servers
+		if i > 0 && len(cfg.BackupGRPCAddrs) > 0 {
+			bbnConfig.GRPCAddr = cfg.BackupGRPCAddrs[i-1]
+		}
+
+		cp, err := newBBNProvider(&bbnConfig, logger)
+		if err != nil {
+			return nil, stopClients(fmt.Errorf("failed to create Babylon provider for %s: %w", rpcAddr, err))
+		}
+
+		c, err := bbnclient.New(&bbnConfig, logger)
+		if err != nil {
+			return nil, stopClients(fmt.Errorf("failed to create Babylon client for %s: %w", rpcAddr, err))
+		}
+
+		endpoints = append(endpoints, &bbnEndpoint{
+			rpcAddr:  rpcAddr,
+			client:   c,
+			provider: cp,
+			healthy:  true,
+		})
+	}
+
+	p := &bbnEndpointPool{
+		endpoints:  endpoints,
+		retryDelay: unhealthyRetryDelay,
+		cfg:        cfg,
+		metrics:    metrics,
+		logger:     logger,
+		quit:       make(chan struct{}),
+	}
+
+	for i, ep := range endpoints {
+		p.metrics.RecordBabylonActiveEndpoint(ep.rpcAddr, i == p.active)
+	}
+
+	return p, nil
+}
+
+// start starts the health check loop if there is more than one endpoint
+func (p *bbnEndpointPool) start() {
+	if len(p.endpoints) < 2 || p.cfg.HealthCheckInterval <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	p.healthChecked = true
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go p.healthCheckLoop()
+}
+
+// stop stops the health check loop and the clients of all the endpoints
+func (p *bbnEndpointPool) stop() error {
+	p.quitOnce.Do(func() { close(p.quit) })
+	p.wg.Wait()
+
+	var errs []error
+	for _, ep := range p.endpoints {
+		if !ep.client.IsRunning() {
+			continue
+		}
+		if err := ep.client.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop the client of %s: %w", ep.rpcAddr, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// activeClient returns the client of the endpoint in use
+func (p *bbnEndpointPool) activeClient() *bbnclient.Client {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.endpoints[p.active].client
+}
+
+// candidates returns the endpoint in use followed by the other healthy
+// endpoints in the order of preference. Without health checks, the endpoints
+// which failed a call are healthy again after retryDelay, so that the more
+// preferred ones are used again once they recover
+func (p *bbnEndpointPool) candidates() []*bbnEndpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.healthChecked {
+		p.retryFailedLocked()
+	}
+
+	candidates := []*bbnEndpoint{p.endpoints[p.active]}
+	for i, ep := range p.endpoints {
+		if i != p.active && ep.healthy {
+			candidates = append(candidates, ep)
+		}
+	}
+
+	return candidates
+}
+
+// markUnhealthy marks the given endpoint as unhealthy and switches to another
+// endpoint if it was the one in use
+func (p *bbnEndpointPool) markUnhealthy(ep *bbnEndpoint) {
+	if len(p.endpoints) < 2 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ep.healthy = false
+	ep.retryAt = time.Now().Add(p.retryDelay)
+	p.metrics.RecordBabylonEndpointHealth(ep.rpcAddr, false, ep.latency)
+	p.selectActiveLocked()
+}
+
+// retryFailedLocked marks the endpoints that failed a call more than
+// retryDelay ago as healthy, and switches back to the most preferred one
+func (p *bbnEndpointPool) retryFailedLocked() {
+	now := time.Now()
+	retried := false
+	for _, ep := range p.endpoints {
+		if ep.healthy || ep.retryAt.IsZero() || now.Before(ep.retryAt) {
+			continue
+		}
+
+		p.logger.Debug("retrying the Babylon rpc endpoint", zap.String("rpc_address", ep.rpcAddr))
+		ep.healthy = true
+		ep.retryAt = time.Time{}
+		p.metrics.RecordBabylonEndpointHealth(ep.rpcAddr, true, ep.latency)
+		retried = true
+	}
+
+	if retried {
+		p.selectActiveLocked()
+	}
+}
+
+// selectActiveLocked selects the first healthy endpoint in the order of
+// preference; the endpoint in use is kept if none of them is healthy
+func (p *bbnEndpointPool) selectActiveLocked() {
+	for i, ep := range p.endpoints {
+		if !ep.healthy {
+			continue
+		}
+		if i == p.active {
+			return
+		}
+
+		p.logger.Warn("switching the Babylon rpc endpoint",
+			zap.String("from", p.endpoints[p.active].rpcAddr),
+			zap.String("to", ep.rpcAddr),
+		)
+		p.metrics.RecordBabylonActiveEndpoint(p.endpoints[p.active].rpcAddr, false)
+		p.metrics.RecordBabylonActiveEndpoint(ep.rpcAddr, true)
+		p.metrics.IncrementBabylonEndpointFailover()
+		p.active = i
+
+		return
+	}
+
+	p.logger.Error("no healthy Babylon rpc endpoint, keep using the current one",
+		zap.String("rpc_address", p.endpoints[p.active].rpcAddr))
+}
+
+func (p *bbnEndpointPool) healthCheckLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.checkHealth()
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// checkHealth queries the status of all the endpoints and updates their
+// health. An endpoint is healthy if it is reachable, not catching up and
+// does not lag more than MaxBlockLag blocks behind the highest endpoint
+func (p *bbnEndpointPool) checkHealth() {
+	type statusResult struct {
+		latestHeight int64
+		catchingUp   bool
+		latency      time.Duration
+		err          error
+	}
+
+	results := make([]statusResult, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range p.endpoints {
+		wg.Add(1)
+		go func(i int, ep *bbnEndpoint) {
+			defer wg.Done()
+
+			ctx, cancel := getContextWithCancel(p.cfg.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			res, err := ep.client.RPCClient.Status(ctx)
+			results[i].latency = time.Since(start)
+			if err != nil {
+				results[i].err = err
+
+				return
+			}
+			results[i].latestHeight = res.SyncInfo.LatestBlockHeight
+			results[i].catchingUp = res.SyncInfo.CatchingUp
+		}(i, ep)
+	}
+	wg.Wait()
+
+	var maxHeight int64
+	for _, res := range results {
+		if res.err == nil && res.latestHeight > maxHeight {
+			maxHeight = res.latestHeight
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, ep := range p.endpoints {
+		res := results[i]
+		ep.latency = res.latency
+		ep.latestHeight = res.latestHeight
+		ep.catchingUp = res.catchingUp
+		ep.healthy = res.err == nil && !res.catchingUp && uint64(maxHeight-res.latestHeight) <= p.cfg.MaxBlockLag
+
+		if !ep.healthy {
+			p.logger.Debug("the Babylon rpc endpoint is unhealthy",
+				zap.String("rpc_address", ep.rpcAddr),
+				zap.Int64("latest_height", res.latestHeight),
+				zap.Int64("highest_height", maxHeight),
+				zap.Bool("catching_up", res.catchingUp),
+				zap.Duration("latency", res.latency),
+				zap.Error(res.err),
+			)
+		}
+
+		p.metrics.RecordBabylonEndpointHealth(ep.rpcAddr, ep.healthy, ep.latency)
+	}
+
+	p.selectActiveLocked()
+}
+
+// withFailover runs the given query against the endpoint in use and
+// retries it against the other healthy endpoints if the node cannot be
+// reached; errors returned by the node itself are returned classified by
+// ClassifyError. No further endpoint is tried once the given context is done
+func withFailover[T any](ctx context.Context, p *bbnEndpointPool, f func(c *bbnclient.Client) (T, error)) (T, error) {
+	return failover(ctx, p, func(ep *bbnEndpoint) (T, error) {
+		return f(ep.client)
+	}, isEndpointFailure)
+}
+
+// withBroadcastFailover runs the given function sending a tx against the
+// endpoint in use, and only retries it against the other healthy endpoints
+// if the node could not be dialed. Otherwise, e.g., upon a timeout, the tx
+// may have been sent, and sending it again through another node would
+// duplicate it or fail on the account sequence
+func withBroadcastFailover[T any](ctx context.Context, p *bbnEndpointPool, f func(ep *bbnEndpoint) (T, error)) (T, error) {
+	return failover(ctx, p, f, isDialFailure)
+}
+
+// failover runs the given function against the candidate endpoints until it
+// succeeds or fails with an error that is not an endpoint failure according
+// to the given predicate
+func failover[T any](
+	ctx context.Context,
+	p *bbnEndpointPool,
+	f func(ep *bbnEndpoint) (T, error),
+	isFailure func(err error) bool,
+) (T, error) {
+	var (
+		res T
+		err error
+	)
+	for _, ep := range p.candidates() {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return res, errors.Join(ctxErr, err)
+		}
+
+		res, err = f(ep)
+		// the endpoint is not to blame if the caller gave up on the request
+		if err == nil || ctx.Err() != nil || !isFailure(err) {
+			return res, ClassifyError(err)
+		}
+
+		p.logger.Warn("failed to reach the Babylon rpc endpoint",
+			zap.String("rpc_address", ep.rpcAddr),
+			zap.Error(err),
+		)
+		p.markUnhealthy(ep)
+	}
+
+	return res, err
+}
+
+// isEndpointFailure returns true if the error indicates that the node could
+// not be reached rather than the request being rejected by the node
+func isEndpointFailure(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s.Code() == codes.Unavailable || s.Code() == codes.DeadlineExceeded
+	}
+
+	return false
+}
+
+// isDialFailure returns true if the error indicates that no connection to the
+// node could be made, so that nothing was sent to it
+func isDialFailure(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+
+	return errors.As(err, &dnsErr)
+}
@@ -0,0 +1,266 @@
+package clientcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// standInNode is a local stand-in for a Babylon node which answers the
+// comet rpc methods used by the endpoint pool
+type standInNode struct {
+	*httptest.Server
+	height     *atomic.Int64
+	catchingUp *atomic.Bool
+	// down closes the connections once the requests are received
+	down *atomic.Bool
+	// stalled holds the abci queries until the requests are cancelled
+	stalled *atomic.Bool
+}
+
+func newStandInNode(t *testing.T, height int64) *standInNode {
+	n := &standInNode{
+		height:     atomic.NewInt64(height),
+		catchingUp: atomic.NewBool(false),
+		down:       atomic.NewBool(false),
+		stalled:    atomic.NewBool(false),
+	}
+	n.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if n.down.Load() {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+
+			return
+		}
+
+		var rpcReq rpctypes.RPCRequest
+		if err := json.NewDecoder(req.Body).Decode(&rpcReq); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if n.stalled.Load() && rpcReq.Method == "abci_query" {
+			<-req.Context().Done()
+
+			return
+		}
+
+		var res rpctypes.RPCResponse
+		switch rpcReq.Method {
+		case "status":
+			res = rpctypes.NewRPCSuccessResponse(rpcReq.ID, &coretypes.ResultStatus{
+				SyncInfo: coretypes.SyncInfo{
+					LatestBlockHeight: n.height.Load(),
+					CatchingUp:        n.catchingUp.Load(),
+				},
+			})
+		case "blockchain":
+			res = rpctypes.NewRPCSuccessResponse(rpcReq.ID, &coretypes.ResultBlockchainInfo{
+				LastHeight: n.height.Load(),
+				BlockMetas: []*cmttypes.BlockMeta{{Header: cmttypes.Header{Height: n.height.Load()}}},
+			})
+		default:
+			res = rpctypes.RPCMethodNotFoundError(rpcReq.ID)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(n.Close)
+
+	return n
+}
+
+func newTestControllerWithNodes(t *testing.T, r *rand.Rand, primary *standInNode, backups ...*standInNode) *BabylonController {
+	bc, err := newTestBabylonController(t, r, func(cfg *fpcfg.BBNConfig) {
+		cfg.RPCAddr = primary.URL
+		for _, b := range backups {
+			cfg.BackupRPCAddrs = append(cfg.BackupRPCAddrs, b.URL)
+		}
+		// health checks are triggered manually
+		cfg.HealthCheckInterval = 0
+		cfg.MaxBlockLag = 5
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, bc.Close())
+	})
+
+	return bc
+}
+
+func TestEndpointPoolHealthCheck(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	primary := newStandInNode(t, 100)
+	backup := newStandInNode(t, 100)
+	bc := newTestControllerWithNodes(t, r, primary, backup)
+	pool := bc.endpoints
+
+	pool.checkHealth()
+	require.Equal(t, 0, pool.active)
+
+	// the primary lags too far behind the backup
+	backup.height.Store(110)
+	pool.checkHealth()
+	require.Equal(t, 1, pool.active)
+	require.False(t, pool.endpoints[0].healthy)
+
+	// the primary catches up and is preferred again
+	primary.height.Store(110)
+	pool.checkHealth()
+	require.Equal(t, 0, pool.active)
+
+	// the primary is catching up
+	primary.catchingUp.Store(true)
+	pool.checkHealth()
+	require.Equal(t, 1, pool.active)
+
+	// the backup goes down while the primary is still catching up,
+	// the backup is kept as there is no healthy endpoint
+	backup.Close()
+	pool.checkHealth()
+	require.Equal(t, 1, pool.active)
+	require.False(t, pool.endpoints[0].healthy)
+	require.False(t, pool.endpoints[1].healthy)
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	primary := newStandInNode(t, 100)
+	backup := newStandInNode(t, 120)
+	bc := newTestControllerWithNodes(t, r, primary, backup)
+
+	block, err := bc.QueryBestBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), block.Height)
+
+	// queries fail over to the backup once the primary is unreachable
+	primary.Close()
+	block, err = bc.QueryBestBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(120), block.Height)
+	require.Equal(t, 1, bc.endpoints.active)
+
+	// errors returned by a reachable node do not trigger failover
+	_, err = bc.QueryActivatedHeight(context.Background())
+	require.Error(t, err)
+	require.False(t, isEndpointFailure(err))
+	require.Equal(t, 1, bc.endpoints.active)
+}
+
+func TestEndpointPoolCancelledContext(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	primary := newStandInNode(t, 100)
+	backup := newStandInNode(t, 100)
+	bc := newTestControllerWithNodes(t, r, primary, backup)
+
+	// a cancelled request neither reaches a node nor triggers failover
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := bc.QueryBestBlock(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, bc.endpoints.active)
+	require.True(t, bc.endpoints.endpoints[0].healthy)
+}
+
+func TestQueryCallerContext(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	primary := newStandInNode(t, 100)
+	bc, err := newTestBabylonController(t, r, func(cfg *fpcfg.BBNConfig) {
+		cfg.RPCAddr = primary.URL
+		cfg.Timeout = time.Minute
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, bc.Close())
+	})
+
+	// the query is given up once the caller's context is done, well before
+	// the configured timeout
+	primary.stalled.Store(true)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = bc.QueryBlock(ctx, 10)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestEndpointPoolRetryWithoutHealthChecks(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	primary := newStandInNode(t, 100)
+	backup := newStandInNode(t, 120)
+	bc := newTestControllerWithNodes(t, r, primary, backup)
+	bc.endpoints.retryDelay = time.Hour
+
+	primary.down.Store(true)
+	block, err := bc.QueryBestBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(120), block.Height)
+	require.Equal(t, 1, bc.endpoints.active)
+
+	// the primary is not used again until the retry delay elapses
+	primary.down.Store(false)
+	block, err = bc.QueryBestBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(120), block.Height)
+
+	bc.endpoints.mu.Lock()
+	bc.endpoints.endpoints[0].retryAt = time.Now()
+	bc.endpoints.mu.Unlock()
+	block, err = bc.QueryBestBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), block.Height)
+	require.Equal(t, 0, bc.endpoints.active)
+}
+
+func TestEndpointPoolBroadcastFailover(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	primary := newStandInNode(t, 100)
+	backup := newStandInNode(t, 120)
+	bc := newTestControllerWithNodes(t, r, primary, backup)
+	status := func(ep *bbnEndpoint) (*coretypes.ResultStatus, error) {
+		return ep.client.RPCClient.Status(context.Background())
+	}
+
+	// the request may have been received by the node, so it is not sent to
+	// another one
+	primary.down.Store(true)
+	_, err := withBroadcastFailover(context.Background(), bc.endpoints, status)
+	require.Error(t, err)
+	require.False(t, isDialFailure(err))
+	require.Equal(t, 0, bc.endpoints.active)
+
+	// no connection could be made to the node
+	primary.Close()
+	res, err := withBroadcastFailover(context.Background(), bc.endpoints, status)
+	require.NoError(t, err)
+	require.Equal(t, int64(120), res.SyncInfo.LatestBlockHeight)
+	require.Equal(t, 1, bc.endpoints.active)
+}
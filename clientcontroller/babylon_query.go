@@ -0,0 +1,105 @@
+package clientcontroller
+
+import (
+	"context"
+	"fmt"
+
+	bbnclient "github.com/babylonlabs-io/babylon/client/client"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queryCodec encodes the queries the same way as the client context of the
+// Cosmos SDK without a codec
+var queryCodec = codec.NewProtoCodec(codectypes.NewInterfaceRegistry()).GRPCCodec()
+
+// abciQueryConn is a gRPC client connection running the queries as abci
+// queries through the comet rpc client of a Babylon node. Unlike the client
+// context of the Cosmos SDK, which the query functions of the Babylon client
+// use, the rpc is made with the context of the query, so that it is given up
+// once the context is done
+type abciQueryConn struct {
+	rpc rpcclient.Client
+}
+
+func (c abciQueryConn) Invoke(ctx context.Context, method string, req, reply interface{}, _ ...grpc.CallOption) error {
+	reqBz, err := queryCodec.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.rpc.ABCIQueryWithOptions(ctx, method, reqBz, rpcclient.ABCIQueryOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !res.Response.IsOK() {
+		return abciQueryError(res.Response.Code, res.Response.Log)
+	}
+
+	return queryCodec.Unmarshal(res.Response.Value, reply)
+}
+
+func (abciQueryConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, fmt.Errorf("streaming rpc not supported")
+}
+
+// abciQueryError converts the error of a failed abci query to a gRPC error
+// the same way as the client context of the Cosmos SDK
+func abciQueryError(code uint32, log string) error {
+	switch code {
+	case sdkerrors.ErrInvalidRequest.ABCICode():
+		return status.Error(codes.InvalidArgument, log)
+	case sdkerrors.ErrUnauthorized.ABCICode():
+		return status.Error(codes.Unauthenticated, log)
+	case sdkerrors.ErrKeyNotFound.ABCICode():
+		return status.Error(codes.NotFound, log)
+	default:
+		return status.Error(codes.Unknown, log)
+	}
+}
+
+// queryFinality runs the given query of the finality module with the context
+// of the caller bounded by the configured timeout, failing over the endpoints.
+// The query functions of the Babylon client are not used as they bound the
+// queries by a timeout context of their own, ignoring the caller's
+func queryFinality[T any](
+	ctx context.Context,
+	bc *BabylonController,
+	f func(ctx context.Context, queryClient finalitytypes.QueryClient) (T, error),
+) (T, error) {
+	return withFailover(ctx, bc.endpoints, func(c *bbnclient.Client) (T, error) {
+		queryCtx, cancel := context.WithTimeout(ctx, bc.cfg.Timeout)
+		defer cancel()
+
+		return f(queryCtx, finalitytypes.NewQueryClient(abciQueryConn{rpc: c.RPCClient}))
+	})
+}
+
+// queryBTCStaking is queryFinality for the queries of the btcstaking module
+func queryBTCStaking[T any](
+	ctx context.Context,
+	bc *BabylonController,
+	f func(ctx context.Context, queryClient btcstakingtypes.QueryClient) (T, error),
+) (T, error) {
+	return withFailover(ctx, bc.endpoints, func(c *bbnclient.Client) (T, error) {
+		queryCtx, cancel := context.WithTimeout(ctx, bc.cfg.Timeout)
+		defer cancel()
+
+		return f(queryCtx, btcstakingtypes.NewQueryClient(abciQueryConn{rpc: c.RPCClient}))
+	})
+}
+
+// queryFinalityProvider queries the finality provider with the given public key
+func (bc *BabylonController) queryFinalityProvider(ctx context.Context, fpPkHex string) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	return queryBTCStaking(ctx, bc, func(ctx context.Context, queryClient btcstakingtypes.QueryClient) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+		return queryClient.FinalityProvider(ctx, &btcstakingtypes.QueryFinalityProviderRequest{FpBtcPkHex: fpPkHex})
+	})
+}
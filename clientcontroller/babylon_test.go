@@ -0,0 +1,63 @@
+package clientcontroller
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/chaincfg"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+func newTestBabylonController(t *testing.T, r *rand.Rand, modifyCfg func(cfg *fpcfg.BBNConfig)) (*BabylonController, error) {
+	cfg := fpcfg.DefaultBBNConfig()
+	cfg.Key = testutil.GenRandomHexStr(r, 4)
+	cfg.KeyDirectory = t.TempDir()
+	cfg.KeyringBackend = "test"
+	modifyCfg(&cfg)
+	_, err := testutil.CreateChainKey(cfg.KeyDirectory, cfg.ChainID, cfg.Key, cfg.KeyringBackend, "", "", "")
+	require.NoError(t, err)
+
+	return NewBabylonController(&cfg, &chaincfg.SigNetParams, testutil.GetTestLogger(t))
+}
+
+func TestBabylonControllerAuthzExec(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	// without a granter the msgs are sent as they are
+	bc, err := newTestBabylonController(t, r, func(_ *fpcfg.BBNConfig) {})
+	require.NoError(t, err)
+	msgs := []sdk.Msg{&finalitytypes.MsgUnjailFinalityProvider{Signer: bc.mustGetFpSigner()}}
+	require.Equal(t, bc.mustGetTxSigner(), bc.mustGetFpSigner())
+	require.Equal(t, msgs, bc.wrapWithAuthzExec(msgs))
+
+	// with a granter the msgs are wrapped in a single MsgExec from the key
+	granter := datagen.GenRandomAccount().Address
+	bc, err = newTestBabylonController(t, r, func(cfg *fpcfg.BBNConfig) { cfg.AuthzGranter = granter })
+	require.NoError(t, err)
+	require.Equal(t, granter, bc.mustGetFpSigner())
+	msgs = []sdk.Msg{
+		&finalitytypes.MsgAddFinalitySig{Signer: bc.mustGetFpSigner(), BlockHeight: 1},
+		&finalitytypes.MsgAddFinalitySig{Signer: bc.mustGetFpSigner(), BlockHeight: 2},
+	}
+	wrapped := bc.wrapWithAuthzExec(msgs)
+	require.Len(t, wrapped, 1)
+	execMsg, ok := wrapped[0].(*authz.MsgExec)
+	require.True(t, ok)
+	require.Equal(t, bc.mustGetTxSigner(), execMsg.Grantee)
+	require.Len(t, execMsg.Msgs, len(msgs))
+	for i, anyMsg := range execMsg.Msgs {
+		require.Equal(t, sdk.MsgTypeURL(msgs[i]), anyMsg.TypeUrl)
+	}
+
+	// an invalid granter is rejected
+	_, err = newTestBabylonController(t, r, func(cfg *fpcfg.BBNConfig) { cfg.AuthzGranter = "invalid" })
+	require.Error(t, err)
+}
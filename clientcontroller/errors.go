@@ -0,0 +1,213 @@
+package clientcontroller
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdkErr "cosmossdk.io/errors"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// the kinds of the errors returned by the consumer chain, which are matched
+// with errors.Is against the errors returned by the ClientController
+var (
+	// ErrRetryable is matched by the errors that may be resolved by retrying
+	ErrRetryable = errors.New("retryable consumer chain error")
+	// ErrUnrecoverable is matched by the errors that indicate something
+	// critical in the finality provider program or the consumer chain
+	ErrUnrecoverable = errors.New("unrecoverable consumer chain error")
+	// ErrFpJailed is matched by the errors rejecting a jailed finality provider
+	ErrFpJailed = errors.New("the finality provider is jailed")
+	// ErrFpSlashed is matched by the errors rejecting a slashed finality provider
+	ErrFpSlashed = errors.New("the finality provider is slashed")
+	// ErrInsufficientFee is matched by the errors rejecting a tx for its fee
+	ErrInsufficientFee = errors.New("insufficient fee")
+	// ErrSequenceMismatch is matched by the errors rejecting a tx for the
+	// sequence of the signer account
+	ErrSequenceMismatch = errors.New("account sequence mismatch")
+)
+
+// errorKinds are the kinds of the registered errors of the consumer chain.
+// The registered errors of the consumer chain that are not listed are
+// retryable
+var errorKinds = map[*sdkErr.Error][]error{
+	finalitytypes.ErrDuplicatedFinalitySig: {ExpectedError{}},
+
+	finalitytypes.ErrBlockNotFound:      {ErrUnrecoverable},
+	finalitytypes.ErrInvalidFinalitySig: {ErrUnrecoverable},
+	finalitytypes.ErrNoPubRandYet:       {ErrUnrecoverable},
+	finalitytypes.ErrPubRandNotFound:    {ErrUnrecoverable},
+	finalitytypes.ErrTooFewPubRand:      {ErrUnrecoverable},
+
+	btcstakingtypes.ErrFpAlreadyJailed:  {ErrFpJailed, ErrUnrecoverable},
+	btcstakingtypes.ErrFpAlreadySlashed: {ErrFpSlashed, ErrUnrecoverable},
+
+	sdkerrors.ErrInsufficientFee:   {ErrInsufficientFee, ErrRetryable},
+	sdkerrors.ErrInsufficientFunds: {ErrInsufficientFee, ErrRetryable},
+	sdkerrors.ErrWrongSequence:     {ErrSequenceMismatch, ErrRetryable},
+}
+
+// babylonCodespaces are the codespaces of the Babylon modules the finality
+// provider interacts with
+var babylonCodespaces = []string{finalitytypes.ModuleName, btcstakingtypes.ModuleName}
+
+// maxRegisteredCode is the highest ABCI code looked up in the codespaces of
+// the Babylon modules
+const maxRegisteredCode = 2000
+
+// codeRegexp matches the codespace and code of a failed tx that are left in
+// its error message, e.g., "codespace: finality, code: 1108"
+var codeRegexp = regexp.MustCompile(`codespace:?\s+(\w+),?\s+code:?\s+(\d+)`)
+
+// ChainError is an error returned by the consumer chain with the codespace
+// and ABCI code it is registered with, which also matches its kinds
+type ChainError struct {
+	Codespace string
+	Code      uint32
+
+	err        error
+	registered *sdkErr.Error
+	kinds      []error
+}
+
+func (e *ChainError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ChainError) Unwrap() []error {
+	return append([]error{e.err, e.registered}, e.kinds...)
+}
+
+// ClassifyError decodes the registered error of the consumer chain carried
+// by the given error, and returns it as a ChainError matching the kinds of
+// the registered error. Errors that do not come from the consumer chain are
+// returned as they are
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var chainErr *ChainError
+	if errors.As(err, &chainErr) {
+		return err
+	}
+
+	registered := decodeRegisteredError(err)
+	if registered == nil {
+		return err
+	}
+
+	kinds, ok := errorKinds[registered]
+	if !ok {
+		kinds = []error{ErrRetryable}
+	}
+
+	return &ChainError{
+		Codespace:  registered.Codespace(),
+		Code:       registered.ABCICode(),
+		err:        err,
+		registered: registered,
+		kinds:      kinds,
+	}
+}
+
+// decodeRegisteredError returns the registered error carried by the given
+// error, or nil if there is none. The tx responses carry the codespace and
+// code of the error, while the queries and the simulations of txs only carry
+// its log, in which case the registered errors of the Babylon modules are
+// looked up by their descriptions
+func decodeRegisteredError(err error) *sdkErr.Error {
+	var registered *sdkErr.Error
+	if errors.As(err, &registered) {
+		return lookupRegisteredError(registered.Codespace(), registered.ABCICode())
+	}
+
+	msg := err.Error()
+	if m := codeRegexp.FindStringSubmatch(msg); m != nil {
+		code, parseErr := strconv.ParseUint(m[2], 10, 32)
+		if parseErr == nil {
+			if registered := lookupRegisteredError(m[1], uint32(code)); registered != nil {
+				return registered
+			}
+		}
+	}
+
+	// the longest description is the most specific match
+	for _, e := range describedErrors() {
+		if strings.Contains(msg, e.Error()) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// lookupRegisteredError returns the error registered with the given codespace
+// and code, or nil if there is none
+func lookupRegisteredError(codespace string, code uint32) *sdkErr.Error {
+	var registered *sdkErr.Error
+	if !errors.As(sdkErr.ABCIError(codespace, code, ""), &registered) {
+		return nil
+	}
+
+	// an unregistered code is returned as an error of its own
+	if registered.Codespace() != codespace || registered.ABCICode() != code || registered.Error() == "unknown" {
+		return nil
+	}
+
+	return registered
+}
+
+var (
+	describedErrorsOnce sync.Once
+	describedErrs       []*sdkErr.Error
+)
+
+// describedErrors returns the registered errors that are looked up by their
+// descriptions, i.e., the errors of the Babylon modules and the classified
+// errors, from the longest description to the shortest
+func describedErrors() []*sdkErr.Error {
+	describedErrorsOnce.Do(func() {
+		seen := make(map[*sdkErr.Error]bool)
+		for _, codespace := range babylonCodespaces {
+			for _, e := range RegisteredErrors(codespace) {
+				seen[e] = true
+			}
+		}
+		for e := range errorKinds {
+			seen[e] = true
+		}
+
+		for e := range seen {
+			describedErrs = append(describedErrs, e)
+		}
+		sort.Slice(describedErrs, func(i, j int) bool {
+			a, b := describedErrs[i].Error(), describedErrs[j].Error()
+			if len(a) != len(b) {
+				return len(a) > len(b)
+			}
+
+			return a < b
+		})
+	})
+
+	return describedErrs
+}
+
+// RegisteredErrors returns the errors registered in the given codespace
+func RegisteredErrors(codespace string) []*sdkErr.Error {
+	var errs []*sdkErr.Error
+	for code := uint32(1); code <= maxRegisteredCode; code++ {
+		if e := lookupRegisteredError(codespace, code); e != nil {
+			errs = append(errs, e)
+		}
+	}
+
+	return errs
+}
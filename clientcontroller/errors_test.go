@@ -0,0 +1,144 @@
+package clientcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	sdkErr "cosmossdk.io/errors"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var allKinds = []error{
+	ExpectedError{},
+	ErrRetryable,
+	ErrUnrecoverable,
+	ErrFpJailed,
+	ErrFpSlashed,
+	ErrInsufficientFee,
+	ErrSequenceMismatch,
+}
+
+// expectedKinds are the kinds of the classified registered errors; the other
+// registered errors are retryable
+var expectedKinds = map[*sdkErr.Error][]error{
+	finalitytypes.ErrDuplicatedFinalitySig: {ExpectedError{}},
+	finalitytypes.ErrBlockNotFound:         {ErrUnrecoverable},
+	finalitytypes.ErrInvalidFinalitySig:    {ErrUnrecoverable},
+	finalitytypes.ErrNoPubRandYet:          {ErrUnrecoverable},
+	finalitytypes.ErrPubRandNotFound:       {ErrUnrecoverable},
+	finalitytypes.ErrTooFewPubRand:         {ErrUnrecoverable},
+	btcstakingtypes.ErrFpAlreadyJailed:     {ErrFpJailed, ErrUnrecoverable},
+	btcstakingtypes.ErrFpAlreadySlashed:    {ErrFpSlashed, ErrUnrecoverable},
+	sdkerrors.ErrInsufficientFee:           {ErrInsufficientFee, ErrRetryable},
+	sdkerrors.ErrInsufficientFunds:         {ErrInsufficientFee, ErrRetryable},
+	sdkerrors.ErrWrongSequence:             {ErrSequenceMismatch, ErrRetryable},
+}
+
+// responseErrors returns the forms in which the registered error is returned
+// by the Babylon client
+func responseErrors(e *sdkErr.Error) map[string]error {
+	return map[string]error{
+		// the relayer returns the registered error of a failed tx
+		"tx":         e,
+		"wrapped tx": fmt.Errorf("failed to submit the tx: %w", e.Wrapf("height %d", 100)),
+		// the relayer leaves the codespace and code in the message of an
+		// error it does not know
+		"tx log": fmt.Errorf("transaction failed to execute: codespace: %s, code: %d, log: %s",
+			e.Codespace(), e.ABCICode(), "failed to execute message"),
+		// the queries and tx simulations only carry the log of the error
+		"query": fmt.Errorf("failed to query: %w",
+			status.Error(codes.Unknown, e.Wrapf("finality provider %s", "abcd").Error())),
+	}
+}
+
+func requireClassified(t *testing.T, e *sdkErr.Error, err error) {
+	classified := ClassifyError(err)
+
+	var chainErr *ChainError
+	require.ErrorAs(t, classified, &chainErr)
+	require.Equal(t, e.Codespace(), chainErr.Codespace)
+	require.Equal(t, e.ABCICode(), chainErr.Code)
+	require.ErrorIs(t, classified, e)
+	require.Equal(t, err.Error(), classified.Error())
+
+	kinds, ok := expectedKinds[e]
+	if !ok {
+		kinds = []error{ErrRetryable}
+	}
+	for _, kind := range allKinds {
+		var isKind bool
+		for _, k := range kinds {
+			isKind = isKind || k == kind
+		}
+		require.Equal(t, isKind, errors.Is(classified, kind), "kind %v", kind)
+	}
+	require.Equal(t, IsUnrecoverable(classified), errors.Is(classified, ErrUnrecoverable))
+	require.Equal(t, IsExpected(classified), errors.Is(classified, ExpectedError{}))
+
+	// the classification is kept once the error is wrapped again
+	wrapped := fmt.Errorf("failed to submit finality signatures: %w", classified)
+	require.Same(t, classified, ClassifyError(classified))
+	require.ErrorIs(t, ClassifyError(wrapped), e)
+}
+
+func TestClassifyRegisteredBabylonErrors(t *testing.T) {
+	t.Parallel()
+	for _, codespace := range babylonCodespaces {
+		registered := RegisteredErrors(codespace)
+		require.NotEmpty(t, registered)
+
+		for _, e := range registered {
+			for form, err := range responseErrors(e) {
+				t.Run(fmt.Sprintf("%s/%d/%s", codespace, e.ABCICode(), form), func(t *testing.T) {
+					t.Parallel()
+					requireClassified(t, e, err)
+				})
+			}
+		}
+	}
+}
+
+func TestClassifyTxErrors(t *testing.T) {
+	t.Parallel()
+	for _, e := range []*sdkErr.Error{
+		sdkerrors.ErrInsufficientFee,
+		sdkerrors.ErrInsufficientFunds,
+		sdkerrors.ErrWrongSequence,
+	} {
+		for form, err := range responseErrors(e) {
+			t.Run(fmt.Sprintf("%s/%d/%s", e.Codespace(), e.ABCICode(), form), func(t *testing.T) {
+				t.Parallel()
+				requireClassified(t, e, err)
+			})
+		}
+	}
+
+	// the other errors of the sdk are retryable once their code is known
+	requireClassified(t, sdkerrors.ErrOutOfGas, sdkerrors.ErrOutOfGas.Wrap("out of gas in location: WriteFlat"))
+}
+
+func TestClassifyOtherErrors(t *testing.T) {
+	t.Parallel()
+	for _, err := range []error{
+		errors.New("connection refused"),
+		context.Canceled,
+		status.Error(codes.Unavailable, "the node is unavailable"),
+		fmt.Errorf("transaction failed to execute: codespace: unknownmodule, code: 3, log: %s", "unknown"),
+		Expected(errors.New("some error")),
+	} {
+		classified := ClassifyError(err)
+		require.Equal(t, err, classified)
+
+		var chainErr *ChainError
+		require.False(t, errors.As(classified, &chainErr))
+		require.False(t, IsUnrecoverable(classified))
+	}
+	require.NoError(t, ClassifyError(nil))
+}
@@ -0,0 +1,141 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+)
+
+// maxEntrySize is the maximum size of a line of a recording
+const maxEntrySize = 64 * 1024 * 1024
+
+// Entry is a recorded call to the consumer chain, which is written as a line
+// of JSON to the recording
+type Entry struct {
+	Time     time.Time       `json:"time"`
+	Method   string          `json:"method"`
+	Request  Request         `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// Request is the arguments identifying a recorded call. The keys,
+// randomness and signatures of the finality provider are left out, so that
+// the recording can be replayed by a finality provider with other keys
+type Request struct {
+	Height      uint64   `json:"height,omitempty"`
+	StartHeight uint64   `json:"start_height,omitempty"`
+	EndHeight   uint64   `json:"end_height,omitempty"`
+	Count       uint64   `json:"count,omitempty"`
+	Limit       uint32   `json:"limit,omitempty"`
+	Heights     []uint64 `json:"heights,omitempty"`
+}
+
+// Error is a recorded error, which keeps the ABCI code of the registered
+// errors, so that the replayed error matches them with errors.Is
+type Error struct {
+	Message   string `json:"message"`
+	Codespace string `json:"codespace,omitempty"`
+	Code      uint32 `json:"code,omitempty"`
+	Expected  bool   `json:"expected,omitempty"`
+}
+
+// slashedOrJailed is the response of QueryFinalityProviderSlashedOrJailed
+type slashedOrJailed struct {
+	Slashed bool `json:"slashed"`
+	Jailed  bool `json:"jailed"`
+}
+
+// replayedError is a replayed error with the message of the recorded one
+type replayedError struct {
+	msg   string
+	cause error
+}
+
+func (e *replayedError) Error() string {
+	return e.msg
+}
+
+func (e *replayedError) Unwrap() error {
+	return e.cause
+}
+
+func newError(err error) *Error {
+	recorded := &Error{
+		Message:  err.Error(),
+		Expected: clientcontroller.IsExpected(err),
+	}
+
+	var registered *sdkErr.Error
+	if errors.As(err, &registered) {
+		recorded.Codespace, recorded.Code = registered.Codespace(), registered.ABCICode()
+	}
+
+	return recorded
+}
+
+// toError returns an error with the recorded message that matches the
+// recorded registered error and its kinds, if any
+func (e *Error) toError() error {
+	var err error = &replayedError{msg: e.Message}
+	if e.Codespace != "" {
+		err = clientcontroller.ClassifyError(&replayedError{msg: e.Message, cause: sdkErr.ABCIError(e.Codespace, e.Code, "")})
+	}
+
+	if e.Expected {
+		return clientcontroller.Expected(err)
+	}
+
+	return err
+}
+
+// ReadEntries reads the entries of the recording at the given path
+func ReadEntries(path string) ([]*Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the recording %s: %w", path, err)
+	}
+	defer f.Close()
+
+	entries, err := readEntries(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the recording %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+func readEntries(r io.Reader) ([]*Entry, error) {
+	var entries []*Entry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid entry at line %d: %w", line, err)
+		}
+		if entry.Method == "" {
+			return nil, fmt.Errorf("invalid entry at line %d: missing method", line)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
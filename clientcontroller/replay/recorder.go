@@ -0,0 +1,256 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"cosmossdk.io/math"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+var _ clientcontroller.ClientController = &Recorder{}
+
+// Recorder is a ClientController that records the calls to the wrapped one
+// and their responses to a file, which can be served back by a Replayer
+type Recorder struct {
+	cc     clientcontroller.ClientController
+	logger *zap.Logger
+
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// NewRecorder returns a recorder appending the calls to the given client
+// controller to the file at the given path
+func NewRecorder(cc clientcontroller.ClientController, path string, logger *zap.Logger) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the recording %s: %w", path, err)
+	}
+
+	return &Recorder{
+		cc:     cc,
+		logger: logger,
+		f:      f,
+		enc:    json.NewEncoder(f),
+	}, nil
+}
+
+// write records the call, unless it is aborted by the caller, in which case
+// the response does not come from the consumer chain
+func (r *Recorder) write(ctx context.Context, method string, req Request, res interface{}, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	entry := &Entry{
+		Time:    time.Now().UTC(),
+		Method:  method,
+		Request: req,
+	}
+	if err != nil {
+		entry.Error = newError(err)
+	} else {
+		bz, marshalErr := json.Marshal(res)
+		if marshalErr != nil {
+			r.logger.Error("failed to encode the recorded response", zap.String("method", method), zap.Error(marshalErr))
+
+			return
+		}
+		entry.Response = bz
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.enc.Encode(entry); err != nil {
+		r.logger.Error("failed to record the call to the consumer chain", zap.String("method", method), zap.Error(err))
+	}
+}
+
+// record records the call and passes its results through
+func record[T any](ctx context.Context, r *Recorder, method string, req Request, res T, err error) (T, error) {
+	r.write(ctx, method, req, res, err)
+
+	return res, err
+}
+
+func blockHeights(blocks []*types.BlockInfo) []uint64 {
+	heights := make([]uint64, len(blocks))
+	for i, b := range blocks {
+		heights[i] = b.Height
+	}
+
+	return heights
+}
+
+func (r *Recorder) RegisterFinalityProvider(
+	ctx context.Context,
+	fpPk *btcec.PublicKey,
+	pop []byte,
+	commission *math.LegacyDec,
+	description []byte,
+) (*types.TxResponse, error) {
+	res, err := r.cc.RegisterFinalityProvider(ctx, fpPk, pop, commission, description)
+
+	return record(ctx, r, "RegisterFinalityProvider", Request{}, res, err)
+}
+
+func (r *Recorder) EditFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	res, err := r.cc.EditFinalityProvider(ctx, fpPk, commission, description)
+	if err != nil {
+		return record(ctx, r, "EditFinalityProvider", Request{}, res, err)
+	}
+
+	// the proto message is recorded in its binary encoding
+	bz, marshalErr := res.Marshal()
+	if marshalErr != nil {
+		r.logger.Error("failed to encode the recorded response", zap.String("method", "EditFinalityProvider"), zap.Error(marshalErr))
+
+		return res, nil
+	}
+	r.write(ctx, "EditFinalityProvider", Request{}, bz, nil)
+
+	return res, nil
+}
+
+func (r *Recorder) CommitPubRandList(ctx context.Context, fpPk *btcec.PublicKey, startHeight uint64, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types.TxResponse, error) {
+	res, err := r.cc.CommitPubRandList(ctx, fpPk, startHeight, numPubRand, commitment, sig)
+
+	return record(ctx, r, "CommitPubRandList", Request{StartHeight: startHeight, Count: numPubRand}, res, err)
+}
+
+func (r *Recorder) SubmitFinalitySig(ctx context.Context, fpPk *btcec.PublicKey, block *types.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types.TxResponse, error) {
+	res, err := r.cc.SubmitFinalitySig(ctx, fpPk, block, pubRand, proof, sig)
+
+	return record(ctx, r, "SubmitFinalitySig", Request{Height: block.Height}, res, err)
+}
+
+func (r *Recorder) SubmitBatchFinalitySigs(ctx context.Context, fpPk *btcec.PublicKey, blocks []*types.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types.TxResponse, error) {
+	res, err := r.cc.SubmitBatchFinalitySigs(ctx, fpPk, blocks, pubRandList, proofList, sigs)
+
+	return record(ctx, r, "SubmitBatchFinalitySigs", Request{Heights: blockHeights(blocks)}, res, err)
+}
+
+func (r *Recorder) UnjailFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	res, err := r.cc.UnjailFinalityProvider(ctx, fpPk)
+
+	return record(ctx, r, "UnjailFinalityProvider", Request{}, res, err)
+}
+
+func (r *Recorder) QueryFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	res, err := r.cc.QueryFinalityProvider(ctx, fpPk)
+	if err != nil {
+		return record(ctx, r, "QueryFinalityProvider", Request{}, res, err)
+	}
+
+	// the proto message is recorded in its binary encoding
+	bz, marshalErr := res.Marshal()
+	if marshalErr != nil {
+		r.logger.Error("failed to encode the recorded response", zap.String("method", "QueryFinalityProvider"), zap.Error(marshalErr))
+
+		return res, nil
+	}
+	r.write(ctx, "QueryFinalityProvider", Request{}, bz, nil)
+
+	return res, nil
+}
+
+func (r *Recorder) QueryFinalityProviderVotingPower(ctx context.Context, fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	res, err := r.cc.QueryFinalityProviderVotingPower(ctx, fpPk, blockHeight)
+
+	return record(ctx, r, "QueryFinalityProviderVotingPower", Request{Height: blockHeight}, res, err)
+}
+
+func (r *Recorder) QueryFinalityProviderSlashedOrJailed(ctx context.Context, fpPk *btcec.PublicKey) (bool, bool, error) {
+	slashed, jailed, err := r.cc.QueryFinalityProviderSlashedOrJailed(ctx, fpPk)
+	r.write(ctx, "QueryFinalityProviderSlashedOrJailed", Request{}, &slashedOrJailed{Slashed: slashed, Jailed: jailed}, err)
+
+	return slashed, jailed, err
+}
+
+func (r *Recorder) QueryFinalityProviderJailedUntil(ctx context.Context, fpPk *btcec.PublicKey) (time.Time, error) {
+	res, err := r.cc.QueryFinalityProviderJailedUntil(ctx, fpPk)
+
+	return record(ctx, r, "QueryFinalityProviderJailedUntil", Request{}, res, err)
+}
+
+func (r *Recorder) QueryFinalityProviderHighestVotedHeight(ctx context.Context, fpPk *btcec.PublicKey) (uint64, error) {
+	res, err := r.cc.QueryFinalityProviderHighestVotedHeight(ctx, fpPk)
+
+	return record(ctx, r, "QueryFinalityProviderHighestVotedHeight", Request{}, res, err)
+}
+
+func (r *Recorder) QueryLatestFinalizedBlocks(ctx context.Context, count uint64) ([]*types.BlockInfo, error) {
+	res, err := r.cc.QueryLatestFinalizedBlocks(ctx, count)
+
+	return record(ctx, r, "QueryLatestFinalizedBlocks", Request{Count: count}, res, err)
+}
+
+func (r *Recorder) QueryLastCommittedPublicRand(ctx context.Context, fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	res, err := r.cc.QueryLastCommittedPublicRand(ctx, fpPk, count)
+
+	return record(ctx, r, "QueryLastCommittedPublicRand", Request{Count: count}, res, err)
+}
+
+func (r *Recorder) QueryBlock(ctx context.Context, height uint64) (*types.BlockInfo, error) {
+	res, err := r.cc.QueryBlock(ctx, height)
+
+	return record(ctx, r, "QueryBlock", Request{Height: height}, res, err)
+}
+
+func (r *Recorder) QueryBlocks(ctx context.Context, startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	res, err := r.cc.QueryBlocks(ctx, startHeight, endHeight, limit)
+
+	return record(ctx, r, "QueryBlocks", Request{StartHeight: startHeight, EndHeight: endHeight, Limit: limit}, res, err)
+}
+
+func (r *Recorder) QueryBestBlock(ctx context.Context) (*types.BlockInfo, error) {
+	res, err := r.cc.QueryBestBlock(ctx)
+
+	return record(ctx, r, "QueryBestBlock", Request{}, res, err)
+}
+
+func (r *Recorder) QueryNodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	res, err := r.cc.QueryNodeStatus(ctx)
+
+	return record(ctx, r, "QueryNodeStatus", Request{}, res, err)
+}
+
+func (r *Recorder) QueryActivatedHeight(ctx context.Context) (uint64, error) {
+	res, err := r.cc.QueryActivatedHeight(ctx)
+
+	return record(ctx, r, "QueryActivatedHeight", Request{}, res, err)
+}
+
+func (r *Recorder) QueryFinalityActivationBlockHeight(ctx context.Context) (uint64, error) {
+	res, err := r.cc.QueryFinalityActivationBlockHeight(ctx)
+
+	return record(ctx, r, "QueryFinalityActivationBlockHeight", Request{}, res, err)
+}
+
+// Close closes the wrapped client controller and the recording
+func (r *Recorder) Close() error {
+	ccErr := r.cc.Close()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.f.Close(); err != nil {
+		return fmt.Errorf("failed to close the recording: %w", err)
+	}
+
+	return ccErr
+}
@@ -0,0 +1,152 @@
+package replay_test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/replay"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	recorder, err := replay.NewRecorder(mockClientController, path, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+
+	blocks := testutil.GenBlocks(r, 1, 3)
+	tip := blocks[len(blocks)-1]
+	fpRes := &btcstakingtypes.QueryFinalityProviderResponse{
+		FinalityProvider: &btcstakingtypes.FinalityProviderResponse{Addr: "addr", Jailed: true},
+	}
+	jailedUntil := time.Now().Add(time.Hour).UTC()
+
+	gomock.InOrder(
+		mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(blocks[0], nil),
+		mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(tip, nil),
+	)
+	mockClientController.EXPECT().QueryBlocks(gomock.Any(), uint64(1), tip.Height, uint32(10)).Return(blocks, nil)
+	mockClientController.EXPECT().QueryFinalityProvider(gomock.Any(), gomock.Any()).Return(fpRes, nil)
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(false, true, nil)
+	mockClientController.EXPECT().QueryFinalityProviderJailedUntil(gomock.Any(), gomock.Any()).Return(jailedUntil, nil)
+	mockClientController.EXPECT().SubmitBatchFinalitySigs(gomock.Any(), gomock.Any(), blocks[1:], gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, btcstakingtypes.ErrFpAlreadyJailed.Wrapf("finality provider %s", "pk"))
+	mockClientController.EXPECT().SubmitBatchFinalitySigs(gomock.Any(), gomock.Any(), blocks[:1], gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, clientcontroller.Expected(finalitytypes.ErrDuplicatedFinalitySig))
+	mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(uint64(0), errors.New("connection refused"))
+	mockClientController.EXPECT().Close().Return(nil)
+
+	_, err = recorder.QueryBestBlock(ctx)
+	require.NoError(t, err)
+	_, err = recorder.QueryBestBlock(ctx)
+	require.NoError(t, err)
+	_, err = recorder.QueryBlocks(ctx, 1, tip.Height, 10)
+	require.NoError(t, err)
+	_, err = recorder.QueryFinalityProvider(ctx, nil)
+	require.NoError(t, err)
+	_, _, err = recorder.QueryFinalityProviderSlashedOrJailed(ctx, nil)
+	require.NoError(t, err)
+	_, err = recorder.QueryFinalityProviderJailedUntil(ctx, nil)
+	require.NoError(t, err)
+	_, err = recorder.SubmitBatchFinalitySigs(ctx, nil, blocks[1:], nil, nil, nil)
+	require.Error(t, err)
+	_, err = recorder.SubmitBatchFinalitySigs(ctx, nil, blocks[:1], nil, nil, nil)
+	require.Error(t, err)
+	_, err = recorder.QueryActivatedHeight(ctx)
+	require.Error(t, err)
+	require.NoError(t, recorder.Close())
+
+	replayer, err := replay.LoadReplayer(path)
+	require.NoError(t, err)
+	require.Equal(t, 9, replayer.NumUnreplayed())
+
+	// the calls with the same request are served in order, and the last
+	// response is served again once they are exhausted
+	b, err := replayer.QueryBestBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, blocks[0], b)
+	for i := 0; i < 2; i++ {
+		b, err = replayer.QueryBestBlock(ctx)
+		require.NoError(t, err)
+		require.Equal(t, tip, b)
+	}
+
+	res, err := replayer.QueryBlocks(ctx, 1, tip.Height, 10)
+	require.NoError(t, err)
+	require.Equal(t, blocks, res)
+	_, err = replayer.QueryBlocks(ctx, 2, tip.Height, 10)
+	require.ErrorIs(t, err, replay.ErrNotRecorded)
+
+	replayedFpRes, err := replayer.QueryFinalityProvider(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, fpRes.FinalityProvider.Addr, replayedFpRes.FinalityProvider.Addr)
+	require.True(t, replayedFpRes.FinalityProvider.Jailed)
+
+	slashed, jailed, err := replayer.QueryFinalityProviderSlashedOrJailed(ctx, nil)
+	require.NoError(t, err)
+	require.False(t, slashed)
+	require.True(t, jailed)
+
+	replayedJailedUntil, err := replayer.QueryFinalityProviderJailedUntil(ctx, nil)
+	require.NoError(t, err)
+	require.True(t, jailedUntil.Equal(replayedJailedUntil))
+
+	// the errors keep their messages and types
+	_, err = replayer.SubmitBatchFinalitySigs(ctx, nil, blocks[1:], nil, nil, nil)
+	require.ErrorIs(t, err, btcstakingtypes.ErrFpAlreadyJailed)
+	require.Contains(t, err.Error(), "finality provider pk")
+	require.True(t, clientcontroller.IsUnrecoverable(err))
+	_, err = replayer.SubmitBatchFinalitySigs(ctx, nil, blocks[:1], nil, nil, nil)
+	require.True(t, clientcontroller.IsExpected(err))
+	require.ErrorIs(t, err, finalitytypes.ErrDuplicatedFinalitySig)
+	_, err = replayer.QueryActivatedHeight(ctx)
+	require.EqualError(t, err, "connection refused")
+	require.False(t, clientcontroller.IsUnrecoverable(err))
+
+	require.Zero(t, replayer.NumUnreplayed())
+}
+
+func TestRecordSkipsAbortedCalls(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	recorder, err := replay.NewRecorder(mockClientController, path, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(nil, context.Canceled)
+	mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(&types.BlockInfo{Height: 1}, nil)
+	mockClientController.EXPECT().Close().Return(nil)
+
+	_, err = recorder.QueryBestBlock(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = recorder.QueryBestBlock(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	entries, err := replay.ReadEntries(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "QueryBestBlock", entries[0].Method)
+	require.Nil(t, entries[0].Error)
+}
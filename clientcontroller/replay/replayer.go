@@ -0,0 +1,303 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/math"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+var _ clientcontroller.ClientController = &Replayer{}
+
+// ErrNotRecorded is returned for a call whose request was never recorded
+var ErrNotRecorded = errors.New("the call is not recorded")
+
+// Replayer is a ClientController serving back the responses of a recording.
+// The calls with the same method and request are served the recorded
+// responses in order, and the last response once they are exhausted, as the
+// consumer chain is not known to have changed since then. As the loops of
+// the finality provider run concurrently, the calls with the same request
+// may be made by other loops than in the recording
+type Replayer struct {
+	mu    sync.Mutex
+	calls map[string]*recordedCalls
+	// votes are the last recorded submissions of finality signatures by height
+	votes map[uint64]*Entry
+}
+
+type recordedCalls struct {
+	entries []*Entry
+	next    int
+}
+
+// NewReplayer returns a replayer serving back the given entries
+func NewReplayer(entries []*Entry) (*Replayer, error) {
+	r := &Replayer{
+		calls: make(map[string]*recordedCalls),
+		votes: make(map[uint64]*Entry),
+	}
+	for _, entry := range entries {
+		switch entry.Method {
+		case "SubmitFinalitySig":
+			r.votes[entry.Request.Height] = entry
+		case "SubmitBatchFinalitySigs":
+			for _, height := range entry.Request.Heights {
+				r.votes[height] = entry
+			}
+		}
+
+		key, err := callKey(entry.Method, entry.Request)
+		if err != nil {
+			return nil, err
+		}
+		if r.calls[key] == nil {
+			r.calls[key] = &recordedCalls{}
+		}
+		r.calls[key].entries = append(r.calls[key].entries, entry)
+	}
+
+	return r, nil
+}
+
+// LoadReplayer returns a replayer serving back the recording at the given path
+func LoadReplayer(path string) (*Replayer, error) {
+	entries, err := ReadEntries(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayer(entries)
+}
+
+// NumUnreplayed returns the number of recorded calls that are not replayed yet
+func (r *Replayer) NumUnreplayed() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var n int
+	for _, c := range r.calls {
+		n += len(c.entries) - c.next
+	}
+
+	return n
+}
+
+func callKey(method string, req Request) (string, error) {
+	bz, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the request of %s: %w", method, err)
+	}
+
+	return method + string(bz), nil
+}
+
+func (r *Replayer) nextEntry(method string, req Request) (*Entry, error) {
+	key, err := callKey(method, req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.calls[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, method, key[len(method):])
+	}
+
+	if c.next == len(c.entries) {
+		return c.entries[len(c.entries)-1], nil
+	}
+	entry := c.entries[c.next]
+	c.next++
+
+	return entry, nil
+}
+
+// voteEntry returns the recorded submission of the finality signatures of
+// the given heights, which may have been batched differently in the
+// recording. The submission is served as long as the signatures of all the
+// heights were submitted, with the first error or the last response
+func (r *Replayer) voteEntry(method string, heights []uint64) (*Entry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entry *Entry
+	for _, height := range heights {
+		vote, ok := r.votes[height]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of height %d", ErrNotRecorded, method, height)
+		}
+		if vote.Error != nil {
+			return vote, nil
+		}
+		entry = vote
+	}
+
+	return entry, nil
+}
+
+// replay returns the recorded response of the call
+func replay[T any](r *Replayer, method string, req Request) (T, error) {
+	entry, err := r.nextEntry(method, req)
+	if err != nil {
+		var res T
+
+		return res, err
+	}
+
+	return decode[T](method, entry)
+}
+
+// replayVote returns the recorded response of the submission of the finality
+// signatures of the given heights
+func (r *Replayer) replayVote(method string, req Request, heights []uint64) (*types.TxResponse, error) {
+	entry, err := r.nextEntry(method, req)
+	if errors.Is(err, ErrNotRecorded) {
+		entry, err = r.voteEntry(method, heights)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decode[*types.TxResponse](method, entry)
+}
+
+// decode returns the recorded response or error of the entry
+func decode[T any](method string, entry *Entry) (T, error) {
+	var res T
+
+	if entry.Error != nil {
+		return res, entry.Error.toError()
+	}
+
+	if err := json.Unmarshal(entry.Response, &res); err != nil {
+		return res, fmt.Errorf("invalid recorded response of %s: %w", method, err)
+	}
+
+	return res, nil
+}
+
+func (r *Replayer) RegisterFinalityProvider(
+	_ context.Context,
+	_ *btcec.PublicKey,
+	_ []byte,
+	_ *math.LegacyDec,
+	_ []byte,
+) (*types.TxResponse, error) {
+	return replay[*types.TxResponse](r, "RegisterFinalityProvider", Request{})
+}
+
+func (r *Replayer) EditFinalityProvider(_ context.Context, _ *btcec.PublicKey, _ *math.LegacyDec, _ []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	bz, err := replay[[]byte](r, "EditFinalityProvider", Request{})
+	if err != nil {
+		return nil, err
+	}
+
+	var res btcstakingtypes.MsgEditFinalityProvider
+	if err := res.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("invalid recorded response of EditFinalityProvider: %w", err)
+	}
+
+	return &res, nil
+}
+
+func (r *Replayer) CommitPubRandList(_ context.Context, _ *btcec.PublicKey, startHeight uint64, numPubRand uint64, _ []byte, _ *schnorr.Signature) (*types.TxResponse, error) {
+	return replay[*types.TxResponse](r, "CommitPubRandList", Request{StartHeight: startHeight, Count: numPubRand})
+}
+
+func (r *Replayer) SubmitFinalitySig(_ context.Context, _ *btcec.PublicKey, block *types.BlockInfo, _ *btcec.FieldVal, _ []byte, _ *btcec.ModNScalar) (*types.TxResponse, error) {
+	return r.replayVote("SubmitFinalitySig", Request{Height: block.Height}, []uint64{block.Height})
+}
+
+func (r *Replayer) SubmitBatchFinalitySigs(_ context.Context, _ *btcec.PublicKey, blocks []*types.BlockInfo, _ []*btcec.FieldVal, _ [][]byte, _ []*btcec.ModNScalar) (*types.TxResponse, error) {
+	heights := blockHeights(blocks)
+
+	return r.replayVote("SubmitBatchFinalitySigs", Request{Heights: heights}, heights)
+}
+
+func (r *Replayer) UnjailFinalityProvider(_ context.Context, _ *btcec.PublicKey) (*types.TxResponse, error) {
+	return replay[*types.TxResponse](r, "UnjailFinalityProvider", Request{})
+}
+
+func (r *Replayer) QueryFinalityProvider(_ context.Context, _ *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	bz, err := replay[[]byte](r, "QueryFinalityProvider", Request{})
+	if err != nil {
+		return nil, err
+	}
+
+	var res btcstakingtypes.QueryFinalityProviderResponse
+	if err := res.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("invalid recorded response of QueryFinalityProvider: %w", err)
+	}
+
+	return &res, nil
+}
+
+func (r *Replayer) QueryFinalityProviderVotingPower(_ context.Context, _ *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	return replay[uint64](r, "QueryFinalityProviderVotingPower", Request{Height: blockHeight})
+}
+
+func (r *Replayer) QueryFinalityProviderSlashedOrJailed(_ context.Context, _ *btcec.PublicKey) (bool, bool, error) {
+	res, err := replay[*slashedOrJailed](r, "QueryFinalityProviderSlashedOrJailed", Request{})
+	if err != nil {
+		return false, false, err
+	}
+
+	return res.Slashed, res.Jailed, nil
+}
+
+func (r *Replayer) QueryFinalityProviderJailedUntil(_ context.Context, _ *btcec.PublicKey) (time.Time, error) {
+	return replay[time.Time](r, "QueryFinalityProviderJailedUntil", Request{})
+}
+
+func (r *Replayer) QueryFinalityProviderHighestVotedHeight(_ context.Context, _ *btcec.PublicKey) (uint64, error) {
+	return replay[uint64](r, "QueryFinalityProviderHighestVotedHeight", Request{})
+}
+
+func (r *Replayer) QueryLatestFinalizedBlocks(_ context.Context, count uint64) ([]*types.BlockInfo, error) {
+	return replay[[]*types.BlockInfo](r, "QueryLatestFinalizedBlocks", Request{Count: count})
+}
+
+func (r *Replayer) QueryLastCommittedPublicRand(_ context.Context, _ *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	return replay[map[uint64]*finalitytypes.PubRandCommitResponse](r, "QueryLastCommittedPublicRand", Request{Count: count})
+}
+
+func (r *Replayer) QueryBlock(_ context.Context, height uint64) (*types.BlockInfo, error) {
+	return replay[*types.BlockInfo](r, "QueryBlock", Request{Height: height})
+}
+
+func (r *Replayer) QueryBlocks(_ context.Context, startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	return replay[[]*types.BlockInfo](r, "QueryBlocks", Request{StartHeight: startHeight, EndHeight: endHeight, Limit: limit})
+}
+
+func (r *Replayer) QueryBestBlock(_ context.Context) (*types.BlockInfo, error) {
+	return replay[*types.BlockInfo](r, "QueryBestBlock", Request{})
+}
+
+func (r *Replayer) QueryNodeStatus(_ context.Context) (*types.NodeStatus, error) {
+	return replay[*types.NodeStatus](r, "QueryNodeStatus", Request{})
+}
+
+func (r *Replayer) QueryActivatedHeight(_ context.Context) (uint64, error) {
+	return replay[uint64](r, "QueryActivatedHeight", Request{})
+}
+
+func (r *Replayer) QueryFinalityActivationBlockHeight(_ context.Context) (uint64, error) {
+	return replay[uint64](r, "QueryFinalityActivationBlockHeight", Request{})
+}
+
+func (r *Replayer) Close() error {
+	return nil
+}
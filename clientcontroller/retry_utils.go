@@ -2,36 +2,11 @@ package clientcontroller
 
 import (
 	"errors"
-	"strings"
-
-	sdkErr "cosmossdk.io/errors"
-	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
-	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
 )
 
-// these errors are considered unrecoverable because they indicate
-// something critical in the finality provider program or the consumer chain
-var unrecoverableErrors = []*sdkErr.Error{
-	finalitytypes.ErrBlockNotFound,
-	finalitytypes.ErrInvalidFinalitySig,
-	finalitytypes.ErrNoPubRandYet,
-	finalitytypes.ErrPubRandNotFound,
-	finalitytypes.ErrTooFewPubRand,
-	btcstakingtypes.ErrFpAlreadySlashed,
-	btcstakingtypes.ErrFpAlreadyJailed,
-}
-
-// IsUnrecoverable returns true when the error is in the unrecoverableErrors list
+// IsUnrecoverable returns true when the error is classified as unrecoverable
 func IsUnrecoverable(err error) bool {
-	for _, e := range unrecoverableErrors {
-		// cannot use error.Is because the unwrapped error
-		// is not the expected error type
-		if strings.Contains(err.Error(), e.Error()) {
-			return true
-		}
-	}
-
-	return false
+	return errors.Is(ClassifyError(err), ErrUnrecoverable)
 }
 
 type ExpectedError struct {
@@ -62,7 +37,8 @@ func Expected(err error) error {
 	return ExpectedError{err}
 }
 
-// IsExpected checks if error is an instance of ExpectedError
+// IsExpected checks if error is an instance of ExpectedError or is
+// classified as expected
 func IsExpected(err error) bool {
-	return errors.Is(err, ExpectedError{})
+	return errors.Is(ClassifyError(err), ExpectedError{})
 }
@@ -0,0 +1,388 @@
+package simulation
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// Chain is an in-memory consumer chain implementing the ClientController
+// interface. It produces blocks, keeps a voting power table per block,
+// verifies public randomness commits and finality signatures the way Babylon
+// does, finalizes the blocks voted by more than 2/3 of the voting power, and
+// jails finality providers missing blocks and slashes the ones double signing
+type Chain struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	mu sync.RWMutex
+	// blocks[i] is the block at height i+1
+	blocks []*block
+	fps    map[string]*finalityProvider
+	// activatedHeight is the first height with a non-empty voting power table
+	activatedHeight      uint64
+	nextHeightToFinalize uint64
+	numTxs               uint64
+
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+	quit      chan struct{}
+}
+
+type block struct {
+	height    uint64
+	hash      []byte
+	time      time.Time
+	finalized bool
+	// votingPower is the voting power table of the block by the finality
+	// provider keys in hex
+	votingPower map[string]uint64
+	// voters are the keys in hex of the finality providers voting the block
+	voters map[string]struct{}
+}
+
+type finalityProvider struct {
+	btcPk            *bbntypes.BIP340PubKey
+	addr             string
+	pop              *btcstakingtypes.ProofOfPossessionBTC
+	description      *stakingtypes.Description
+	commission       *sdkmath.LegacyDec
+	registeredHeight uint64
+	votingPower      uint64
+	// pubRandCommits are the public randomness commits in ascending order
+	pubRandCommits []*finalitytypes.PubRandCommit
+	// votes and forkVotes are the hashes of the voted blocks by heights
+	votes              map[uint64][]byte
+	forkVotes          map[uint64][]byte
+	highestVotedHeight uint64
+	missedBlocks       uint64
+	jailed             bool
+	jailedUntil        time.Time
+	slashedHeight      uint64
+}
+
+// NewChain returns a simulated consumer chain with the genesis block
+func NewChain(cfg *Config, logger *zap.Logger) (*Chain, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config for the simulated chain: %w", err)
+	}
+
+	c := &Chain{
+		cfg:                  cfg,
+		logger:               logger,
+		fps:                  make(map[string]*finalityProvider),
+		nextHeightToFinalize: 1,
+		quit:                 make(chan struct{}),
+	}
+	c.produceBlockLocked()
+
+	return c, nil
+}
+
+// Start starts producing blocks at the configured interval, if any
+func (c *Chain) Start() {
+	c.startOnce.Do(func() {
+		if c.cfg.BlockInterval == 0 {
+			return
+		}
+
+		c.logger.Info("starting the simulated consumer chain",
+			zap.Duration("block_interval", c.cfg.BlockInterval))
+
+		c.wg.Add(1)
+		go c.blockProductionLoop()
+	})
+}
+
+// Stop stops producing blocks
+func (c *Chain) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.quit)
+		c.wg.Wait()
+	})
+}
+
+func (c *Chain) blockProductionLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.BlockInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.ProduceBlocks(1)
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// ProduceBlocks produces the given number of blocks and returns the tip
+func (c *Chain) ProduceBlocks(n uint64) *types.BlockInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := uint64(0); i < n; i++ {
+		c.produceBlockLocked()
+	}
+
+	return c.tipLocked().toBlockInfo()
+}
+
+// SetVotingPower sets the voting power of the finality provider, which takes
+// effect from the next block
+func (c *Chain) SetVotingPower(fpPk *btcec.PublicKey, power uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return err
+	}
+	fp.votingPower = power
+
+	return nil
+}
+
+func (c *Chain) produceBlockLocked() {
+	var (
+		height   uint64 = 1
+		prevHash []byte
+	)
+	if len(c.blocks) > 0 {
+		tip := c.tipLocked()
+		height, prevHash = tip.height+1, tip.hash
+	}
+
+	hash := sha256.Sum256(append(sdk.Uint64ToBigEndian(height), prevHash...))
+	b := &block{
+		height:      height,
+		hash:        hash[:],
+		time:        time.Now(),
+		votingPower: c.votingPowerTableLocked(height),
+		voters:      make(map[string]struct{}),
+	}
+	c.blocks = append(c.blocks, b)
+
+	if c.activatedHeight == 0 && len(b.votingPower) > 0 {
+		c.activatedHeight = height
+		c.logger.Info("the simulated chain is activated", zap.Uint64("height", height))
+	}
+
+	c.logger.Debug("produced a block on the simulated chain",
+		zap.Uint64("height", height),
+		zap.String("hash", hex.EncodeToString(b.hash)),
+		zap.Int("num_active_fps", len(b.votingPower)),
+	)
+
+	c.updateMissedBlocksLocked(b)
+	c.tallyBlocksLocked()
+}
+
+// votingPowerTableLocked returns the voting power of the finality providers
+// that are neither jailed nor slashed and have public randomness committed
+// for the given height
+func (c *Chain) votingPowerTableLocked(height uint64) map[string]uint64 {
+	table := make(map[string]uint64)
+	for pkHex, fp := range c.fps {
+		if fp.votingPower == 0 || fp.jailed || fp.slashedHeight > 0 || fp.pubRandCommitAt(height) == nil {
+			continue
+		}
+		table[pkHex] = fp.votingPower
+	}
+
+	return table
+}
+
+// updateMissedBlocksLocked counts the block that is FinalitySigTimeout blocks
+// older than the tip as missed for the finality providers that have not
+// voted it, and jails the ones missing MaxMissedBlocks consecutive blocks
+func (c *Chain) updateMissedBlocksLocked(tip *block) {
+	if c.cfg.MaxMissedBlocks == 0 || tip.height <= c.cfg.FinalitySigTimeout {
+		return
+	}
+
+	b := c.blocks[tip.height-c.cfg.FinalitySigTimeout-1]
+	for pkHex := range b.votingPower {
+		fp := c.fps[pkHex]
+		if fp.jailed || fp.slashedHeight > 0 {
+			continue
+		}
+
+		if _, voted := b.voters[pkHex]; voted {
+			fp.missedBlocks = 0
+
+			continue
+		}
+
+		fp.missedBlocks++
+		if fp.missedBlocks < c.cfg.MaxMissedBlocks {
+			continue
+		}
+
+		fp.jailed = true
+		fp.jailedUntil = tip.time.Add(c.cfg.JailDuration)
+		fp.missedBlocks = 0
+		c.logger.Info("jailed the finality provider for missing blocks on the simulated chain",
+			zap.String("pk", pkHex),
+			zap.Uint64("height", tip.height),
+			zap.Time("jailed_until", fp.jailedUntil),
+		)
+	}
+}
+
+// tallyBlocksLocked finalizes the blocks in order once more than 2/3 of
+// their voting power has voted, and skips the ones without voting power
+func (c *Chain) tallyBlocksLocked() {
+	startHeight := max(c.nextHeightToFinalize, c.cfg.FinalityActivationHeight)
+	for height := startHeight; height <= c.tipLocked().height; height++ {
+		b := c.blocks[height-1]
+
+		var totalPower, votedPower uint64
+		for pkHex, power := range b.votingPower {
+			totalPower += power
+			if _, voted := b.voters[pkHex]; voted {
+				votedPower += power
+			}
+		}
+
+		if totalPower > 0 && votedPower*3 <= totalPower*2 {
+			return
+		}
+
+		b.finalized = totalPower > 0
+		c.nextHeightToFinalize = height + 1
+
+		if b.finalized {
+			c.logger.Debug("finalized a block on the simulated chain", zap.Uint64("height", height))
+		}
+	}
+}
+
+// recordVoteLocked records the vote of a verified finality signature, and
+// slashes the finality provider if it votes both the block and a fork
+func (c *Chain) recordVoteLocked(fp *finalityProvider, b *types.BlockInfo) {
+	indexedBlock := c.blocks[b.Height-1]
+
+	if bytes.Equal(indexedBlock.hash, b.Hash) {
+		fp.votes[b.Height] = b.Hash
+		fp.highestVotedHeight = max(fp.highestVotedHeight, b.Height)
+		indexedBlock.voters[fp.btcPk.MarshalHex()] = struct{}{}
+	} else {
+		fp.forkVotes[b.Height] = b.Hash
+	}
+
+	_, votedBlock := fp.votes[b.Height]
+	_, votedFork := fp.forkVotes[b.Height]
+	if votedBlock && votedFork && fp.slashedHeight == 0 {
+		fp.slashedHeight = c.tipLocked().height
+		c.logger.Info("slashed the finality provider for double signing on the simulated chain",
+			zap.String("pk", fp.btcPk.MarshalHex()),
+			zap.Uint64("height", b.Height),
+		)
+	}
+}
+
+func (c *Chain) newTxResponseLocked() *types.TxResponse {
+	c.numTxs++
+	hash := sha256.Sum256(sdk.Uint64ToBigEndian(c.numTxs))
+
+	return &types.TxResponse{TxHash: strings.ToUpper(hex.EncodeToString(hash[:]))}
+}
+
+func (c *Chain) getFinalityProviderLocked(fpPk *btcec.PublicKey) (*finalityProvider, error) {
+	pkHex := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()
+	fp, ok := c.fps[pkHex]
+	if !ok {
+		return nil, wrapf(btcstakingtypes.ErrFpNotFound, "finality provider %s", pkHex)
+	}
+
+	return fp, nil
+}
+
+func (c *Chain) getBlockLocked(height uint64) (*block, error) {
+	if height == 0 || height > c.tipLocked().height {
+		return nil, wrapf(finalitytypes.ErrBlockNotFound, "height %d", height)
+	}
+
+	return c.blocks[height-1], nil
+}
+
+func (c *Chain) tipLocked() *block {
+	return c.blocks[len(c.blocks)-1]
+}
+
+func (b *block) toBlockInfo() *types.BlockInfo {
+	return &types.BlockInfo{
+		Height:    b.height,
+		Hash:      b.hash,
+		Finalized: b.finalized,
+	}
+}
+
+// pubRandCommitAt returns the public randomness commit covering the height
+func (fp *finalityProvider) pubRandCommitAt(height uint64) *finalitytypes.PubRandCommit {
+	for _, commit := range fp.pubRandCommits {
+		if commit.IsInRange(height) {
+			return commit
+		}
+	}
+
+	return nil
+}
+
+func (fp *finalityProvider) lastPubRandCommit() *finalitytypes.PubRandCommit {
+	if len(fp.pubRandCommits) == 0 {
+		return nil
+	}
+
+	return fp.pubRandCommits[len(fp.pubRandCommits)-1]
+}
+
+func (fp *finalityProvider) toResponse() *btcstakingtypes.FinalityProviderResponse {
+	res := &btcstakingtypes.FinalityProviderResponse{
+		Description: fp.description,
+		Commission:  fp.commission,
+		Addr:        fp.addr,
+		BtcPk:       fp.btcPk,
+		Pop:         fp.pop,
+		Height:      fp.registeredHeight,
+		Jailed:      fp.jailed,
+		// #nosec G115 -- the simulated chain does not reach such heights
+		HighestVotedHeight: uint32(fp.highestVotedHeight),
+	}
+
+	if fp.slashedHeight > 0 {
+		res.SlashedBabylonHeight = fp.slashedHeight
+		// the simulated chain has no BTC light client, so any positive
+		// height marks the finality provider as slashed
+		res.SlashedBtcHeight = 1
+	}
+
+	return res
+}
+
+// wrapf returns the registered error of Babylon with the given context,
+// classified as the errors returned by the BabylonController
+func wrapf(err *sdkErr.Error, format string, args ...interface{}) error {
+	return clientcontroller.ClassifyError(err.Wrapf(format, args...))
+}
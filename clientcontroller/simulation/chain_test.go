@@ -0,0 +1,260 @@
+package simulation_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/babylon/crypto/eots"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+const numPubRand = 100
+
+// testFp is a finality provider voting on the simulated chain
+type testFp struct {
+	sk        *btcec.PrivateKey
+	startRand uint64
+	secRands  []*eots.PrivateRand
+	pubRands  []*btcec.FieldVal
+	proofs    []*merkle.Proof
+}
+
+func newTestFp(t *testing.T, r *rand.Rand, c *simulation.Chain) *testFp {
+	sk, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	pop, err := (&btcstakingtypes.ProofOfPossessionBTC{}).Marshal()
+	require.NoError(t, err)
+	description, err := (&stakingtypes.Description{Moniker: testutil.GenRandomHexStr(r, 4)}).Marshal()
+	require.NoError(t, err)
+
+	_, err = c.RegisterFinalityProvider(context.Background(), sk.PubKey(), pop, testutil.ZeroCommissionRate(), description)
+	require.NoError(t, err)
+
+	return &testFp{sk: sk}
+}
+
+// commitPubRand commits new public randomness, which is used for voting once
+// the commit succeeds
+func (fp *testFp) commitPubRand(t *testing.T, r *rand.Rand, c *simulation.Chain, startHeight uint64) error {
+	var (
+		secRands []*eots.PrivateRand
+		pubRands []*btcec.FieldVal
+	)
+	for i := 0; i < numPubRand; i++ {
+		secRand, pubRand, err := eots.RandGen(r)
+		require.NoError(t, err)
+		secRands = append(secRands, secRand)
+		pubRands = append(pubRands, pubRand)
+	}
+	commitment, proofs := types.GetPubRandCommitAndProofs(pubRands)
+
+	msg := &finalitytypes.MsgCommitPubRandList{StartHeight: startHeight, NumPubRand: numPubRand, Commitment: commitment}
+	hash, err := msg.HashToSign()
+	require.NoError(t, err)
+	sig, err := schnorr.Sign(fp.sk, hash)
+	require.NoError(t, err)
+
+	if _, err := c.CommitPubRandList(context.Background(), fp.sk.PubKey(), startHeight, numPubRand, commitment, sig); err != nil {
+		return err
+	}
+	fp.startRand, fp.secRands, fp.pubRands, fp.proofs = startHeight, secRands, pubRands, proofs
+
+	return nil
+}
+
+func (fp *testFp) vote(t *testing.T, c *simulation.Chain, b *types.BlockInfo) (*types.TxResponse, error) {
+	i := b.Height - fp.startRand
+	sig, err := eots.Sign(fp.sk, fp.secRands[i], append(sdk.Uint64ToBigEndian(b.Height), b.Hash...))
+	require.NoError(t, err)
+	proof, err := fp.proofs[i].ToProto().Marshal()
+	require.NoError(t, err)
+
+	return c.SubmitFinalitySig(context.Background(), fp.sk.PubKey(), b, fp.pubRands[i], proof, sig)
+}
+
+func newTestChain(t *testing.T, cfg *simulation.Config) *simulation.Chain {
+	c, err := simulation.NewChain(cfg, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+
+	return c
+}
+
+func newManualConfig() *simulation.Config {
+	cfg := simulation.DefaultConfig()
+	cfg.BlockInterval = 0
+
+	return cfg
+}
+
+func TestBlockProduction(t *testing.T) {
+	t.Parallel()
+	cfg := simulation.DefaultConfig()
+	cfg.BlockInterval = 10 * time.Millisecond
+	c := newTestChain(t, cfg)
+	ctx := context.Background()
+
+	c.Start()
+	require.Eventually(t, func() bool {
+		tip, err := c.QueryBestBlock(ctx)
+		require.NoError(t, err)
+
+		return tip.Height >= 5
+	}, 5*time.Second, 10*time.Millisecond)
+	c.Stop()
+
+	tip, err := c.QueryBestBlock(ctx)
+	require.NoError(t, err)
+	blocks, err := c.QueryBlocks(ctx, 1, tip.Height+10, 100)
+	require.NoError(t, err)
+	require.Len(t, blocks, int(tip.Height))
+	require.Equal(t, tip, blocks[len(blocks)-1])
+
+	_, err = c.QueryBlock(ctx, tip.Height+1)
+	require.True(t, clientcontroller.IsUnrecoverable(err))
+	_, err = c.QueryActivatedHeight(ctx)
+	require.Error(t, err)
+}
+
+func TestFinalization(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	c := newTestChain(t, newManualConfig())
+	ctx := context.Background()
+
+	fp1, fp2 := newTestFp(t, r, c), newTestFp(t, r, c)
+	tip := c.ProduceBlocks(2)
+
+	// the finality providers only have voting power with public randomness
+	power, err := c.QueryFinalityProviderVotingPower(ctx, fp1.sk.PubKey(), tip.Height)
+	require.NoError(t, err)
+	require.Zero(t, power)
+
+	startHeight := tip.Height + 1
+	require.NoError(t, fp1.commitPubRand(t, r, c, startHeight))
+	require.NoError(t, fp2.commitPubRand(t, r, c, startHeight))
+	require.Error(t, fp1.commitPubRand(t, r, c, startHeight+numPubRand-1))
+	require.NoError(t, c.SetVotingPower(fp2.sk.PubKey(), 2*simulation.DefaultConfig().DefaultVotingPower))
+
+	b1 := c.ProduceBlocks(1)
+	activatedHeight, err := c.QueryActivatedHeight(ctx)
+	require.NoError(t, err)
+	require.Equal(t, startHeight, activatedHeight)
+
+	// fp2 has 2/3 of the voting power, which is not enough to finalize
+	_, err = fp2.vote(t, c, b1)
+	require.NoError(t, err)
+	finalizedBlocks, err := c.QueryLatestFinalizedBlocks(ctx, 1)
+	require.NoError(t, err)
+	require.Empty(t, finalizedBlocks)
+
+	res, err := fp1.vote(t, c, b1)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.TxHash)
+	finalizedBlocks, err = c.QueryLatestFinalizedBlocks(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, finalizedBlocks, 1)
+	require.Equal(t, b1.Height, finalizedBlocks[0].Height)
+
+	// a duplicated vote is ignored
+	res, err = fp1.vote(t, c, b1)
+	require.NoError(t, err)
+	require.Empty(t, res.TxHash)
+
+	votedHeight, err := c.QueryFinalityProviderHighestVotedHeight(ctx, fp1.sk.PubKey())
+	require.NoError(t, err)
+	require.Equal(t, b1.Height, votedHeight)
+
+	// a signature with the randomness of another height is invalid
+	b2 := c.ProduceBlocks(1)
+	fp1.startRand--
+	_, err = fp1.vote(t, c, b2)
+	require.True(t, clientcontroller.IsUnrecoverable(err))
+}
+
+func TestSlashing(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	c := newTestChain(t, newManualConfig())
+	ctx := context.Background()
+
+	fp := newTestFp(t, r, c)
+	require.NoError(t, fp.commitPubRand(t, r, c, 2))
+	b := c.ProduceBlocks(1)
+
+	_, err := fp.vote(t, c, b)
+	require.NoError(t, err)
+	slashed, _, err := c.QueryFinalityProviderSlashedOrJailed(ctx, fp.sk.PubKey())
+	require.NoError(t, err)
+	require.False(t, slashed)
+
+	// voting a fork of the same height slashes the finality provider
+	fork := &types.BlockInfo{Height: b.Height, Hash: testutil.GenRandomByteArray(r, 32)}
+	_, err = fp.vote(t, c, fork)
+	require.NoError(t, err)
+	slashed, _, err = c.QueryFinalityProviderSlashedOrJailed(ctx, fp.sk.PubKey())
+	require.NoError(t, err)
+	require.True(t, slashed)
+
+	_, err = fp.vote(t, c, c.ProduceBlocks(1))
+	require.ErrorIs(t, err, btcstakingtypes.ErrFpAlreadySlashed)
+}
+
+func TestJailing(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	cfg := newManualConfig()
+	cfg.FinalitySigTimeout = 1
+	cfg.MaxMissedBlocks = 3
+	cfg.JailDuration = time.Hour
+	c := newTestChain(t, cfg)
+	ctx := context.Background()
+
+	fp := newTestFp(t, r, c)
+	require.NoError(t, fp.commitPubRand(t, r, c, 2))
+
+	_, err := c.UnjailFinalityProvider(ctx, fp.sk.PubKey())
+	require.ErrorIs(t, err, btcstakingtypes.ErrFpNotJailed)
+
+	// the votes within the timeout keep the finality provider active
+	for i := 0; i < 5; i++ {
+		_, err := fp.vote(t, c, c.ProduceBlocks(1))
+		require.NoError(t, err)
+	}
+	_, jailed, err := c.QueryFinalityProviderSlashedOrJailed(ctx, fp.sk.PubKey())
+	require.NoError(t, err)
+	require.False(t, jailed)
+
+	c.ProduceBlocks(cfg.MaxMissedBlocks + cfg.FinalitySigTimeout)
+	_, jailed, err = c.QueryFinalityProviderSlashedOrJailed(ctx, fp.sk.PubKey())
+	require.NoError(t, err)
+	require.True(t, jailed)
+
+	tip := c.ProduceBlocks(1)
+	power, err := c.QueryFinalityProviderVotingPower(ctx, fp.sk.PubKey(), tip.Height)
+	require.NoError(t, err)
+	require.Zero(t, power)
+
+	jailedUntil, err := c.QueryFinalityProviderJailedUntil(ctx, fp.sk.PubKey())
+	require.NoError(t, err)
+	require.True(t, jailedUntil.After(time.Now()))
+	_, err = c.UnjailFinalityProvider(ctx, fp.sk.PubKey())
+	require.ErrorIs(t, err, finalitytypes.ErrJailingPeriodNotPassed)
+}
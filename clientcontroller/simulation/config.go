@@ -0,0 +1,67 @@
+package simulation
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultBlockInterval            = 5 * time.Second
+	defaultVotingPower              = 100
+	defaultMinPubRand               = 100
+	defaultFinalitySigTimeout       = 3
+	defaultMaxMissedBlocks          = 50
+	defaultJailDuration             = 1 * time.Minute
+	defaultFinalityActivationHeight = 0
+)
+
+// Config defines the parameters of the simulated consumer chain
+type Config struct {
+	// BlockInterval is the interval between two blocks, and blocks are only
+	// produced by ProduceBlocks if it is zero
+	BlockInterval time.Duration
+	// DefaultVotingPower is the voting power assigned to a newly registered
+	// finality provider
+	DefaultVotingPower uint64
+	// MinPubRand is the minimum number of public randomness in a commit
+	MinPubRand uint64
+	// FinalityActivationHeight is the height from which finality signatures
+	// and public randomness commits are accepted
+	FinalityActivationHeight uint64
+	// FinalitySigTimeout is the number of blocks after which a block that is
+	// not voted by a finality provider with voting power counts as missed
+	FinalitySigTimeout uint64
+	// MaxMissedBlocks is the number of consecutive missed blocks after which
+	// a finality provider is jailed, and zero disables jailing
+	MaxMissedBlocks uint64
+	// JailDuration is the duration a finality provider stays jailed
+	JailDuration time.Duration
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		BlockInterval:            defaultBlockInterval,
+		DefaultVotingPower:       defaultVotingPower,
+		MinPubRand:               defaultMinPubRand,
+		FinalityActivationHeight: defaultFinalityActivationHeight,
+		FinalitySigTimeout:       defaultFinalitySigTimeout,
+		MaxMissedBlocks:          defaultMaxMissedBlocks,
+		JailDuration:             defaultJailDuration,
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.BlockInterval < 0 {
+		return fmt.Errorf("block interval should not be negative")
+	}
+
+	if cfg.MaxMissedBlocks > 0 && cfg.FinalitySigTimeout == 0 {
+		return fmt.Errorf("finality signature timeout should be positive when jailing is enabled")
+	}
+
+	if cfg.JailDuration < 0 {
+		return fmt.Errorf("jail duration should not be negative")
+	}
+
+	return nil
+}
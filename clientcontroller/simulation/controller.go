@@ -0,0 +1,521 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"go.uber.org/zap"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+var _ clientcontroller.ClientController = &Chain{}
+
+// RegisterFinalityProvider registers the finality provider with the default
+// voting power. The address of the finality provider is derived from its
+// key, as the simulated chain has no transaction signer
+func (c *Chain) RegisterFinalityProvider(
+	_ context.Context,
+	fpPk *btcec.PublicKey,
+	pop []byte,
+	commission *sdkmath.LegacyDec,
+	description []byte,
+) (*types.TxResponse, error) {
+	var bbnPop btcstakingtypes.ProofOfPossessionBTC
+	if err := bbnPop.Unmarshal(pop); err != nil {
+		return nil, fmt.Errorf("invalid proof-of-possession: %w", err)
+	}
+
+	var sdkDescription stakingtypes.Description
+	if err := sdkDescription.Unmarshal(description); err != nil {
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	btcPk := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
+	if _, ok := c.fps[btcPk.MarshalHex()]; ok {
+		return nil, wrapf(btcstakingtypes.ErrFpRegistered, "finality provider %s", btcPk.MarshalHex())
+	}
+
+	c.fps[btcPk.MarshalHex()] = &finalityProvider{
+		btcPk:            btcPk,
+		addr:             sdk.AccAddress(tmhash.SumTruncated(*btcPk)).String(),
+		pop:              &bbnPop,
+		description:      &sdkDescription,
+		commission:       commission,
+		registeredHeight: c.tipLocked().height,
+		votingPower:      c.cfg.DefaultVotingPower,
+		votes:            make(map[uint64][]byte),
+		forkVotes:        make(map[uint64][]byte),
+	}
+
+	c.logger.Info("registered the finality provider on the simulated chain",
+		zap.String("pk", btcPk.MarshalHex()),
+		zap.Uint64("voting_power", c.cfg.DefaultVotingPower),
+	)
+
+	return c.newTxResponseLocked(), nil
+}
+
+// EditFinalityProvider edits the description and commission of the finality
+// provider, keeping the fields that are not given
+func (c *Chain) EditFinalityProvider(_ context.Context, fpPk *btcec.PublicKey, rate *sdkmath.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	var reqDesc proto.Description
+	if err := protobuf.Unmarshal(description, &reqDesc); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	getValueOrDefault := func(reqValue, defaultValue string) string {
+		if reqValue != "" {
+			return reqValue
+		}
+
+		return defaultValue
+	}
+
+	fp.description = &stakingtypes.Description{
+		Moniker:         getValueOrDefault(reqDesc.Moniker, fp.description.Moniker),
+		Identity:        getValueOrDefault(reqDesc.Identity, fp.description.Identity),
+		Website:         getValueOrDefault(reqDesc.Website, fp.description.Website),
+		SecurityContact: getValueOrDefault(reqDesc.SecurityContact, fp.description.SecurityContact),
+		Details:         getValueOrDefault(reqDesc.Details, fp.description.Details),
+	}
+	if rate != nil {
+		fp.commission = rate
+	}
+
+	return &btcstakingtypes.MsgEditFinalityProvider{
+		Addr:        fp.addr,
+		BtcPk:       fp.btcPk.MustMarshal(),
+		Description: fp.description,
+		Commission:  fp.commission,
+	}, nil
+}
+
+// CommitPubRandList verifies the signature over the public randomness commit
+// and stores it, which must not overlap with the previous commits
+func (c *Chain) CommitPubRandList(
+	_ context.Context,
+	fpPk *btcec.PublicKey,
+	startHeight uint64,
+	numPubRand uint64,
+	commitment []byte,
+	sig *schnorr.Signature,
+) (*types.TxResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return nil, err
+	}
+	if fp.slashedHeight > 0 {
+		return nil, wrapf(btcstakingtypes.ErrFpAlreadySlashed, "finality provider %s", fp.btcPk.MarshalHex())
+	}
+
+	if numPubRand < c.cfg.MinPubRand {
+		return nil, wrapf(finalitytypes.ErrTooFewPubRand, "required minimum: %d, actual: %d", c.cfg.MinPubRand, numPubRand)
+	}
+	if startHeight < c.cfg.FinalityActivationHeight {
+		return nil, wrapf(finalitytypes.ErrFinalityNotActivated, "public rand commit start block height %d is lower than the finality activation height %d",
+			startHeight, c.cfg.FinalityActivationHeight)
+	}
+
+	msg := &finalitytypes.MsgCommitPubRandList{
+		FpBtcPk:     fp.btcPk,
+		StartHeight: startHeight,
+		NumPubRand:  numPubRand,
+		Commitment:  commitment,
+		Sig:         bbntypes.NewBIP340SignatureFromBTCSig(sig),
+	}
+	if err := msg.VerifySig(); err != nil {
+		return nil, wrapf(finalitytypes.ErrInvalidPubRand, "invalid signature over the public randomness list: %v", err)
+	}
+
+	if lastCommit := fp.lastPubRandCommit(); lastCommit != nil && startHeight <= lastCommit.EndHeight() {
+		return nil, wrapf(finalitytypes.ErrInvalidPubRand, "the start height %d has overlap with the height of the highest public randomness committed %d",
+			startHeight, lastCommit.EndHeight())
+	}
+
+	fp.pubRandCommits = append(fp.pubRandCommits, &finalitytypes.PubRandCommit{
+		StartHeight: startHeight,
+		NumPubRand:  numPubRand,
+		Commitment:  commitment,
+	})
+
+	return c.newTxResponseLocked(), nil
+}
+
+// SubmitFinalitySig submits a single finality signature
+func (c *Chain) SubmitFinalitySig(
+	ctx context.Context,
+	fpPk *btcec.PublicKey,
+	block *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proof []byte,
+	sig *btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	return c.SubmitBatchFinalitySigs(
+		ctx, fpPk, []*types.BlockInfo{block}, []*btcec.FieldVal{pubRand},
+		[][]byte{proof}, []*btcec.ModNScalar{sig},
+	)
+}
+
+// SubmitBatchFinalitySigs verifies the finality signatures and records the
+// votes. The batch is applied atomically as a single transaction, and a batch
+// containing a duplicated vote is ignored the same way as the Babylon
+// controller ignores the expected error
+func (c *Chain) SubmitBatchFinalitySigs(
+	_ context.Context,
+	fpPk *btcec.PublicKey,
+	blocks []*types.BlockInfo,
+	pubRandList []*btcec.FieldVal,
+	proofList [][]byte,
+	sigs []*btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	// the tx is accepted and included at once
+	broadcastAt := time.Now()
+	if len(blocks) != len(sigs) {
+		return nil, fmt.Errorf("the number of blocks %v should match the number of finality signatures %v", len(blocks), len(sigs))
+	}
+	if len(blocks) != len(pubRandList) || len(blocks) != len(proofList) {
+		return nil, fmt.Errorf("the number of blocks %v should match the number of public randomness %v and proofs %v",
+			len(blocks), len(pubRandList), len(proofList))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return nil, err
+	}
+	if fp.slashedHeight > 0 {
+		return nil, wrapf(btcstakingtypes.ErrFpAlreadySlashed, "finality provider %s", fp.btcPk.MarshalHex())
+	}
+	if fp.jailed {
+		return nil, wrapf(btcstakingtypes.ErrFpAlreadyJailed, "finality provider %s", fp.btcPk.MarshalHex())
+	}
+
+	batchHeights := make(map[uint64]struct{}, len(blocks))
+	for i, b := range blocks {
+		err := c.verifyFinalitySigLocked(fp, b, pubRandList[i], proofList[i], sigs[i])
+		if _, ok := batchHeights[b.Height]; ok && err == nil {
+			err = wrapf(finalitytypes.ErrDuplicatedFinalitySig, "height %d is voted twice in the batch", b.Height)
+		}
+		if errors.Is(err, finalitytypes.ErrDuplicatedFinalitySig) {
+			c.logger.Debug("ignored the batch of finality signatures with a duplicated vote",
+				zap.String("pk", fp.btcPk.MarshalHex()),
+				zap.Uint64("height", b.Height),
+			)
+
+			return &types.TxResponse{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		batchHeights[b.Height] = struct{}{}
+	}
+
+	for _, b := range blocks {
+		c.recordVoteLocked(fp, b)
+	}
+	c.tallyBlocksLocked()
+
+	res := c.newTxResponseLocked()
+	res.BroadcastAt = broadcastAt
+
+	return res, nil
+}
+
+func (c *Chain) verifyFinalitySigLocked(
+	fp *finalityProvider,
+	b *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proof []byte,
+	sig *btcec.ModNScalar,
+) error {
+	if b.Height < c.cfg.FinalityActivationHeight {
+		return wrapf(finalitytypes.ErrFinalityNotActivated, "finality block height %d is lower than the finality activation height %d",
+			b.Height, c.cfg.FinalityActivationHeight)
+	}
+
+	indexedBlock, err := c.getBlockLocked(b.Height)
+	if err != nil {
+		return err
+	}
+	if indexedBlock.votingPower[fp.btcPk.MarshalHex()] == 0 {
+		return wrapf(finalitytypes.ErrInvalidFinalitySig, "the finality provider %s does not have voting power at height %d",
+			fp.btcPk.MarshalHex(), b.Height)
+	}
+
+	if votedHash, ok := fp.votes[b.Height]; ok && bytes.Equal(votedHash, b.Hash) {
+		return wrapf(finalitytypes.ErrDuplicatedFinalitySig, "height %d", b.Height)
+	}
+	if votedHash, ok := fp.forkVotes[b.Height]; ok && bytes.Equal(votedHash, b.Hash) {
+		return wrapf(finalitytypes.ErrDuplicatedFinalitySig, "height %d", b.Height)
+	}
+
+	prCommit := fp.pubRandCommitAt(b.Height)
+	if prCommit == nil {
+		return wrapf(finalitytypes.ErrPubRandNotFound, "height %d", b.Height)
+	}
+
+	var cmtProof cmtcrypto.Proof
+	if err := cmtProof.Unmarshal(proof); err != nil {
+		return wrapf(finalitytypes.ErrInvalidFinalitySig, "invalid inclusion proof: %v", err)
+	}
+
+	msg := &finalitytypes.MsgAddFinalitySig{
+		FpBtcPk:      fp.btcPk,
+		BlockHeight:  b.Height,
+		PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRand),
+		Proof:        &cmtProof,
+		BlockAppHash: b.Hash,
+		FinalitySig:  bbntypes.NewSchnorrEOTSSigFromModNScalar(sig),
+	}
+	if err := finalitytypes.VerifyFinalitySig(msg, prCommit); err != nil {
+		if errors.Is(err, finalitytypes.ErrInvalidFinalitySig) {
+			return clientcontroller.ClassifyError(err)
+		}
+
+		return wrapf(finalitytypes.ErrInvalidFinalitySig, "%v", err)
+	}
+
+	return nil
+}
+
+// UnjailFinalityProvider unjails the finality provider once the jailing
+// period has passed
+func (c *Chain) UnjailFinalityProvider(_ context.Context, fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return nil, err
+	}
+	if fp.slashedHeight > 0 {
+		return nil, wrapf(btcstakingtypes.ErrFpAlreadySlashed, "finality provider %s", fp.btcPk.MarshalHex())
+	}
+	if !fp.jailed {
+		return nil, wrapf(btcstakingtypes.ErrFpNotJailed, "finality provider %s", fp.btcPk.MarshalHex())
+	}
+	if time.Now().Before(fp.jailedUntil) {
+		return nil, wrapf(finalitytypes.ErrJailingPeriodNotPassed, "current time: %v, jailing until: %v", time.Now(), fp.jailedUntil)
+	}
+
+	fp.jailed = false
+	c.logger.Info("unjailed the finality provider on the simulated chain",
+		zap.String("pk", fp.btcPk.MarshalHex()))
+
+	return c.newTxResponseLocked(), nil
+}
+
+// QueryFinalityProvider queries the finality provider by pk
+func (c *Chain) QueryFinalityProvider(_ context.Context, fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the finality provider: %w", err)
+	}
+
+	return &btcstakingtypes.QueryFinalityProviderResponse{FinalityProvider: fp.toResponse()}, nil
+}
+
+// QueryFinalityProviderVotingPower queries the voting power of the finality
+// provider at the given height, which is zero for the heights not produced yet
+func (c *Chain) QueryFinalityProviderVotingPower(_ context.Context, fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	b, err := c.getBlockLocked(blockHeight)
+	if err != nil {
+		// the voting power table is not updated yet, which is treated as
+		// the finality provider having no voting power
+		return 0, nil
+	}
+
+	return b.votingPower[bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()], nil
+}
+
+// QueryFinalityProviderSlashedOrJailed queries if the finality provider is slashed or jailed
+func (c *Chain) QueryFinalityProviderSlashedOrJailed(_ context.Context, fpPk *btcec.PublicKey) (bool, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to query the finality provider: %w", err)
+	}
+
+	return fp.slashedHeight > 0, fp.jailed, nil
+}
+
+// QueryFinalityProviderJailedUntil queries the time until which the finality provider is jailed
+func (c *Chain) QueryFinalityProviderJailedUntil(_ context.Context, fpPk *btcec.PublicKey) (time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query the signing info of the finality provider: %w", err)
+	}
+
+	return fp.jailedUntil, nil
+}
+
+// QueryFinalityProviderHighestVotedHeight queries the highest voted height of the given finality provider
+func (c *Chain) QueryFinalityProviderHighestVotedHeight(_ context.Context, fpPk *btcec.PublicKey) (uint64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query highest voted height: %w", err)
+	}
+
+	return fp.highestVotedHeight, nil
+}
+
+// QueryLatestFinalizedBlocks returns the latest finalized blocks, the latest first
+func (c *Chain) QueryLatestFinalizedBlocks(_ context.Context, count uint64) ([]*types.BlockInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var blocks []*types.BlockInfo
+	for i := len(c.blocks) - 1; i >= 0 && uint64(len(blocks)) < count; i-- {
+		if c.blocks[i].finalized {
+			blocks = append(blocks, c.blocks[i].toBlockInfo())
+		}
+	}
+
+	return blocks, nil
+}
+
+// QueryLastCommittedPublicRand returns the last public randomness commits by their start heights
+func (c *Chain) QueryLastCommittedPublicRand(_ context.Context, fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	commits := make(map[uint64]*finalitytypes.PubRandCommitResponse)
+	fp, err := c.getFinalityProviderLocked(fpPk)
+	if err != nil {
+		return commits, nil
+	}
+
+	for i := len(fp.pubRandCommits) - 1; i >= 0 && uint64(len(commits)) < count; i-- {
+		commit := fp.pubRandCommits[i]
+		commits[commit.StartHeight] = commit.ToResponse()
+	}
+
+	return commits, nil
+}
+
+// QueryBlock queries the block at the given height
+func (c *Chain) QueryBlock(_ context.Context, height uint64) (*types.BlockInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	b, err := c.getBlockLocked(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query indexed block at height %v: %w", height, err)
+	}
+
+	return b.toBlockInfo(), nil
+}
+
+// QueryBlocks returns at most limit blocks from startHeight to endHeight in ascending order
+func (c *Chain) QueryBlocks(_ context.Context, startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	if endHeight < startHeight {
+		return nil, fmt.Errorf("the startHeight %v should not be higher than the endHeight %v", startHeight, endHeight)
+	}
+	if limit == 0 {
+		return nil, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	endHeight = min(endHeight, c.tipLocked().height, startHeight+uint64(limit)-1)
+
+	var blocks []*types.BlockInfo
+	for height := max(startHeight, 1); height <= endHeight; height++ {
+		blocks = append(blocks, c.blocks[height-1].toBlockInfo())
+	}
+
+	return blocks, nil
+}
+
+// QueryBestBlock queries the tip block
+func (c *Chain) QueryBestBlock(_ context.Context) (*types.BlockInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.tipLocked().toBlockInfo(), nil
+}
+
+// QueryNodeStatus returns the status of the simulated chain, which is always synced
+func (c *Chain) QueryNodeStatus(_ context.Context) (*types.NodeStatus, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	tip := c.tipLocked()
+
+	return &types.NodeStatus{
+		LatestHeight:    tip.height,
+		LatestBlockTime: tip.time,
+	}, nil
+}
+
+// QueryActivatedHeight returns the first height with a non-empty voting power table
+func (c *Chain) QueryActivatedHeight(_ context.Context) (uint64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.activatedHeight == 0 {
+		return 0, wrapf(finalitytypes.ErrBTCStakingNotActivated, "failed to query activated height")
+	}
+
+	return c.activatedHeight, nil
+}
+
+// QueryFinalityActivationBlockHeight returns the configured finality activation height
+func (c *Chain) QueryFinalityActivationBlockHeight(_ context.Context) (uint64, error) {
+	return c.cfg.FinalityActivationHeight, nil
+}
+
+// Close stops producing blocks
+func (c *Chain) Close() error {
+	c.Stop()
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package clientcontroller
+
+import (
+	"context"
+	"time"
+
+	"cosmossdk.io/math"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/babylonlabs-io/finality-provider/tracing"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+var _ ClientController = &TracedClientController{}
+
+// TracedClientController is a ClientController that traces the calls to the
+// wrapped one, as the children of the span of the context of the caller
+type TracedClientController struct {
+	cc ClientController
+	// name prefixes the names of the spans, e.g., BabylonController
+	name string
+}
+
+// NewTracedClientController returns a client controller tracing the calls to
+// the given one with spans named after it
+func NewTracedClientController(cc ClientController, name string) *TracedClientController {
+	return &TracedClientController{cc: cc, name: name}
+}
+
+func (t *TracedClientController) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, func(error)) {
+	ctx, span := tracing.Start(ctx, t.name+"."+method, attrs...)
+
+	return ctx, func(err error) { tracing.End(span, err) }
+}
+
+func (t *TracedClientController) RegisterFinalityProvider(
+	ctx context.Context,
+	fpPk *btcec.PublicKey,
+	pop []byte,
+	commission *math.LegacyDec,
+	description []byte,
+) (*types.TxResponse, error) {
+	ctx, end := t.start(ctx, "RegisterFinalityProvider")
+	res, err := t.cc.RegisterFinalityProvider(ctx, fpPk, pop, commission, description)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) EditFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	ctx, end := t.start(ctx, "EditFinalityProvider")
+	res, err := t.cc.EditFinalityProvider(ctx, fpPk, commission, description)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) CommitPubRandList(ctx context.Context, fpPk *btcec.PublicKey, startHeight uint64, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types.TxResponse, error) {
+	ctx, end := t.start(ctx, "CommitPubRandList",
+		tracing.Uint64("start_height", startHeight), tracing.Uint64("num_pub_rand", numPubRand))
+	res, err := t.cc.CommitPubRandList(ctx, fpPk, startHeight, numPubRand, commitment, sig)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) SubmitFinalitySig(ctx context.Context, fpPk *btcec.PublicKey, block *types.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types.TxResponse, error) {
+	ctx, end := t.start(ctx, "SubmitFinalitySig", tracing.Uint64("height", block.Height))
+	res, err := t.cc.SubmitFinalitySig(ctx, fpPk, block, pubRand, proof, sig)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) SubmitBatchFinalitySigs(ctx context.Context, fpPk *btcec.PublicKey, blocks []*types.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types.TxResponse, error) {
+	var attrs []attribute.KeyValue
+	if len(blocks) > 0 {
+		attrs = append(attrs,
+			tracing.Uint64("start_height", blocks[0].Height),
+			tracing.Uint64("end_height", blocks[len(blocks)-1].Height))
+	}
+	ctx, end := t.start(ctx, "SubmitBatchFinalitySigs", attrs...)
+	res, err := t.cc.SubmitBatchFinalitySigs(ctx, fpPk, blocks, pubRandList, proofList, sigs)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) UnjailFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	ctx, end := t.start(ctx, "UnjailFinalityProvider")
+	res, err := t.cc.UnjailFinalityProvider(ctx, fpPk)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	ctx, end := t.start(ctx, "QueryFinalityProvider")
+	res, err := t.cc.QueryFinalityProvider(ctx, fpPk)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryFinalityProviderVotingPower(ctx context.Context, fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	ctx, end := t.start(ctx, "QueryFinalityProviderVotingPower", tracing.Uint64("height", blockHeight))
+	res, err := t.cc.QueryFinalityProviderVotingPower(ctx, fpPk, blockHeight)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryFinalityProviderSlashedOrJailed(ctx context.Context, fpPk *btcec.PublicKey) (bool, bool, error) {
+	ctx, end := t.start(ctx, "QueryFinalityProviderSlashedOrJailed")
+	slashed, jailed, err := t.cc.QueryFinalityProviderSlashedOrJailed(ctx, fpPk)
+	end(err)
+
+	return slashed, jailed, err
+}
+
+func (t *TracedClientController) QueryFinalityProviderJailedUntil(ctx context.Context, fpPk *btcec.PublicKey) (time.Time, error) {
+	ctx, end := t.start(ctx, "QueryFinalityProviderJailedUntil")
+	res, err := t.cc.QueryFinalityProviderJailedUntil(ctx, fpPk)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryFinalityProviderHighestVotedHeight(ctx context.Context, fpPk *btcec.PublicKey) (uint64, error) {
+	ctx, end := t.start(ctx, "QueryFinalityProviderHighestVotedHeight")
+	res, err := t.cc.QueryFinalityProviderHighestVotedHeight(ctx, fpPk)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryLatestFinalizedBlocks(ctx context.Context, count uint64) ([]*types.BlockInfo, error) {
+	ctx, end := t.start(ctx, "QueryLatestFinalizedBlocks", tracing.Uint64("count", count))
+	res, err := t.cc.QueryLatestFinalizedBlocks(ctx, count)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryLastCommittedPublicRand(ctx context.Context, fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	ctx, end := t.start(ctx, "QueryLastCommittedPublicRand", tracing.Uint64("count", count))
+	res, err := t.cc.QueryLastCommittedPublicRand(ctx, fpPk, count)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryBlock(ctx context.Context, height uint64) (*types.BlockInfo, error) {
+	ctx, end := t.start(ctx, "QueryBlock", tracing.Uint64("height", height))
+	res, err := t.cc.QueryBlock(ctx, height)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryBlocks(ctx context.Context, startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	ctx, end := t.start(ctx, "QueryBlocks",
+		tracing.Uint64("start_height", startHeight), tracing.Uint64("end_height", endHeight))
+	res, err := t.cc.QueryBlocks(ctx, startHeight, endHeight, limit)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryBestBlock(ctx context.Context) (*types.BlockInfo, error) {
+	ctx, end := t.start(ctx, "QueryBestBlock")
+	res, err := t.cc.QueryBestBlock(ctx)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryNodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	ctx, end := t.start(ctx, "QueryNodeStatus")
+	res, err := t.cc.QueryNodeStatus(ctx)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryActivatedHeight(ctx context.Context) (uint64, error) {
+	ctx, end := t.start(ctx, "QueryActivatedHeight")
+	res, err := t.cc.QueryActivatedHeight(ctx)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) QueryFinalityActivationBlockHeight(ctx context.Context) (uint64, error) {
+	ctx, end := t.start(ctx, "QueryFinalityActivationBlockHeight")
+	res, err := t.cc.QueryFinalityActivationBlockHeight(ctx)
+	end(err)
+
+	return res, err
+}
+
+func (t *TracedClientController) Close() error {
+	return t.cc.Close()
+}
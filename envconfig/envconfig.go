@@ -0,0 +1,245 @@
+package envconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+)
+
+const (
+	// FileSuffix is the suffix of the environment variables holding the path
+	// of a file the value is read from, e.g., a mounted secret
+	FileSuffix = "_FILE"
+
+	// Redacted replaces the value of the secret options once redacted
+	Redacted = "<redacted>"
+
+	// secretTag marks the options holding a secret, e.g., a token, which are
+	// redacted before the config is printed
+	secretTag = "secret"
+)
+
+// LookupEnvFunc looks up the value of an environment variable, as os.LookupEnv
+type LookupEnvFunc func(key string) (string, bool)
+
+// Load parses the config file into cfg and then applies the overrides of the
+// environment variables with the given prefix, if lookupEnv is not nil. The
+// names of the applied environment variables are returned
+func Load(cfg any, cfgFile, prefix string, lookupEnv LookupEnvFunc) ([]string, error) {
+	parser := flags.NewParser(cfg, flags.Default)
+	iniParser := flags.NewIniParser(parser)
+	if err := iniParser.ParseFile(cfgFile); err != nil {
+		return nil, err
+	}
+
+	if lookupEnv == nil {
+		return nil, nil
+	}
+
+	overrides, err := envOverrides(parser, prefix, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]string, 0, len(overrides))
+	for _, o := range overrides {
+		// the override is parsed as an INI file, so that the value is
+		// converted and validated the same way as the ones of the config file
+		if err := iniParser.Parse(strings.NewReader(o.ini)); err != nil {
+			var iniErr *flags.IniError
+			if errors.As(err, &iniErr) {
+				err = errors.New(iniErr.Message)
+			}
+
+			return nil, fmt.Errorf("invalid %s: %w", o.name, err)
+		}
+		applied = append(applied, o.name)
+	}
+
+	return applied, nil
+}
+
+// Lookup returns the value of the given environment variable, which is read
+// from the file of the variable suffixed with FileSuffix if that one is set
+// instead
+func Lookup(name string, lookupEnv LookupEnvFunc) (string, bool, error) {
+	value, ok := lookupEnv(name)
+	path, fromFile := lookupEnv(name + FileSuffix)
+	if ok && fromFile {
+		return "", false, fmt.Errorf("only one of %s and %s can be set", name, name+FileSuffix)
+	}
+	if !fromFile {
+		return value, ok, nil
+	}
+
+	value, err := ReadSecretFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read the file of %s: %w", name+FileSuffix, err)
+	}
+
+	return value, true, nil
+}
+
+// ReadSecretFile returns the content of the file at the given path without
+// its trailing newline
+func ReadSecretFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// ResolveSecret returns the given value if set, the content of the file at
+// the given path if set, or the value of the given environment variable
+// otherwise, so that a secret is not passed on the command line
+func ResolveSecret(value, path, envName string) (string, error) {
+	if value != "" && path != "" {
+		return "", fmt.Errorf("only one of the value and the file of the secret can be set")
+	}
+	if value != "" {
+		return value, nil
+	}
+	if path != "" {
+		return ReadSecretFile(path)
+	}
+
+	value, _, err := Lookup(envName, os.LookupEnv)
+
+	return value, err
+}
+
+// EnvName returns the name of the environment variable overriding the option
+// of the given long name and namespace, e.g., FPD_BABYLON_CHAIN_ID for the
+// option chain-id of the namespace babylon
+func EnvName(prefix, namespace, longName string) string {
+	name := longName
+	if namespace != "" {
+		name = namespace + "." + longName
+	}
+
+	return prefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(name))
+}
+
+// override is the INI content setting the option overridden by the
+// environment variable of the given name
+type override struct {
+	name string
+	ini  string
+}
+
+// envOverrides returns the overrides of the options by the environment
+// variables, in order of name
+func envOverrides(parser *flags.Parser, prefix string, lookupEnv LookupEnvFunc) ([]*override, error) {
+	var (
+		overrides []*override
+		err       error
+	)
+	eachGroup(parser.Groups(), "", func(group *flags.Group, namespace string) {
+		for _, opt := range group.Options() {
+			if err != nil || opt.LongName == "" {
+				continue
+			}
+
+			name := EnvName(prefix, namespace, opt.LongName)
+			value, ok, lookupErr := Lookup(name, lookupEnv)
+			if lookupErr != nil {
+				err = lookupErr
+
+				continue
+			}
+			if !ok {
+				continue
+			}
+
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "[%s]\n", group.ShortDescription)
+
+			// a slice is set by a comma-separated list of values
+			values := []string{value}
+			if opt.Field().Type.Kind() == reflect.Slice {
+				values = strings.Split(value, ",")
+			}
+			for _, v := range values {
+				fmt.Fprintf(&sb, "%s = %s\n", iniName(opt), strconv.Quote(strings.TrimSpace(v)))
+			}
+			overrides = append(overrides, &override{name: name, ini: sb.String()})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].name < overrides[j].name
+	})
+
+	return overrides, nil
+}
+
+// eachGroup calls fn for each of the given groups and their subgroups, along
+// with their namespace
+func eachGroup(groups []*flags.Group, namespace string, fn func(group *flags.Group, namespace string)) {
+	for _, group := range groups {
+		groupNamespace := namespace
+		if group.Namespace != "" {
+			if groupNamespace != "" {
+				groupNamespace += "."
+			}
+			groupNamespace += group.Namespace
+		}
+
+		fn(group, groupNamespace)
+		eachGroup(group.Groups(), groupNamespace, fn)
+	}
+}
+
+// iniName returns the name of the option in the INI file
+func iniName(opt *flags.Option) string {
+	if name := opt.Field().Tag.Get("ini-name"); name != "" {
+		return name
+	}
+
+	return opt.Field().Name
+}
+
+// Redact replaces the non-empty values of the options of cfg tagged as
+// secret, descending into the nested config groups
+func Redact(cfg any) {
+	redact(reflect.ValueOf(cfg))
+}
+
+func redact(v reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+		if field.Tag.Get(secretTag) == "true" && fv.Kind() == reflect.String && fv.String() != "" {
+			fv.SetString(Redacted)
+
+			continue
+		}
+		if field.Tag.Get("group") != "" {
+			redact(fv)
+		}
+	}
+}
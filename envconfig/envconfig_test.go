@@ -0,0 +1,97 @@
+package envconfig_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/envconfig"
+)
+
+type testGroupConfig struct {
+	Addrs  []string      `long:"addr" description:"addresses"`
+	Token  string        `long:"token" description:"token" secret:"true"`
+	Period time.Duration `long:"period" description:"period"`
+}
+
+type testConfig struct {
+	LogLevel string           `long:"loglevel" description:"log level" choice:"info" choice:"debug"`
+	Count    uint32           `long:"count" description:"count"`
+	Group    *testGroupConfig `group:"group" namespace:"group"`
+}
+
+const testCfgFile = `[Application Options]
+LogLevel = info
+Count = 1
+
+[group]
+Addrs = a
+Period = 1s
+`
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfgFile := filepath.Join(dir, "test.conf")
+	require.NoError(t, os.WriteFile(cfgFile, []byte(testCfgFile), 0600))
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s3cret\n"), 0600))
+
+	env := map[string]string{
+		"TEST_COUNT":            "7",
+		"TEST_GROUP_ADDR":       "b, c",
+		"TEST_GROUP_TOKEN_FILE": tokenFile,
+		"OTHER_LOGLEVEL":        "debug",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		v, ok := env[key]
+
+		return v, ok
+	}
+
+	// the config file only
+	cfg := testConfig{Group: &testGroupConfig{}}
+	applied, err := envconfig.Load(&cfg, cfgFile, "TEST", nil)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Equal(t, uint32(1), cfg.Count)
+	require.Equal(t, []string{"a"}, cfg.Group.Addrs)
+
+	// the environment variables override the config file
+	cfg = testConfig{Group: &testGroupConfig{}}
+	applied, err = envconfig.Load(&cfg, cfgFile, "TEST", lookupEnv)
+	require.NoError(t, err)
+	require.Equal(t, []string{"TEST_COUNT", "TEST_GROUP_ADDR", "TEST_GROUP_TOKEN"}, applied)
+	require.Equal(t, "info", cfg.LogLevel)
+	require.Equal(t, uint32(7), cfg.Count)
+	require.Equal(t, []string{"b", "c"}, cfg.Group.Addrs)
+	require.Equal(t, "s3cret", cfg.Group.Token)
+	require.Equal(t, time.Second, cfg.Group.Period)
+
+	envconfig.Redact(&cfg)
+	require.Equal(t, envconfig.Redacted, cfg.Group.Token)
+	require.Equal(t, uint32(7), cfg.Count)
+
+	// the values are validated as the ones of the config file
+	env["TEST_LOGLEVEL"] = "trace"
+	_, err = envconfig.Load(&testConfig{Group: &testGroupConfig{}}, cfgFile, "TEST", lookupEnv)
+	require.ErrorContains(t, err, "invalid TEST_LOGLEVEL")
+	delete(env, "TEST_LOGLEVEL")
+
+	// a value cannot be both set and read from a file
+	env["TEST_GROUP_TOKEN"] = "token"
+	_, err = envconfig.Load(&testConfig{Group: &testGroupConfig{}}, cfgFile, "TEST", lookupEnv)
+	require.ErrorContains(t, err, "only one of TEST_GROUP_TOKEN and TEST_GROUP_TOKEN_FILE")
+}
+
+func TestEnvName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "FPD_LOGLEVEL", envconfig.EnvName("FPD", "", "loglevel"))
+	require.Equal(t, "FPD_BABYLON_CHAIN_ID", envconfig.EnvName("FPD", "babylon", "chain-id"))
+	require.Equal(t, "FPD_RETRY_VOTINGPOWER_ATTEMPTS", envconfig.EnvName("FPD", "retry.votingpower", "attempts"))
+}
@@ -2,16 +2,24 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 )
 
 var _ eotsmanager.EOTSManager = &EOTSManagerGRpcClient{}
@@ -21,8 +29,15 @@ type EOTSManagerGRpcClient struct {
 	conn   *grpc.ClientConn
 }
 
-func NewEOTSManagerGRpcClient(remoteAddr string) (*EOTSManagerGRpcClient, error) {
-	conn, err := grpc.NewClient(remoteAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewEOTSManagerGRpcClient connects to the EOTS manager at the given address,
+// with the given options added to the ones of the connection, e.g., WithLogger.
+// The calls are traced, along with the ones to the server
+func NewEOTSManagerGRpcClient(remoteAddr string, opts ...grpc.DialOption) (*EOTSManagerGRpcClient, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		tracing.DialOption(),
+	}, opts...)
+	conn, err := grpc.NewClient(remoteAddr, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build gRPC connection to %s: %w", remoteAddr, err)
 	}
@@ -32,17 +47,38 @@ func NewEOTSManagerGRpcClient(remoteAddr string) (*EOTSManagerGRpcClient, error)
 		conn:   conn,
 	}
 
-	if err := gClient.Ping(); err != nil {
+	if err := gClient.Ping(context.Background()); err != nil {
 		return nil, fmt.Errorf("the EOTS manager server is not responding: %w", err)
 	}
 
 	return gClient, nil
 }
 
-func (c *EOTSManagerGRpcClient) Ping() error {
+// WithLogger logs the calls to the EOTS manager along with their duration at
+// the debug level
+func WithLogger(logger *zap.Logger) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(func(
+		ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		logger.Debug("called the EOTS manager",
+			zap.String("method", method), zap.Duration("duration", time.Since(start)), zap.Error(err))
+
+		return err
+	})
+}
+
+// WithMetrics records the duration of the calls to the EOTS manager
+func WithMetrics(m *metrics.FpMetrics) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(m.EotsRPCClientInterceptor())
+}
+
+func (c *EOTSManagerGRpcClient) Ping(ctx context.Context) error {
 	req := &proto.PingRequest{}
 
-	_, err := c.client.Ping(context.Background(), req)
+	_, err := c.client.Ping(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -50,9 +86,9 @@ func (c *EOTSManagerGRpcClient) Ping() error {
 	return nil
 }
 
-func (c *EOTSManagerGRpcClient) CreateKey(name, passphrase, hdPath string) ([]byte, error) {
+func (c *EOTSManagerGRpcClient) CreateKey(ctx context.Context, name, passphrase, hdPath string) ([]byte, error) {
 	req := &proto.CreateKeyRequest{Name: name, Passphrase: passphrase, HdPath: hdPath}
-	res, err := c.client.CreateKey(context.Background(), req)
+	res, err := c.client.CreateKey(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +96,7 @@ func (c *EOTSManagerGRpcClient) CreateKey(name, passphrase, hdPath string) ([]by
 	return res.Pk, nil
 }
 
-func (c *EOTSManagerGRpcClient) CreateRandomnessPairList(uid, chainID []byte, startHeight uint64, num uint32, passphrase string) ([]*btcec.FieldVal, error) {
+func (c *EOTSManagerGRpcClient) CreateRandomnessPairList(ctx context.Context, uid, chainID []byte, startHeight uint64, num uint32, passphrase string) ([]*btcec.FieldVal, error) {
 	req := &proto.CreateRandomnessPairListRequest{
 		Uid:         uid,
 		ChainId:     chainID,
@@ -68,7 +104,7 @@ func (c *EOTSManagerGRpcClient) CreateRandomnessPairList(uid, chainID []byte, st
 		Num:         num,
 		Passphrase:  passphrase,
 	}
-	res, err := c.client.CreateRandomnessPairList(context.Background(), req)
+	res, err := c.client.CreateRandomnessPairList(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -83,20 +119,20 @@ func (c *EOTSManagerGRpcClient) CreateRandomnessPairList(uid, chainID []byte, st
 	return pubRandFieldValList, nil
 }
 
-func (c *EOTSManagerGRpcClient) SaveEOTSKeyName(pk *btcec.PublicKey, keyName string) error {
+func (c *EOTSManagerGRpcClient) SaveEOTSKeyName(ctx context.Context, pk *btcec.PublicKey, keyName string) error {
 	req := &proto.SaveEOTSKeyNameRequest{
 		KeyName: keyName,
 		EotsPk:  pk.SerializeUncompressed(),
 	}
-	_, err := c.client.SaveEOTSKeyName(context.Background(), req)
+	_, err := c.client.SaveEOTSKeyName(ctx, req)
 
 	return err
 }
 
-func (c *EOTSManagerGRpcClient) KeyRecord(uid []byte, passphrase string) (*types.KeyRecord, error) {
+func (c *EOTSManagerGRpcClient) KeyRecord(ctx context.Context, uid []byte, passphrase string) (*types.KeyRecord, error) {
 	req := &proto.KeyRecordRequest{Uid: uid, Passphrase: passphrase}
 
-	res, err := c.client.KeyRecord(context.Background(), req)
+	res, err := c.client.KeyRecord(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +145,7 @@ func (c *EOTSManagerGRpcClient) KeyRecord(uid []byte, passphrase string) (*types
 	}, nil
 }
 
-func (c *EOTSManagerGRpcClient) SignEOTS(uid, chaiID, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+func (c *EOTSManagerGRpcClient) SignEOTS(ctx context.Context, uid, chaiID, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
 	req := &proto.SignEOTSRequest{
 		Uid:        uid,
 		ChainId:    chaiID,
@@ -117,7 +153,10 @@ func (c *EOTSManagerGRpcClient) SignEOTS(uid, chaiID, msg []byte, height uint64,
 		Height:     height,
 		Passphrase: passphrase,
 	}
-	res, err := c.client.SignEOTS(context.Background(), req)
+	res, err := c.client.SignEOTS(ctx, req)
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, fmt.Errorf("%w: %s", types.ErrDoubleSign, status.Convert(err).Message())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +167,7 @@ func (c *EOTSManagerGRpcClient) SignEOTS(uid, chaiID, msg []byte, height uint64,
 	return &s, nil
 }
 
-func (c *EOTSManagerGRpcClient) UnsafeSignEOTS(uid, chaiID, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+func (c *EOTSManagerGRpcClient) UnsafeSignEOTS(ctx context.Context, uid, chaiID, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
 	req := &proto.SignEOTSRequest{
 		Uid:        uid,
 		ChainId:    chaiID,
@@ -136,7 +175,7 @@ func (c *EOTSManagerGRpcClient) UnsafeSignEOTS(uid, chaiID, msg []byte, height u
 		Height:     height,
 		Passphrase: passphrase,
 	}
-	res, err := c.client.UnsafeSignEOTS(context.Background(), req)
+	res, err := c.client.UnsafeSignEOTS(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -147,9 +186,9 @@ func (c *EOTSManagerGRpcClient) UnsafeSignEOTS(uid, chaiID, msg []byte, height u
 	return &s, nil
 }
 
-func (c *EOTSManagerGRpcClient) SignSchnorrSig(uid, msg []byte, passphrase string) (*schnorr.Signature, error) {
+func (c *EOTSManagerGRpcClient) SignSchnorrSig(ctx context.Context, uid, msg []byte, passphrase string) (*schnorr.Signature, error) {
 	req := &proto.SignSchnorrSigRequest{Uid: uid, Msg: msg, Passphrase: passphrase}
-	res, err := c.client.SignSchnorrSig(context.Background(), req)
+	res, err := c.client.SignSchnorrSig(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +201,28 @@ func (c *EOTSManagerGRpcClient) SignSchnorrSig(uid, msg []byte, passphrase strin
 	return sig, nil
 }
 
+// BackupDB writes a consistent snapshot of the database of the EOTS manager
+// daemon to w
+func (c *EOTSManagerGRpcClient) BackupDB(ctx context.Context, w io.Writer) error {
+	stream, err := c.client.BackupDB(ctx, &proto.BackupEOTSDBRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(res.Chunk); err != nil {
+			return err
+		}
+	}
+}
+
 func (c *EOTSManagerGRpcClient) Close() error {
 	return c.conn.Close()
 }
@@ -0,0 +1,306 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	bbncfg "github.com/babylonlabs-io/babylon/client/config"
+	"github.com/babylonlabs-io/babylon/client/query"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/backup"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+	"github.com/babylonlabs-io/finality-provider/migration"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// nodeQueryTimeout is the timeout of the queries to the Babylon node
+const nodeQueryTimeout = 20 * time.Second
+
+// errStaleSignRecords is returned when restoring a snapshot missing signing
+// records of heights the finality providers have voted at
+var errStaleSignRecords = errors.New("the snapshot is older than the votes of its finality providers")
+
+// NewDBCmd returns the db command, which manages the eotsd database
+func NewDBCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the eotsd database.",
+	}
+
+	cmd.AddCommand(newDBMigrateCmd(), newDBBackupCmd(), newDBRestoreCmd(), newDBInspectCmd())
+
+	return cmd
+}
+
+func newDBMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Run the pending schema migrations of the eotsd database.",
+		Long: "Run the pending schema migrations of the eotsd database, which are also run upon start. " +
+			"With --dry-run, the pending migrations are only listed. eotsd should be stopped beforehand.",
+		Example: `eotsd db migrate --dry-run --home /home/user/.eotsd`,
+		Args:    cobra.NoArgs,
+		RunE:    runDBMigrate,
+	}
+
+	cmd.Flags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "The path to the eotsd home directory")
+	cmd.Flags().Bool(dryRunFlag, false, "List the pending migrations without running them")
+
+	return cmd
+}
+
+func runDBMigrate(cmd *cobra.Command, _ []string) error {
+	homePath, err := getHomePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	dryRun, err := cmd.Flags().GetBool(dryRunFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", dryRunFlag, err)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer dbBackend.Close()
+
+	var plan *migration.Plan
+	if dryRun {
+		plan, err = store.CheckDBMigrations(dbBackend)
+	} else {
+		plan, err = store.MigrateDB(dbBackend)
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd.Print(plan)
+	if !dryRun && len(plan.Pending) > 0 {
+		cmd.Printf("migrated the database to schema version %d\n", plan.Target)
+	}
+
+	return nil
+}
+
+func newDBBackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup [output-file]",
+		Short: "Write a snapshot of the database of the running eotsd daemon.",
+		Long: "Write a consistent snapshot of the database of the running eotsd daemon to the given file. " +
+			"The snapshot is taken within a read transaction, so the daemon keeps running meanwhile. " +
+			"The daemon is reached at --rpc-client, or at the RPC listener of the config otherwise.",
+		Example: `eotsd db backup /backups/eots.db --home /home/user/.eotsd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runDBBackup,
+	}
+
+	cmd.Flags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "The path to the eotsd home directory")
+	cmd.Flags().String(rpcClientFlag, "", "The RPC address of the running eotsd")
+
+	return cmd
+}
+
+func runDBBackup(cmd *cobra.Command, args []string) error {
+	rpcAddr, err := cmd.Flags().GetString(rpcClientFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", rpcClientFlag, err)
+	}
+
+	if rpcAddr == "" {
+		homePath, err := getHomePath(cmd)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.LoadConfig(homePath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		rpcAddr = cfg.RPCListener
+	}
+
+	eotsClient, err := client.NewEOTSManagerGRpcClient(rpcAddr)
+	if err != nil {
+		return err
+	}
+	defer eotsClient.Close()
+
+	output := util.CleanAndExpandPath(args[0])
+	err = backup.WriteFile(output, func(w io.Writer) error {
+		return eotsClient.BackupDB(cmd.Context(), w)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to back up the database: %w", err)
+	}
+
+	var plan *migration.Plan
+	err = backup.Validate(output, func(db kvdb.Backend) error {
+		plan, err = store.ValidateDB(db)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("invalid snapshot %s: %w", output, err)
+	}
+
+	cmd.Printf("wrote the snapshot of schema version %d to %s\n", plan.Current, output)
+
+	return nil
+}
+
+func newDBRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [snapshot-file]",
+		Short: "Restore the eotsd database from a snapshot.",
+		Long: "Restore the eotsd database from a snapshot written by eotsd db backup or by the scheduled backups, " +
+			"once the snapshot is validated. eotsd should be stopped beforehand. The replaced database is kept " +
+			"next to the restored one.\n\n" +
+			"Restoring signing records older than the votes of the finality providers would allow the EOTS keys " +
+			"to sign a second time at the same height, which is slashable. The restore is thus refused if the " +
+			"Babylon node at --node shows that a finality provider of the snapshot has voted at a height above " +
+			"the highest height it signed in the snapshot, unless --force is set.",
+		Example: `eotsd db restore /backups/eots.db --node http://127.0.0.1:26657 --home /home/user/.eotsd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runDBRestore,
+	}
+
+	cmd.Flags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "The path to the eotsd home directory")
+	cmd.Flags().String(sdkflags.FlagNode, "", "The RPC address of the Babylon node the signing records are checked against")
+	cmd.Flags().Bool(forceFlag, false, "Restore the snapshot without checking the signing records against the chain")
+
+	return cmd
+}
+
+func runDBRestore(cmd *cobra.Command, args []string) error {
+	homePath, err := getHomePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	nodeAddr, err := cmd.Flags().GetString(sdkflags.FlagNode)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", sdkflags.FlagNode, err)
+	}
+
+	force, err := cmd.Flags().GetBool(forceFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", forceFlag, err)
+	}
+
+	if nodeAddr == "" && !force {
+		return fmt.Errorf("the --%s flag is required to check the signing records against the chain, "+
+			"set --%s to skip the check", sdkflags.FlagNode, forceFlag)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var plan *migration.Plan
+	replaced, err := backup.Restore(util.CleanAndExpandPath(args[0]), cfg.DatabaseConfig.DBConfigToBoltBackendConfig(),
+		func(db kvdb.Backend) error {
+			plan, err = store.ValidateDB(db)
+			if err != nil {
+				return err
+			}
+
+			if force {
+				cmd.Println("skipped checking the signing records against the chain")
+
+				return nil
+			}
+
+			return checkSignRecords(cmd.Context(), db, nodeAddr)
+		})
+	if err != nil {
+		return fmt.Errorf("failed to restore the database: %w", err)
+	}
+
+	cmd.Printf("restored the database of schema version %d\n", plan.Current)
+	if replaced != "" {
+		cmd.Printf("the replaced database is kept at %s\n", replaced)
+	}
+
+	return nil
+}
+
+// checkSignRecords checks that the snapshot has the signing records of the
+// heights the finality providers of its EOTS keys have voted at, according
+// to the Babylon node at the given address
+func checkSignRecords(ctx context.Context, db kvdb.Backend, nodeAddr string) error {
+	qc, err := query.New(&bbncfg.BabylonQueryConfig{RPCAddr: nodeAddr, Timeout: nodeQueryTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to create the Babylon query client: %w", err)
+	}
+
+	status, err := qc.RPCClient.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query the status of the Babylon node: %w", err)
+	}
+	chainID := status.NodeInfo.Network
+
+	// the pending migrations are run on the copy of the snapshot to restore,
+	// as upon start
+	eotsStore, err := store.NewEOTSStore(db)
+	if err != nil {
+		return err
+	}
+
+	keyNames, err := eotsStore.GetAllEOTSKeyNames()
+	if err != nil {
+		return err
+	}
+
+	signedHeights, err := eotsStore.GetLatestSignRecordHeights([]byte(chainID))
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for keyName, pk := range keyNames {
+		pkHex := hex.EncodeToString(pk)
+		res, err := qc.FinalityProvider(pkHex)
+		if err != nil {
+			if errors.Is(clientcontroller.ClassifyError(err), btcstakingtypes.ErrFpNotFound) {
+				continue
+			}
+
+			return fmt.Errorf("failed to query the finality provider %s: %w", pkHex, err)
+		}
+
+		votedHeight := uint64(res.FinalityProvider.HighestVotedHeight)
+		if votedHeight > signedHeights[pkHex] {
+			stale = append(stale, fmt.Sprintf("%s (key %s): voted at height %d, signed up to height %d",
+				pkHex, keyName, votedHeight, signedHeights[pkHex]))
+		}
+	}
+
+	if len(stale) > 0 {
+		sort.Strings(stale)
+
+		return fmt.Errorf("%w on chain %s:\n%s", errStaleSignRecords, chainID, strings.Join(stale, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/backup"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func newDBInspectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Print the content of the eotsd database.",
+		Long: "Print the content of the eotsd database without changing it. The database of the config is " +
+			"inspected by default, which requires eotsd to be stopped. Otherwise, --db-file inspects the given " +
+			"database file, e.g., a snapshot, and --rpc-client inspects a snapshot of the database of the " +
+			"running eotsd.",
+	}
+
+	cmd.PersistentFlags().String(sdkflags.FlagHome, config.DefaultEOTSDir, "The path to the eotsd home directory")
+	cmd.PersistentFlags().String(dbFileFlag, "", "The database file to inspect, e.g., a snapshot")
+	cmd.PersistentFlags().String(rpcClientFlag, "", "The RPC address of the running eotsd whose database is inspected")
+	cmd.PersistentFlags().String(outputFlag, outputTable, "The output format, either table or json")
+	cmd.PersistentFlags().String(eotsPkFlag, "", "Only print the records of the given EOTS public key in hex")
+
+	cmd.AddCommand(newDBInspectKeysCmd(), newDBInspectSignRecordsCmd())
+
+	return cmd
+}
+
+func newDBInspectKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "keys",
+		Short:   "Print the names of the EOTS keys stored in the eotsd database.",
+		Example: `eotsd db inspect keys --db-file /backups/eots.db --output json`,
+		Args:    cobra.NoArgs,
+		RunE:    runDBInspectKeys,
+	}
+
+	return cmd
+}
+
+func runDBInspectKeys(cmd *cobra.Command, _ []string) error {
+	output, filter, err := readInspectFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	db, closeDB, err := openDBToInspect(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	keyNames, err := store.ListEOTSKeyNames(db, filter)
+	if err != nil {
+		return err
+	}
+	if keyNames == nil {
+		keyNames = []*store.EOTSKeyName{}
+	}
+
+	return printInspected(cmd, output, keyNames, func(w io.Writer) {
+		fmt.Fprintln(w, "KEY NAME\tEOTS PK")
+		for _, k := range keyNames {
+			fmt.Fprintf(w, "%s\t%s\n", k.KeyName, k.EotsPkHex)
+		}
+	})
+}
+
+func newDBInspectSignRecordsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-records",
+		Short: "Print the signing records stored in the eotsd database.",
+		Example: `eotsd db inspect sign-records --rpc-client 127.0.0.1:12582 --chain-id bbn-1 ` +
+			`--eots-pk 3d0bebcbe800236ce8603c5bb1ab6c2af0932e947db4956a338f119797c37f1e --from-height 1000 --to-height 2000`,
+		Args: cobra.NoArgs,
+		RunE: runDBInspectSignRecords,
+	}
+
+	cmd.Flags().String(chainIDFlag, "", "Only print the signing records of the given chain")
+	cmd.Flags().Uint64(fromHeightFlag, 0, "Only print the signing records from the given height")
+	cmd.Flags().Uint64(toHeightFlag, 0, "Only print the signing records up to the given height, 0 for no bound")
+
+	return cmd
+}
+
+func runDBInspectSignRecords(cmd *cobra.Command, _ []string) error {
+	output, filter, err := readInspectFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if filter.ChainID, err = cmd.Flags().GetString(chainIDFlag); err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", chainIDFlag, err)
+	}
+	if filter.FromHeight, err = cmd.Flags().GetUint64(fromHeightFlag); err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fromHeightFlag, err)
+	}
+	if filter.ToHeight, err = cmd.Flags().GetUint64(toHeightFlag); err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", toHeightFlag, err)
+	}
+
+	db, closeDB, err := openDBToInspect(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	records, err := store.ListSignRecords(db, filter)
+	if err != nil {
+		return err
+	}
+	if records == nil {
+		records = []*store.SignRecordInfo{}
+	}
+
+	return printInspected(cmd, output, records, func(w io.Writer) {
+		fmt.Fprintln(w, "CHAIN ID\tEOTS PK\tHEIGHT\tMSG\tTIMESTAMP")
+		for _, r := range records {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+				r.ChainID, r.EotsPkHex, r.Height, r.MsgHex, r.Timestamp.Format(time.RFC3339))
+		}
+	})
+}
+
+// readInspectFlags reads the output format and the filter of the records to
+// inspect
+func readInspectFlags(cmd *cobra.Command) (string, *store.Filter, error) {
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read flag %s: %w", outputFlag, err)
+	}
+	if output != outputTable && output != outputJSON {
+		return "", nil, fmt.Errorf("invalid output format %s, expected %s or %s", output, outputTable, outputJSON)
+	}
+
+	eotsPkHex, err := cmd.Flags().GetString(eotsPkFlag)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read flag %s: %w", eotsPkFlag, err)
+	}
+
+	filter := &store.Filter{}
+	if eotsPkHex != "" {
+		eotsPk, err := bbntypes.NewBIP340PubKeyFromHex(eotsPkHex)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid %s: %w", eotsPkFlag, err)
+		}
+		filter.EotsPkHex = eotsPk.MarshalHex()
+	}
+
+	return output, filter, nil
+}
+
+// openDBToInspect opens the database to inspect, which is a snapshot of the
+// database of the running eotsd if --rpc-client is set, the database file of
+// --db-file if set, or the database of the config otherwise. The returned
+// function closes the database
+func openDBToInspect(cmd *cobra.Command) (kvdb.Backend, func(), error) {
+	rpcAddr, err := cmd.Flags().GetString(rpcClientFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read flag %s: %w", rpcClientFlag, err)
+	}
+
+	dbFile, err := cmd.Flags().GetString(dbFileFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read flag %s: %w", dbFileFlag, err)
+	}
+
+	var (
+		db      kvdb.Backend
+		closeDB func()
+	)
+	switch {
+	case rpcAddr != "" && dbFile != "":
+		return nil, nil, fmt.Errorf("only one of --%s and --%s can be set", rpcClientFlag, dbFileFlag)
+	case rpcAddr != "":
+		eotsClient, err := client.NewEOTSManagerGRpcClient(rpcAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer eotsClient.Close()
+
+		db, closeDB, err = backup.OpenCopy(func(w io.Writer) error {
+			return eotsClient.BackupDB(cmd.Context(), w)
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to take a snapshot of the database: %w", err)
+		}
+	case dbFile != "":
+		db, err = backup.Open(util.CleanAndExpandPath(dbFile))
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		homePath, err := getHomePath(cmd)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cfg, err := config.LoadConfig(homePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load config: %w", err)
+		}
+
+		db, err = backup.Open(filepath.Join(cfg.DatabaseConfig.DBPath, cfg.DatabaseConfig.DBFileName))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, err
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w, the database of a running eotsd is inspected with --%s",
+				err, rpcClientFlag)
+		}
+	}
+	if closeDB == nil {
+		closeDB = func() {
+			_ = db.Close()
+		}
+	}
+
+	if _, err := store.ValidateDB(db); err != nil {
+		closeDB()
+
+		return nil, nil, fmt.Errorf("invalid database: %w", err)
+	}
+
+	return db, closeDB, nil
+}
+
+// printInspected prints the inspected records as JSON, or as the table
+// written by the given function
+func printInspected(cmd *cobra.Command, output string, records any, writeTable func(w io.Writer)) error {
+	if output == outputJSON {
+		jsonBytes, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(jsonBytes))
+
+		return nil
+	}
+
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	writeTable(tw)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	cmd.Print(sb.String())
+
+	return nil
+}
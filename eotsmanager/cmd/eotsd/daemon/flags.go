@@ -4,6 +4,7 @@ const (
 	keyNameFlag           = "key-name"
 	eotsPkFlag            = "eots-pk"
 	passphraseFlag        = "passphrase"
+	passphraseFileFlag    = "passphrase-file"
 	forceFlag             = "force"
 	rpcListenerFlag       = "rpc-listener"
 	rpcClientFlag         = "rpc-client"
@@ -19,4 +20,10 @@ const (
 	flagIndex             = "index"
 	flagRecover           = "recover"
 	flagMnemonicSrc       = "source"
+	dryRunFlag            = "dry-run"
+	dbFileFlag            = "db-file"
+	outputFlag            = "output"
+	chainIDFlag           = "chain-id"
+	fromHeightFlag        = "from-height"
+	toHeightFlag          = "to-height"
 )
@@ -120,7 +120,7 @@ func saveKeyNameMapping(cmd *cobra.Command, keyName string) (*types.BIP340PubKey
 			return nil, fmt.Errorf("failed to get public key for key %s: %w", keyName, err)
 		}
 
-		if err := client.SaveEOTSKeyName(eotsPk.MustToBTCPK(), keyName); err != nil {
+		if err := client.SaveEOTSKeyName(cmd.Context(), eotsPk.MustToBTCPK(), keyName); err != nil {
 			return nil, fmt.Errorf("failed to save key name mapping: %w", err)
 		}
 
@@ -153,7 +153,7 @@ func saveKeyNameMapping(cmd *cobra.Command, keyName string) (*types.BIP340PubKey
 	}
 
 	// Save the public key to key name mapping
-	if err := eotsManager.SaveEOTSKeyName(eotsPk.MustToBTCPK(), keyName); err != nil {
+	if err := eotsManager.SaveEOTSKeyName(cmd.Context(), eotsPk.MustToBTCPK(), keyName); err != nil {
 		return nil, fmt.Errorf("failed to save key name mapping: %w", err)
 	}
 
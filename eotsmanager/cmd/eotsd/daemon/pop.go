@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -66,6 +67,7 @@ func NewExportPopCmd() *cobra.Command {
 	f.String(keyNameFlag, "", "EOTS key name")
 	f.String(eotsPkFlag, "", "EOTS public key of the finality-provider")
 	f.String(passphraseFlag, "", "EOTS passphrase used to decrypt the keyring")
+	f.String(passphraseFileFlag, "", "The file of the EOTS passphrase used to decrypt the keyring, instead of --passphrase")
 	f.String(sdkflags.FlagKeyringBackend, keyring.BackendTest, "EOTS backend of the keyring")
 
 	f.String(flagHomeBaby, "", "BABY home directory")
@@ -88,7 +90,7 @@ func exportPop(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	eotsPassphrase, err := f.GetString(passphraseFlag)
+	eotsPassphrase, err := readPassphrase(f)
 	if err != nil {
 		return err
 	}
@@ -160,7 +162,7 @@ func exportPop(cmd *cobra.Command, _ []string) error {
 	}
 
 	hashOfMsgToSign := tmhash.Sum([]byte(bbnAddr.String()))
-	schnorrSigOverBabyAddr, btcPubKey, err := eotsSignMsg(eotsManager, eotsKeyName, eotsFpPubKeyStr, eotsPassphrase, hashOfMsgToSign)
+	schnorrSigOverBabyAddr, btcPubKey, err := eotsSignMsg(cmd.Context(), eotsManager, eotsKeyName, eotsFpPubKeyStr, eotsPassphrase, hashOfMsgToSign)
 	if err != nil {
 		return fmt.Errorf("failed to sign address %s: %w", bbnAddr.String(), err)
 	}
@@ -283,6 +285,7 @@ func babyPk(babyRecord *keyring.Record) (*secp256k1.PubKey, error) {
 }
 
 func eotsSignMsg(
+	ctx context.Context,
 	eotsManager *eotsmanager.LocalEOTSManager,
 	keyName, fpPkStr, passphrase string,
 	hashOfMsgToSign []byte,
@@ -292,7 +295,7 @@ func eotsSignMsg(
 		if err != nil {
 			return nil, nil, fmt.Errorf("invalid finality-provider public key %s: %w", fpPkStr, err)
 		}
-		signature, err := eotsManager.SignSchnorrSig(*fpPk, hashOfMsgToSign, passphrase)
+		signature, err := eotsManager.SignSchnorrSig(ctx, *fpPk, hashOfMsgToSign, passphrase)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to sign msg with pk %s: %w", fpPkStr, err)
 		}
@@ -27,6 +27,7 @@ func NewRootCmd() *cobra.Command {
 		version.CommandVersion("eotsd"),
 		CommandPrintAllKeys(),
 		NewExportPopCmd(),
+		NewDBCmd(),
 	)
 
 	return rootCmd
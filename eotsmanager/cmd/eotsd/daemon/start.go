@@ -6,11 +6,14 @@ import (
 
 	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	eotsservice "github.com/babylonlabs-io/finality-provider/eotsmanager/service"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 )
 
 func NewStartCmd() *cobra.Command {
@@ -50,16 +53,40 @@ func startFn(cmd *cobra.Command, _ []string) error {
 		cfg.RPCListener = rpcListener
 	}
 
-	logger, err := log.NewRootLoggerWithFile(config.LogFile(homePath), cfg.LogLevel)
+	logLevel, err := log.ParseLevel(cfg.LogLevel)
 	if err != nil {
 		return fmt.Errorf("failed to load the logger: %w", err)
 	}
+	logger, logFile, err := log.NewRootLoggerWithRotation(config.LogFile(homePath), cfg.Log, log.NewLevels(logLevel, nil))
+	if err != nil {
+		return fmt.Errorf("failed to load the logger: %w", err)
+	}
+	defer logFile.Close()
+
+	shutdownTracing, err := tracing.Setup(cmd.Context(), cfg.Tracing, "eotsd")
+	if err != nil {
+		return fmt.Errorf("failed to set up the tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(); err != nil {
+			logger.Error("failed to export the pending spans", zap.Error(err))
+		}
+	}()
 
 	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
 	if err != nil {
 		return fmt.Errorf("failed to create db backend: %w", err)
 	}
 
+	plan, err := store.MigrateDB(dbBackend)
+	if err != nil {
+		return fmt.Errorf("failed to migrate the database: %w", err)
+	}
+	if len(plan.Pending) > 0 {
+		logger.Named(log.SubsystemStore).Info("migrated the database",
+			zap.Uint32("from_version", plan.Current), zap.Uint32("to_version", plan.Target))
+	}
+
 	eotsManager, err := eotsmanager.NewLocalEOTSManager(homePath, cfg.KeyringBackend, dbBackend, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create EOTS manager: %w", err)
@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -9,10 +10,35 @@ import (
 	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/std"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	"github.com/babylonlabs-io/finality-provider/envconfig"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
+// readPassphrase reads the passphrase of --passphrase, of the file of
+// --passphrase-file, or of the EOTSD_PASSPHRASE environment variable, in order
+// of precedence
+func readPassphrase(flags *pflag.FlagSet) (string, error) {
+	passphrase, err := flags.GetString(passphraseFlag)
+	if err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+	}
+
+	passphraseFile, err := flags.GetString(passphraseFileFlag)
+	if err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphraseFileFlag, err)
+	}
+
+	passphrase, err = envconfig.ResolveSecret(passphrase, passphraseFile, envconfig.EnvName(config.EnvPrefix, "", passphraseFlag))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the passphrase: %w", err)
+	}
+
+	return passphrase, nil
+}
+
 func getHomePath(cmd *cobra.Command) (string, error) {
 	return getCleanPath(cmd, sdkflags.FlagHome)
 }
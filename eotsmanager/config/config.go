@@ -3,14 +3,18 @@ package config
 import (
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
 	"strconv"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
-	"github.com/jessevdk/go-flags"
 
+	"github.com/babylonlabs-io/finality-provider/backup"
+	"github.com/babylonlabs-io/finality-provider/envconfig"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -18,12 +22,16 @@ const (
 	defaultLogLevel       = "debug"
 	defaultDataDirname    = "data"
 	defaultLogDirname     = "logs"
+	defaultBackupDirname  = "backups"
 	defaultLogFilename    = "eotsd.log"
 	defaultConfigFileName = "eotsd.conf"
 	DefaultRPCPort        = 12582
 	defaultKeyringBackend = keyring.BackendTest
 )
 
+// EnvPrefix is the prefix of the environment variables overriding the config
+const EnvPrefix = "EOTSD"
+
 var (
 	// DefaultEOTSDir the default EOTS home directory:
 	//   C:\Users\<username>\AppData\Local\ on Windows
@@ -38,9 +46,15 @@ type Config struct {
 	LogLevel       string          `long:"loglevel" description:"Logging level for all subsystems" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error" choice:"fatal"`
 	KeyringBackend string          `long:"keyring-type" description:"Type of keyring to use"`
 	RPCListener    string          `long:"rpclistener" description:"the listener for RPC connections, e.g., 127.0.0.1:1234"`
+	HTTPListener   string          `long:"httplistener" description:"the loopback listener for the REST gateway of the RPC server, e.g., 127.0.0.1:1235; the gateway is disabled if empty"`
 	Metrics        *metrics.Config `group:"metrics" namespace:"metrics"`
+	Tracing        *tracing.Config `group:"tracing" namespace:"tracing"`
 
 	DatabaseConfig *DBConfig `group:"dbconfig" namespace:"dbconfig"`
+
+	Log *log.Config `group:"log" namespace:"log"`
+
+	Backup *backup.Config `group:"backup" namespace:"backup"`
 }
 
 // LoadConfig initializes and parses the config using a config file and command
@@ -50,7 +64,8 @@ type Config struct {
 //  1. Start with a default config with sane settings
 //  2. Pre-parse the command line to check for an alternative config file
 //  3. Load configuration file overwriting defaults with any specified options
-//  4. Parse CLI options and overwrite/add any specified options
+//  4. Apply the overrides of the EOTSD_* environment variables
+//  5. Parse CLI options and overwrite/add any specified options
 func LoadConfig(homePath string) (*Config, error) {
 	// The home directory is required to have a configuration file with a specific name
 	// under it.
@@ -60,11 +75,10 @@ func LoadConfig(homePath string) (*Config, error) {
 			"not exist in %s", cfgFile)
 	}
 
-	// Next, load any additional configuration options from the file.
+	// Next, load any additional configuration options from the file and the
+	// environment.
 	var cfg Config
-	fileParser := flags.NewParser(&cfg, flags.Default)
-	err := flags.NewIniParser(fileParser).ParseFile(cfgFile)
-	if err != nil {
+	if _, err := envconfig.Load(&cfg, cfgFile, EnvPrefix, os.LookupEnv); err != nil {
 		return nil, err
 	}
 
@@ -85,6 +99,12 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid RPC listener address %s, %w", cfg.RPCListener, err)
 	}
 
+	if cfg.HTTPListener != "" {
+		if _, err := net.ResolveTCPAddr("tcp", cfg.HTTPListener); err != nil {
+			return fmt.Errorf("invalid HTTP listener address %s, %w", cfg.HTTPListener, err)
+		}
+	}
+
 	if cfg.KeyringBackend == "" {
 		return fmt.Errorf("the keyring backend should not be empty")
 	}
@@ -97,6 +117,30 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid metrics config")
 	}
 
+	if cfg.Tracing == nil {
+		return fmt.Errorf("empty tracing config")
+	}
+
+	if err := cfg.Tracing.Validate(); err != nil {
+		return fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	if cfg.Log == nil {
+		return fmt.Errorf("empty log config")
+	}
+
+	if err := cfg.Log.Validate(); err != nil {
+		return fmt.Errorf("invalid log config: %w", err)
+	}
+
+	if cfg.Backup == nil {
+		return fmt.Errorf("empty backup config")
+	}
+
+	if err := cfg.Backup.Validate(); err != nil {
+		return fmt.Errorf("invalid backup config: %w", err)
+	}
+
 	return nil
 }
 
@@ -116,6 +160,10 @@ func DataDir(homePath string) string {
 	return filepath.Join(homePath, defaultDataDirname)
 }
 
+func BackupDir(homePath string) string {
+	return filepath.Join(homePath, defaultBackupDirname)
+}
+
 func DefaultConfig() *Config {
 	return DefaultConfigWithHomePath(DefaultEOTSDir)
 }
@@ -125,8 +173,11 @@ func DefaultConfigWithHomePath(homePath string) *Config {
 		LogLevel:       defaultLogLevel,
 		KeyringBackend: defaultKeyringBackend,
 		DatabaseConfig: DefaultDBConfigWithHomePath(homePath),
+		Log:            log.DefaultConfig(),
 		RPCListener:    defaultRPCListener,
 		Metrics:        metrics.DefaultEotsConfig(),
+		Tracing:        tracing.DefaultConfig(),
+		Backup:         backup.DefaultConfig(BackupDir(homePath)),
 	}
 	if err := cfg.Validate(); err != nil {
 		panic(err)
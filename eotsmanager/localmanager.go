@@ -2,6 +2,7 @@ package eotsmanager
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -76,7 +77,7 @@ func InitKeyring(homeDir, keyringBackend string, inputReader *strings.Reader) (k
 	)
 }
 
-func (lm *LocalEOTSManager) CreateKey(name, passphrase, hdPath string) ([]byte, error) {
+func (lm *LocalEOTSManager) CreateKey(_ context.Context, name, passphrase, hdPath string) ([]byte, error) {
 	mnemonic, err := NewMnemonic()
 	if err != nil {
 		return nil, err
@@ -130,7 +131,7 @@ func (lm *LocalEOTSManager) CreateKeyWithMnemonic(name, passphrase, hdPath, mnem
 		return nil, err
 	}
 
-	if err := lm.SaveEOTSKeyName(eotsPk.MustToBTCPK(), name); err != nil {
+	if err := lm.es.AddEOTSKeyName(eotsPk.MustToBTCPK(), name); err != nil {
 		return nil, err
 	}
 
@@ -144,7 +145,7 @@ func (lm *LocalEOTSManager) CreateKeyWithMnemonic(name, passphrase, hdPath, mnem
 	return eotsPk, nil
 }
 
-func (lm *LocalEOTSManager) SaveEOTSKeyName(pk *btcec.PublicKey, keyName string) error {
+func (lm *LocalEOTSManager) SaveEOTSKeyName(_ context.Context, pk *btcec.PublicKey, keyName string) error {
 	return lm.es.AddEOTSKeyName(pk, keyName)
 }
 
@@ -177,10 +178,14 @@ func LoadBIP340PubKeyFromKeyName(kr keyring.Keyring, keyName string) (*bbntypes.
 	}
 }
 
-func (lm *LocalEOTSManager) CreateRandomnessPairList(fpPk []byte, chainID []byte, startHeight uint64, num uint32, passphrase string) ([]*btcec.FieldVal, error) {
+func (lm *LocalEOTSManager) CreateRandomnessPairList(ctx context.Context, fpPk []byte, chainID []byte, startHeight uint64, num uint32, passphrase string) ([]*btcec.FieldVal, error) {
 	prList := make([]*btcec.FieldVal, 0, num)
 
 	for i := uint32(0); i < num; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		height := startHeight + uint64(i)
 		_, pubRand, err := lm.getRandomnessPair(fpPk, chainID, height, passphrase)
 		if err != nil {
@@ -195,7 +200,7 @@ func (lm *LocalEOTSManager) CreateRandomnessPairList(fpPk []byte, chainID []byte
 	return prList, nil
 }
 
-func (lm *LocalEOTSManager) SignEOTS(eotsPk []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+func (lm *LocalEOTSManager) SignEOTS(_ context.Context, eotsPk []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
 	record, found, err := lm.es.GetSignRecord(eotsPk, chainID, height)
 	if err != nil {
 		return nil, fmt.Errorf("error getting sign record: %w", err)
@@ -256,7 +261,7 @@ func (lm *LocalEOTSManager) SignEOTS(eotsPk []byte, chainID []byte, msg []byte,
 }
 
 // UnsafeSignEOTS should only be used in e2e test to demonstrate double sign
-func (lm *LocalEOTSManager) UnsafeSignEOTS(fpPk []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+func (lm *LocalEOTSManager) UnsafeSignEOTS(_ context.Context, fpPk []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
 	privRand, _, err := lm.getRandomnessPair(fpPk, chainID, height, passphrase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get private randomness: %w", err)
@@ -274,7 +279,7 @@ func (lm *LocalEOTSManager) UnsafeSignEOTS(fpPk []byte, chainID []byte, msg []by
 	return eots.Sign(privKey, privRand, msg)
 }
 
-func (lm *LocalEOTSManager) SignSchnorrSig(fpPk []byte, msg []byte, passphrase string) (*schnorr.Signature, error) {
+func (lm *LocalEOTSManager) SignSchnorrSig(_ context.Context, fpPk []byte, msg []byte, passphrase string) (*schnorr.Signature, error) {
 	privKey, err := lm.getEOTSPrivKey(fpPk, passphrase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get EOTS private key: %w", err)
@@ -318,7 +323,7 @@ func (lm *LocalEOTSManager) Close() error {
 
 // getRandomnessPair returns a randomness pair generated based on the given finality provider key, chainID and height
 func (lm *LocalEOTSManager) getRandomnessPair(fpPk []byte, chainID []byte, height uint64, passphrase string) (*eots.PrivateRand, *eots.PublicRand, error) {
-	record, err := lm.KeyRecord(fpPk, passphrase)
+	record, err := lm.keyRecord(fpPk, passphrase)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -327,7 +332,11 @@ func (lm *LocalEOTSManager) getRandomnessPair(fpPk []byte, chainID []byte, heigh
 	return privRand, pubRand, nil
 }
 
-func (lm *LocalEOTSManager) KeyRecord(fpPk []byte, passphrase string) (*eotstypes.KeyRecord, error) {
+func (lm *LocalEOTSManager) KeyRecord(_ context.Context, fpPk []byte, passphrase string) (*eotstypes.KeyRecord, error) {
+	return lm.keyRecord(fpPk, passphrase)
+}
+
+func (lm *LocalEOTSManager) keyRecord(fpPk []byte, passphrase string) (*eotstypes.KeyRecord, error) {
 	name, err := lm.es.GetEOTSKeyName(fpPk)
 	if err != nil {
 		return nil, err
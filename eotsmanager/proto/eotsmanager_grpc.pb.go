@@ -27,6 +27,7 @@ const (
 	EOTSManager_UnsafeSignEOTS_FullMethodName           = "/proto.EOTSManager/UnsafeSignEOTS"
 	EOTSManager_SignSchnorrSig_FullMethodName           = "/proto.EOTSManager/SignSchnorrSig"
 	EOTSManager_SaveEOTSKeyName_FullMethodName          = "/proto.EOTSManager/SaveEOTSKeyName"
+	EOTSManager_BackupDB_FullMethodName                 = "/proto.EOTSManager/BackupDB"
 )
 
 // EOTSManagerClient is the client API for EOTSManager service.
@@ -48,6 +49,8 @@ type EOTSManagerClient interface {
 	SignSchnorrSig(ctx context.Context, in *SignSchnorrSigRequest, opts ...grpc.CallOption) (*SignSchnorrSigResponse, error)
 	// SaveEOTSKeyName saves a new key name mapping for the EOTS public key
 	SaveEOTSKeyName(ctx context.Context, in *SaveEOTSKeyNameRequest, opts ...grpc.CallOption) (*SaveEOTSKeyNameResponse, error)
+	// BackupDB streams a consistent snapshot of the database of the daemon
+	BackupDB(ctx context.Context, in *BackupEOTSDBRequest, opts ...grpc.CallOption) (EOTSManager_BackupDBClient, error)
 }
 
 type eOTSManagerClient struct {
@@ -130,6 +133,38 @@ func (c *eOTSManagerClient) SaveEOTSKeyName(ctx context.Context, in *SaveEOTSKey
 	return out, nil
 }
 
+func (c *eOTSManagerClient) BackupDB(ctx context.Context, in *BackupEOTSDBRequest, opts ...grpc.CallOption) (EOTSManager_BackupDBClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EOTSManager_ServiceDesc.Streams[0], EOTSManager_BackupDB_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eOTSManagerBackupDBClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EOTSManager_BackupDBClient interface {
+	Recv() (*BackupEOTSDBResponse, error)
+	grpc.ClientStream
+}
+
+type eOTSManagerBackupDBClient struct {
+	grpc.ClientStream
+}
+
+func (x *eOTSManagerBackupDBClient) Recv() (*BackupEOTSDBResponse, error) {
+	m := new(BackupEOTSDBResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EOTSManagerServer is the server API for EOTSManager service.
 // All implementations must embed UnimplementedEOTSManagerServer
 // for forward compatibility
@@ -149,6 +184,8 @@ type EOTSManagerServer interface {
 	SignSchnorrSig(context.Context, *SignSchnorrSigRequest) (*SignSchnorrSigResponse, error)
 	// SaveEOTSKeyName saves a new key name mapping for the EOTS public key
 	SaveEOTSKeyName(context.Context, *SaveEOTSKeyNameRequest) (*SaveEOTSKeyNameResponse, error)
+	// BackupDB streams a consistent snapshot of the database of the daemon
+	BackupDB(*BackupEOTSDBRequest, EOTSManager_BackupDBServer) error
 	mustEmbedUnimplementedEOTSManagerServer()
 }
 
@@ -180,6 +217,9 @@ func (UnimplementedEOTSManagerServer) SignSchnorrSig(context.Context, *SignSchno
 func (UnimplementedEOTSManagerServer) SaveEOTSKeyName(context.Context, *SaveEOTSKeyNameRequest) (*SaveEOTSKeyNameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveEOTSKeyName not implemented")
 }
+func (UnimplementedEOTSManagerServer) BackupDB(*BackupEOTSDBRequest, EOTSManager_BackupDBServer) error {
+	return status.Errorf(codes.Unimplemented, "method BackupDB not implemented")
+}
 func (UnimplementedEOTSManagerServer) mustEmbedUnimplementedEOTSManagerServer() {}
 
 // UnsafeEOTSManagerServer may be embedded to opt out of forward compatibility for this service.
@@ -337,6 +377,27 @@ func _EOTSManager_SaveEOTSKeyName_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EOTSManager_BackupDB_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupEOTSDBRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EOTSManagerServer).BackupDB(m, &eOTSManagerBackupDBServer{stream})
+}
+
+type EOTSManager_BackupDBServer interface {
+	Send(*BackupEOTSDBResponse) error
+	grpc.ServerStream
+}
+
+type eOTSManagerBackupDBServer struct {
+	grpc.ServerStream
+}
+
+func (x *eOTSManagerBackupDBServer) Send(m *BackupEOTSDBResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // EOTSManager_ServiceDesc is the grpc.ServiceDesc for EOTSManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -377,6 +438,12 @@ var EOTSManager_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _EOTSManager_SaveEOTSKeyName_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BackupDB",
+			Handler:       _EOTSManager_BackupDB_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "eotsmanager.proto",
 }
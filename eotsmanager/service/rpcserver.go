@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/lightningnetwork/lnd/kvdb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/babylonlabs-io/finality-provider/backup"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
 )
 
 // rpcServer is the main RPC server for the EOTS daemon that handles
@@ -16,14 +22,17 @@ type rpcServer struct {
 	proto.UnimplementedEOTSManagerServer
 
 	em eotsmanager.EOTSManager
+	db kvdb.Backend
 }
 
 // newRPCServer creates a new RPC sever from the set of input dependencies.
 func newRPCServer(
 	em eotsmanager.EOTSManager,
+	db kvdb.Backend,
 ) *rpcServer {
 	return &rpcServer{
 		em: em,
+		db: db,
 	}
 }
 
@@ -41,9 +50,9 @@ func (r *rpcServer) Ping(_ context.Context, _ *proto.PingRequest) (*proto.PingRe
 }
 
 // CreateKey generates and saves an EOTS key
-func (r *rpcServer) CreateKey(_ context.Context, req *proto.CreateKeyRequest) (
+func (r *rpcServer) CreateKey(ctx context.Context, req *proto.CreateKeyRequest) (
 	*proto.CreateKeyResponse, error) {
-	pk, err := r.em.CreateKey(req.Name, req.Passphrase, req.HdPath)
+	pk, err := r.em.CreateKey(ctx, req.Name, req.Passphrase, req.HdPath)
 
 	if err != nil {
 		return nil, err
@@ -53,9 +62,9 @@ func (r *rpcServer) CreateKey(_ context.Context, req *proto.CreateKeyRequest) (
 }
 
 // CreateRandomnessPairList returns a list of Schnorr randomness pairs
-func (r *rpcServer) CreateRandomnessPairList(_ context.Context, req *proto.CreateRandomnessPairListRequest) (
+func (r *rpcServer) CreateRandomnessPairList(ctx context.Context, req *proto.CreateRandomnessPairListRequest) (
 	*proto.CreateRandomnessPairListResponse, error) {
-	pubRandList, err := r.em.CreateRandomnessPairList(req.Uid, req.ChainId, req.StartHeight, req.Num, req.Passphrase)
+	pubRandList, err := r.em.CreateRandomnessPairList(ctx, req.Uid, req.ChainId, req.StartHeight, req.Num, req.Passphrase)
 
 	if err != nil {
 		return nil, err
@@ -72,9 +81,9 @@ func (r *rpcServer) CreateRandomnessPairList(_ context.Context, req *proto.Creat
 }
 
 // KeyRecord returns the key record
-func (r *rpcServer) KeyRecord(_ context.Context, req *proto.KeyRecordRequest) (
+func (r *rpcServer) KeyRecord(ctx context.Context, req *proto.KeyRecordRequest) (
 	*proto.KeyRecordResponse, error) {
-	record, err := r.em.KeyRecord(req.Uid, req.Passphrase)
+	record, err := r.em.KeyRecord(ctx, req.Uid, req.Passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -88,9 +97,13 @@ func (r *rpcServer) KeyRecord(_ context.Context, req *proto.KeyRecordRequest) (
 }
 
 // SignEOTS signs an EOTS with the EOTS private key and the relevant randomness
-func (r *rpcServer) SignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
+func (r *rpcServer) SignEOTS(ctx context.Context, req *proto.SignEOTSRequest) (
 	*proto.SignEOTSResponse, error) {
-	sig, err := r.em.SignEOTS(req.Uid, req.ChainId, req.Msg, req.Height, req.Passphrase)
+	sig, err := r.em.SignEOTS(ctx, req.Uid, req.ChainId, req.Msg, req.Height, req.Passphrase)
+	if errors.Is(err, types.ErrDoubleSign) {
+		// the client maps the code back to the typed error
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -101,9 +114,9 @@ func (r *rpcServer) SignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
 }
 
 // UnsafeSignEOTS only used for testing purposes. Doesn't offer slashing protection!
-func (r *rpcServer) UnsafeSignEOTS(_ context.Context, req *proto.SignEOTSRequest) (
+func (r *rpcServer) UnsafeSignEOTS(ctx context.Context, req *proto.SignEOTSRequest) (
 	*proto.SignEOTSResponse, error) {
-	sig, err := r.em.UnsafeSignEOTS(req.Uid, req.ChainId, req.Msg, req.Height, req.Passphrase)
+	sig, err := r.em.UnsafeSignEOTS(ctx, req.Uid, req.ChainId, req.Msg, req.Height, req.Passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -114,9 +127,9 @@ func (r *rpcServer) UnsafeSignEOTS(_ context.Context, req *proto.SignEOTSRequest
 }
 
 // SignSchnorrSig signs a Schnorr sig with the EOTS private key
-func (r *rpcServer) SignSchnorrSig(_ context.Context, req *proto.SignSchnorrSigRequest) (
+func (r *rpcServer) SignSchnorrSig(ctx context.Context, req *proto.SignSchnorrSigRequest) (
 	*proto.SignSchnorrSigResponse, error) {
-	sig, err := r.em.SignSchnorrSig(req.Uid, req.Msg, req.Passphrase)
+	sig, err := r.em.SignSchnorrSig(ctx, req.Uid, req.Msg, req.Passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +139,7 @@ func (r *rpcServer) SignSchnorrSig(_ context.Context, req *proto.SignSchnorrSigR
 
 // SaveEOTSKeyName signs a Schnorr sig with the EOTS private key
 func (r *rpcServer) SaveEOTSKeyName(
-	_ context.Context,
+	ctx context.Context,
 	req *proto.SaveEOTSKeyNameRequest,
 ) (*proto.SaveEOTSKeyNameResponse, error) {
 	eotsPk, err := btcec.ParsePubKey(req.EotsPk)
@@ -134,5 +147,13 @@ func (r *rpcServer) SaveEOTSKeyName(
 		return nil, err
 	}
 
-	return &proto.SaveEOTSKeyNameResponse{}, r.em.SaveEOTSKeyName(eotsPk, req.KeyName)
+	return &proto.SaveEOTSKeyNameResponse{}, r.em.SaveEOTSKeyName(ctx, eotsPk, req.KeyName)
+}
+
+// BackupDB streams a consistent snapshot of the database, which is taken
+// within a read transaction while the daemon keeps running
+func (r *rpcServer) BackupDB(_ *proto.BackupEOTSDBRequest, stream proto.EOTSManager_BackupDBServer) error {
+	return backup.Stream(r.db, func(chunk []byte) error {
+		return stream.Send(&proto.BackupEOTSDBResponse{Chunk: chunk})
+	})
 }
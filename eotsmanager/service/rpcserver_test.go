@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+// TestSignEOTSDoubleSign tests that the double sign error of the EOTS manager
+// keeps its type when it is returned to the remote client
+func TestSignEOTSDoubleSign(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	homeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(homeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(homeDir, eotsCfg.KeyringBackend, dbBackend, zap.NewNop())
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	require.NoError(t, newRPCServer(em, dbBackend).RegisterWithGrpcServer(grpcServer))
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	eotsCli, err := client.NewEOTSManagerGRpcClient(lis.Addr().String())
+	require.NoError(t, err)
+	defer eotsCli.Close()
+
+	ctx := context.Background()
+	fpPk, err := eotsCli.CreateKey(ctx, datagen.GenRandomHexStr(r, 4), "", "")
+	require.NoError(t, err)
+
+	chainID := datagen.GenRandomByteArray(r, 10)
+	height := datagen.RandomInt(r, 100) + 1
+	_, err = eotsCli.SignEOTS(ctx, fpPk, chainID, datagen.GenRandomByteArray(r, 32), height, "")
+	require.NoError(t, err)
+
+	_, err = eotsCli.SignEOTS(ctx, fpPk, chainID, datagen.GenRandomByteArray(r, 32), height, "")
+	require.ErrorIs(t, err, types.ErrDoubleSign)
+}
@@ -6,7 +6,9 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/babylonlabs-io/finality-provider/backup"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 
 	"github.com/lightningnetwork/lnd/kvdb"
@@ -15,8 +17,16 @@ import (
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/gateway"
+	"github.com/babylonlabs-io/finality-provider/health"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 )
 
+// gatewayShutdownTimeout is the time given to the in-flight REST requests
+// to complete upon shutdown
+const gatewayShutdownTimeout = 5 * time.Second
+
 // Server is the main daemon construct for the EOTS manager server. It handles
 // spinning up the RPC sever, the database, and any other components that the
 // EOTS manager server needs to function.
@@ -37,7 +47,7 @@ func NewEOTSManagerServer(cfg *config.Config, l *zap.Logger, em eotsmanager.EOTS
 	return &Server{
 		cfg:       cfg,
 		logger:    l,
-		rpcServer: newRPCServer(em),
+		rpcServer: newRPCServer(em, db),
 		db:        db,
 		quit:      make(chan struct{}, 1),
 	}
@@ -50,12 +60,15 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		return nil
 	}
 
-	// Start the metrics server.
+	checker := health.NewChecker(health.DefaultCheckTimeout)
+	checker.AddLivenessCheck("db", health.DBCheck(s.db))
+
+	// Start the metrics server, which also serves the health endpoints.
 	promAddr, err := s.cfg.Metrics.Address()
 	if err != nil {
 		return fmt.Errorf("failed to get prometheus address: %w", err)
 	}
-	metricsServer := metrics.Start(promAddr, s.logger)
+	metricsServer := metrics.Start(promAddr, s.logger, checker)
 
 	defer func() {
 		s.logger.Info("Shutdown complete")
@@ -72,6 +85,11 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		s.logger.Info("Metrics server stopped")
 	}()
 
+	// The scheduled snapshots are stopped before the database is closed.
+	backupScheduler := backup.NewScheduler(s.cfg.Backup, s.db, s.cfg.DatabaseConfig.DBFileName, s.logger)
+	backupScheduler.Start()
+	defer backupScheduler.Stop()
+
 	listenAddr := s.cfg.RPCListener
 	// we create listeners from the RPCListeners defined
 	// in the config.
@@ -83,17 +101,32 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		_ = lis.Close()
 	}()
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(metrics.NewEotsMetrics().RPCServerInterceptor()))
 	defer grpcServer.Stop()
 
 	if err := s.rpcServer.RegisterWithGrpcServer(grpcServer); err != nil {
 		return fmt.Errorf("failed to register gRPC server: %w", err)
 	}
+	checker.RegisterGrpcHealthServer(grpcServer, proto.EOTSManager_ServiceDesc.ServiceName)
 
 	// All the necessary components have been registered, so we can
 	// actually start listening for requests.
 	s.startGrpcListen(grpcServer, []net.Listener{lis})
 
+	if s.cfg.HTTPListener != "" {
+		gw, err := gateway.Start(ctx, s.cfg.HTTPListener, lis.Addr().String(),
+			proto.OpenAPIDocument, proto.RegisterEOTSManagerHandler, s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to start the REST gateway: %w", err)
+		}
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), gatewayShutdownTimeout)
+			defer cancel()
+			gw.Stop(stopCtx)
+		}()
+	}
+
 	s.logger.Info("EOTS Manager Daemon is fully active!")
 
 	// Wait for shutdown signal from either a graceful server stop or from
@@ -1,6 +1,8 @@
 package store
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcwallet/walletdb"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/lightningnetwork/lnd/kvdb"
 )
 
@@ -26,29 +29,13 @@ type EOTSStore struct {
 
 func NewEOTSStore(db kvdb.Backend) (*EOTSStore, error) {
 	s := &EOTSStore{db}
-	if err := s.initBuckets(); err != nil {
+	if _, err := MigrateDB(db); err != nil {
 		return nil, err
 	}
 
 	return s, nil
 }
 
-func (s *EOTSStore) initBuckets() error {
-	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
-		_, err := tx.CreateTopLevelBucket(eotsBucketName)
-		if err != nil {
-			return err
-		}
-
-		_, err = tx.CreateTopLevelBucket(signRecordBucketName)
-		if err != nil {
-			return err
-		}
-
-		return nil
-	})
-}
-
 func (s *EOTSStore) AddEOTSKeyName(
 	btcPk *btcec.PublicKey,
 	keyName string,
@@ -110,9 +97,13 @@ func (s *EOTSStore) GetEOTSKeyName(pk []byte) (string, error) {
 // GetAllEOTSKeyNames retrieves all keys and values.
 // Returns keyName -> btcPK
 func (s *EOTSStore) GetAllEOTSKeyNames() (map[string][]byte, error) {
+	return getAllEOTSKeyNames(s.db)
+}
+
+func getAllEOTSKeyNames(db kvdb.Backend) (map[string][]byte, error) {
 	result := make(map[string][]byte)
 
-	err := s.db.View(func(tx kvdb.RTx) error {
+	err := db.View(func(tx kvdb.RTx) error {
 		eotsBucket := tx.ReadBucket(eotsBucketName)
 		if eotsBucket == nil {
 			return ErrCorruptedEOTSDb
@@ -200,3 +191,39 @@ func (s *EOTSStore) GetSignRecord(eotsPk, chainID []byte, height uint64) (*Signi
 
 	return res, true, nil
 }
+
+// GetLatestSignRecordHeights returns the highest height signed by each EOTS key
+// on the given chain, keyed by the hex of the public key
+func (s *EOTSStore) GetLatestSignRecordHeights(chainID []byte) (map[string]uint64, error) {
+	heights := make(map[string]uint64)
+	keyLen := len(chainID) + schnorr.PubKeyBytesLen + 8
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(signRecordBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		// the records are sorted by chain ID, public key, and height, while
+		// the keys of the chain IDs having the given one as a prefix are
+		// told apart by their length
+		c := bucket.ReadCursor()
+		for k, _ := c.Seek(chainID); k != nil && bytes.HasPrefix(k, chainID); k, _ = c.Next() {
+			if len(k) != keyLen {
+				continue
+			}
+			pk := hex.EncodeToString(k[len(chainID) : len(chainID)+schnorr.PubKeyBytesLen])
+			heights[pk] = sdk.BigEndianToUint64(k[len(chainID)+schnorr.PubKeyBytesLen:])
+		}
+
+		return nil
+	}, func() {
+		heights = make(map[string]uint64)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return heights, nil
+}
@@ -1,6 +1,9 @@
 package store_test
 
 import (
+	"encoding/hex"
+	"errors"
+	"math"
 	"math/rand"
 	"os"
 	"testing"
@@ -176,3 +179,94 @@ func FuzzListKeysEOTSStore(f *testing.F) {
 		}
 	})
 }
+
+// FuzzLatestSignRecordHeights tests getting the highest signed height of each
+// key on a chain
+func FuzzLatestSignRecordHeights(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		dbBackend, err := cfg.GetDBBackend()
+		require.NoError(t, err)
+		defer dbBackend.Close()
+
+		vs, err := store.NewEOTSStore(dbBackend)
+		require.NoError(t, err)
+
+		chainID := []byte("test-chain")
+		// the chain ID of the other chain has the chain ID as a prefix
+		otherChainID := []byte("test-chain-2")
+		expected := make(map[string]uint64)
+		for i := 0; i < 1+r.Intn(5); i++ {
+			pk := testutil.GenRandomByteArray(r, 32)
+			for j := 0; j < 1+r.Intn(5); j++ {
+				height := uint64(r.Int63n(1000000))
+				err := vs.SaveSignRecord(height, chainID, []byte("msg"), pk, []byte("sig"))
+				if errors.Is(err, store.ErrDuplicateSignRecord) {
+					continue
+				}
+				require.NoError(t, err)
+				expected[hex.EncodeToString(pk)] = max(expected[hex.EncodeToString(pk)], height)
+			}
+			err := vs.SaveSignRecord(math.MaxUint64, otherChainID, []byte("msg"), pk, []byte("sig"))
+			require.NoError(t, err)
+		}
+
+		heights, err := vs.GetLatestSignRecordHeights(chainID)
+		require.NoError(t, err)
+		require.Equal(t, expected, heights)
+	})
+}
+
+// FuzzListSignRecords tests listing the signing records selected by a filter
+func FuzzListSignRecords(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		dbBackend, err := cfg.GetDBBackend()
+		require.NoError(t, err)
+		defer dbBackend.Close()
+
+		vs, err := store.NewEOTSStore(dbBackend)
+		require.NoError(t, err)
+
+		chainID := []byte("test-chain")
+		pk := testutil.GenRandomByteArray(r, 32)
+		otherPk := testutil.GenRandomByteArray(r, 32)
+		startHeight := uint64(1 + r.Intn(1000))
+		num := uint64(1 + r.Intn(20))
+		for i := uint64(0); i < num; i++ {
+			msg := testutil.GenRandomByteArray(r, 32)
+			require.NoError(t, vs.SaveSignRecord(startHeight+i, chainID, msg, pk, []byte("sig")))
+			require.NoError(t, vs.SaveSignRecord(startHeight+i, chainID, msg, otherPk, []byte("sig")))
+		}
+
+		records, err := store.ListSignRecords(dbBackend, &store.Filter{
+			EotsPkHex:  hex.EncodeToString(pk),
+			ChainID:    string(chainID),
+			FromHeight: startHeight + 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, records, int(num-1))
+		for i, record := range records {
+			require.Equal(t, startHeight+1+uint64(i), record.Height)
+			require.Equal(t, hex.EncodeToString(pk), record.EotsPkHex)
+			require.Equal(t, string(chainID), record.ChainID)
+			require.Equal(t, hex.EncodeToString([]byte("sig")), record.SigHex)
+		}
+
+		records, err = store.ListSignRecords(dbBackend, &store.Filter{ToHeight: startHeight})
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		records, err = store.ListSignRecords(dbBackend, &store.Filter{ChainID: "other-chain"})
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+}
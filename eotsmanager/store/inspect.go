@@ -0,0 +1,136 @@
+package store
+
+import (
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+	pm "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+)
+
+// Filter selects the records of the database to inspect, the zero value
+// selecting all of them
+type Filter struct {
+	// EotsPkHex is the hex of the EOTS public key, or empty for all of them
+	EotsPkHex string
+	// ChainID is the chain of the signing records, or empty for all of them
+	ChainID string
+	// FromHeight is the lowest height of the signing records
+	FromHeight uint64
+	// ToHeight is the highest height of the signing records, or 0 for no bound
+	ToHeight uint64
+}
+
+func (f *Filter) matches(chainID, eotsPkHex string, height uint64) bool {
+	return (f.ChainID == "" || f.ChainID == chainID) &&
+		(f.EotsPkHex == "" || f.EotsPkHex == eotsPkHex) &&
+		height >= f.FromHeight && (f.ToHeight == 0 || height <= f.ToHeight)
+}
+
+// EOTSKeyName is the name of an EOTS key in the keyring
+type EOTSKeyName struct {
+	KeyName   string `json:"key_name"`
+	EotsPkHex string `json:"eots_pk_hex"`
+}
+
+// SignRecordInfo is a decoded signing record
+type SignRecordInfo struct {
+	ChainID   string    `json:"chain_id"`
+	EotsPkHex string    `json:"eots_pk_hex"`
+	Height    uint64    `json:"height"`
+	MsgHex    string    `json:"msg_hex"`
+	SigHex    string    `json:"sig_hex"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ListEOTSKeyNames returns the names of the EOTS keys of the database selected
+// by the public key of the filter, without changing the database
+func ListEOTSKeyNames(db kvdb.Backend, f *Filter) ([]*EOTSKeyName, error) {
+	keyNames, err := getAllEOTSKeyNames(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*EOTSKeyName
+	for keyName, pk := range keyNames {
+		pkHex := hex.EncodeToString(pk)
+		if f.EotsPkHex != "" && f.EotsPkHex != pkHex {
+			continue
+		}
+		res = append(res, &EOTSKeyName{KeyName: keyName, EotsPkHex: pkHex})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].KeyName < res[j].KeyName
+	})
+
+	return res, nil
+}
+
+// ListSignRecords returns the signing records of the database selected by the
+// filter, in order of chain, public key, and height, without changing the
+// database
+func ListSignRecords(db kvdb.Backend, f *Filter) ([]*SignRecordInfo, error) {
+	var res []*SignRecordInfo
+
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(signRecordBucketName)
+		if bucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			// the key is (chainID || pk || height)
+			if len(k) < schnorr.PubKeyBytesLen+8 {
+				return ErrCorruptedEOTSDb
+			}
+			heightIdx := len(k) - 8
+			chainID := string(k[:heightIdx-schnorr.PubKeyBytesLen])
+			eotsPkHex := hex.EncodeToString(k[heightIdx-schnorr.PubKeyBytesLen : heightIdx])
+			height := sdk.BigEndianToUint64(k[heightIdx:])
+
+			if !f.matches(chainID, eotsPkHex, height) {
+				return nil
+			}
+
+			var record proto.SigningRecord
+			if err := pm.Unmarshal(v, &record); err != nil {
+				return ErrCorruptedEOTSDb
+			}
+
+			res = append(res, &SignRecordInfo{
+				ChainID:   chainID,
+				EotsPkHex: eotsPkHex,
+				Height:    height,
+				MsgHex:    hex.EncodeToString(record.Msg),
+				SigHex:    hex.EncodeToString(record.EotsSig),
+				Timestamp: time.UnixMilli(record.Timestamp).UTC(),
+			})
+
+			return nil
+		})
+	}, func() {
+		res = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the keys of chain IDs having another one as a prefix may interleave
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].ChainID != res[j].ChainID {
+			return res[i].ChainID < res[j].ChainID
+		}
+		if res[i].EotsPkHex != res[j].EotsPkHex {
+			return res[i].EotsPkHex < res[j].EotsPkHex
+		}
+
+		return res[i].Height < res[j].Height
+	})
+
+	return res, nil
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"github.com/lightningnetwork/lnd/kvdb"
+
+	"github.com/babylonlabs-io/finality-provider/migration"
+)
+
+// migrations are the schema migrations of the eotsd database, in order. New
+// migrations must only be appended
+var migrations = []migration.Migration{
+	migration.CreateBuckets("create the EOTS key name and signing record buckets",
+		eotsBucketName, signRecordBucketName),
+}
+
+// MigrateDB runs the pending schema migrations of the eotsd database
+func MigrateDB(db kvdb.Backend) (*migration.Plan, error) {
+	return migration.Run(db, migrations)
+}
+
+// CheckDBMigrations returns the pending schema migrations of the eotsd database
+// without running them
+func CheckDBMigrations(db kvdb.Backend) (*migration.Plan, error) {
+	return migration.Check(db, migrations)
+}
+
+// ValidateDB checks that the database, e.g., a snapshot to restore, is an
+// eotsd database of a schema version known to the binary, and returns its
+// pending schema migrations
+func ValidateDB(db kvdb.Backend) (*migration.Plan, error) {
+	plan, err := CheckDBMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migration.CheckBuckets(db, eotsBucketName, signRecordBucketName); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,225 @@
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+var _ clientcontroller.ClientController = &ClientController{}
+
+// errPartialBatch is returned for the rest of a partially submitted batch if
+// the rule does not specify an error
+var errPartialBatch = errors.New("injected partial batch failure")
+
+// ClientController decorates a ClientController with injected faults
+type ClientController struct {
+	cc clientcontroller.ClientController
+	in *Injector
+}
+
+func NewClientController(cc clientcontroller.ClientController, in *Injector) *ClientController {
+	return &ClientController{
+		cc: cc,
+		in: in,
+	}
+}
+
+func (c *ClientController) inject(ctx context.Context, method string) error {
+	return c.in.inject(ctx, TargetClientController, method)
+}
+
+func (c *ClientController) RegisterFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey, pop []byte, commission *sdkmath.LegacyDec, description []byte) (*types.TxResponse, error) {
+	if err := c.inject(ctx, "RegisterFinalityProvider"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.RegisterFinalityProvider(ctx, fpPk, pop, commission, description)
+}
+
+func (c *ClientController) EditFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey, commission *sdkmath.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	if err := c.inject(ctx, "EditFinalityProvider"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.EditFinalityProvider(ctx, fpPk, commission, description)
+}
+
+func (c *ClientController) CommitPubRandList(ctx context.Context, fpPk *btcec.PublicKey, startHeight uint64, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types.TxResponse, error) {
+	if err := c.inject(ctx, "CommitPubRandList"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.CommitPubRandList(ctx, fpPk, startHeight, numPubRand, commitment, sig)
+}
+
+func (c *ClientController) SubmitFinalitySig(ctx context.Context, fpPk *btcec.PublicKey, block *types.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types.TxResponse, error) {
+	if err := c.inject(ctx, "SubmitFinalitySig"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.SubmitFinalitySig(ctx, fpPk, block, pubRand, proof, sig)
+}
+
+// SubmitBatchFinalitySigs submits the batch, or only its first signatures if
+// the injected fault is a partial batch failure
+func (c *ClientController) SubmitBatchFinalitySigs(ctx context.Context, fpPk *btcec.PublicKey, blocks []*types.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types.TxResponse, error) {
+	const method = "SubmitBatchFinalitySigs"
+
+	f := c.in.pick(TargetClientController, method)
+	if f == nil {
+		return c.cc.SubmitBatchFinalitySigs(ctx, fpPk, blocks, pubRandList, proofList, sigs)
+	}
+
+	if err := f.delay(ctx); err != nil {
+		return nil, err
+	}
+
+	if f.partialBatch == 0 || f.partialBatch >= len(blocks) || len(blocks) != len(sigs) ||
+		len(blocks) != len(pubRandList) || len(blocks) != len(proofList) {
+		if f.err != nil {
+			return nil, f.err
+		}
+
+		return c.cc.SubmitBatchFinalitySigs(ctx, fpPk, blocks, pubRandList, proofList, sigs)
+	}
+
+	n := f.partialBatch
+	if _, err := c.cc.SubmitBatchFinalitySigs(ctx, fpPk, blocks[:n], pubRandList[:n], proofList[:n], sigs[:n]); err != nil {
+		return nil, err
+	}
+
+	err := f.err
+	if err == nil {
+		err = errPartialBatch
+	}
+
+	return nil, fmt.Errorf("only submitted the first %d of %d finality signatures: %w", n, len(blocks), err)
+}
+
+func (c *ClientController) UnjailFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	if err := c.inject(ctx, "UnjailFinalityProvider"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.UnjailFinalityProvider(ctx, fpPk)
+}
+
+func (c *ClientController) QueryFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	if err := c.inject(ctx, "QueryFinalityProvider"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.QueryFinalityProvider(ctx, fpPk)
+}
+
+func (c *ClientController) QueryFinalityProviderVotingPower(ctx context.Context, fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	if err := c.inject(ctx, "QueryFinalityProviderVotingPower"); err != nil {
+		return 0, err
+	}
+
+	return c.cc.QueryFinalityProviderVotingPower(ctx, fpPk, blockHeight)
+}
+
+func (c *ClientController) QueryFinalityProviderSlashedOrJailed(ctx context.Context, fpPk *btcec.PublicKey) (bool, bool, error) {
+	if err := c.inject(ctx, "QueryFinalityProviderSlashedOrJailed"); err != nil {
+		return false, false, err
+	}
+
+	return c.cc.QueryFinalityProviderSlashedOrJailed(ctx, fpPk)
+}
+
+func (c *ClientController) QueryFinalityProviderJailedUntil(ctx context.Context, fpPk *btcec.PublicKey) (time.Time, error) {
+	if err := c.inject(ctx, "QueryFinalityProviderJailedUntil"); err != nil {
+		return time.Time{}, err
+	}
+
+	return c.cc.QueryFinalityProviderJailedUntil(ctx, fpPk)
+}
+
+func (c *ClientController) QueryFinalityProviderHighestVotedHeight(ctx context.Context, fpPk *btcec.PublicKey) (uint64, error) {
+	if err := c.inject(ctx, "QueryFinalityProviderHighestVotedHeight"); err != nil {
+		return 0, err
+	}
+
+	return c.cc.QueryFinalityProviderHighestVotedHeight(ctx, fpPk)
+}
+
+func (c *ClientController) QueryLatestFinalizedBlocks(ctx context.Context, count uint64) ([]*types.BlockInfo, error) {
+	if err := c.inject(ctx, "QueryLatestFinalizedBlocks"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.QueryLatestFinalizedBlocks(ctx, count)
+}
+
+func (c *ClientController) QueryLastCommittedPublicRand(ctx context.Context, fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	if err := c.inject(ctx, "QueryLastCommittedPublicRand"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.QueryLastCommittedPublicRand(ctx, fpPk, count)
+}
+
+func (c *ClientController) QueryBlock(ctx context.Context, height uint64) (*types.BlockInfo, error) {
+	if err := c.inject(ctx, "QueryBlock"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.QueryBlock(ctx, height)
+}
+
+func (c *ClientController) QueryBlocks(ctx context.Context, startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	if err := c.inject(ctx, "QueryBlocks"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.QueryBlocks(ctx, startHeight, endHeight, limit)
+}
+
+func (c *ClientController) QueryBestBlock(ctx context.Context) (*types.BlockInfo, error) {
+	if err := c.inject(ctx, "QueryBestBlock"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.QueryBestBlock(ctx)
+}
+
+func (c *ClientController) QueryNodeStatus(ctx context.Context) (*types.NodeStatus, error) {
+	if err := c.inject(ctx, "QueryNodeStatus"); err != nil {
+		return nil, err
+	}
+
+	return c.cc.QueryNodeStatus(ctx)
+}
+
+func (c *ClientController) QueryActivatedHeight(ctx context.Context) (uint64, error) {
+	if err := c.inject(ctx, "QueryActivatedHeight"); err != nil {
+		return 0, err
+	}
+
+	return c.cc.QueryActivatedHeight(ctx)
+}
+
+func (c *ClientController) QueryFinalityActivationBlockHeight(ctx context.Context) (uint64, error) {
+	if err := c.inject(ctx, "QueryFinalityActivationBlockHeight"); err != nil {
+		return 0, err
+	}
+
+	return c.cc.QueryFinalityActivationBlockHeight(ctx)
+}
+
+// Close closes the decorated controller without injecting any fault
+func (c *ClientController) Close() error {
+	return c.cc.Close()
+}
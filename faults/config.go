@@ -0,0 +1,130 @@
+package faults
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// TargetClientController targets the calls to the consumer chain
+	TargetClientController = "clientcontroller"
+	// TargetEOTSManager targets the calls to the EOTS manager
+	TargetEOTSManager = "eotsmanager"
+)
+
+// Config is the set of faults to inject, which can be loaded from a JSON file
+type Config struct {
+	// Seed seeds the randomness of the probabilistic rules, and a random
+	// seed is used if it is zero
+	Seed  int64   `json:"seed"`
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule injects a fault into the matching calls. Each call is subject to the
+// first applicable rule only
+type Rule struct {
+	// Target is the interface whose calls are matched, and an empty target
+	// matches both
+	Target string `json:"target"`
+	// Method is the name of the method whose calls are matched, and an empty
+	// method matches all the methods of the target
+	Method string `json:"method"`
+	// Probability is the probability of injecting the fault into a matching
+	// call, and the fault is always injected if it is zero
+	Probability float64 `json:"probability"`
+	// After is the number of matching calls to let through before injecting
+	After uint64 `json:"after"`
+	// Count is the maximum number of injected faults, and zero means unlimited
+	Count uint64 `json:"count"`
+	// Latency delays the call
+	Latency time.Duration `json:"latency"`
+	// Hang blocks the call until its context is done
+	Hang bool `json:"hang"`
+	// Error is returned instead of making the call, which is either the name
+	// of a Babylon error, e.g., ErrInvalidFinalitySig, or an arbitrary message
+	Error string `json:"error"`
+	// PartialBatch is the number of finality signatures in a batch that are
+	// submitted before Error is returned for the rest of the batch, which
+	// only applies to SubmitBatchFinalitySigs
+	PartialBatch int `json:"partial_batch"`
+}
+
+// LoadConfig loads the faults from the JSON file at the given path
+func LoadConfig(path string) (*Config, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the fault config %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(bz, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the fault config %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fault config %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+func (cfg *Config) Validate() error {
+	for i, r := range cfg.Rules {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Rule) Validate() error {
+	switch r.Target {
+	case "", TargetClientController, TargetEOTSManager:
+	default:
+		return fmt.Errorf("unsupported target %s", r.Target)
+	}
+
+	if r.Probability < 0 || r.Probability > 1 {
+		return fmt.Errorf("probability should be between 0 and 1")
+	}
+
+	if r.Latency < 0 {
+		return fmt.Errorf("latency should not be negative")
+	}
+
+	if r.PartialBatch < 0 {
+		return fmt.Errorf("partial batch should not be negative")
+	}
+
+	if r.Latency == 0 && !r.Hang && r.Error == "" && r.PartialBatch == 0 {
+		return fmt.Errorf("no fault is specified")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON parses the latency as a duration string, e.g., 500ms
+func (r *Rule) UnmarshalJSON(bz []byte) error {
+	type rule Rule
+	aux := struct {
+		*rule
+		Latency string `json:"latency"`
+	}{rule: (*rule)(r)}
+
+	if err := json.Unmarshal(bz, &aux); err != nil {
+		return err
+	}
+
+	if aux.Latency != "" {
+		latency, err := time.ParseDuration(aux.Latency)
+		if err != nil {
+			return fmt.Errorf("invalid latency %s: %w", aux.Latency, err)
+		}
+		r.Latency = latency
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package faults
+
+import (
+	"context"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+var _ eotsmanager.EOTSManager = &EOTSManager{}
+
+// EOTSManager decorates an EOTSManager with injected faults
+type EOTSManager struct {
+	em eotsmanager.EOTSManager
+	in *Injector
+}
+
+func NewEOTSManager(em eotsmanager.EOTSManager, in *Injector) *EOTSManager {
+	return &EOTSManager{
+		em: em,
+		in: in,
+	}
+}
+
+func (e *EOTSManager) inject(ctx context.Context, method string) error {
+	return e.in.inject(ctx, TargetEOTSManager, method)
+}
+
+func (e *EOTSManager) CreateKey(ctx context.Context, name, passphrase, hdPath string) ([]byte, error) {
+	if err := e.inject(ctx, "CreateKey"); err != nil {
+		return nil, err
+	}
+
+	return e.em.CreateKey(ctx, name, passphrase, hdPath)
+}
+
+func (e *EOTSManager) CreateRandomnessPairList(ctx context.Context, uid []byte, chainID []byte, startHeight uint64, num uint32, passphrase string) ([]*btcec.FieldVal, error) {
+	if err := e.inject(ctx, "CreateRandomnessPairList"); err != nil {
+		return nil, err
+	}
+
+	return e.em.CreateRandomnessPairList(ctx, uid, chainID, startHeight, num, passphrase)
+}
+
+func (e *EOTSManager) KeyRecord(ctx context.Context, uid []byte, passphrase string) (*types.KeyRecord, error) {
+	if err := e.inject(ctx, "KeyRecord"); err != nil {
+		return nil, err
+	}
+
+	return e.em.KeyRecord(ctx, uid, passphrase)
+}
+
+func (e *EOTSManager) SignEOTS(ctx context.Context, uid []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+	if err := e.inject(ctx, "SignEOTS"); err != nil {
+		return nil, err
+	}
+
+	return e.em.SignEOTS(ctx, uid, chainID, msg, height, passphrase)
+}
+
+func (e *EOTSManager) UnsafeSignEOTS(ctx context.Context, uid []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+	if err := e.inject(ctx, "UnsafeSignEOTS"); err != nil {
+		return nil, err
+	}
+
+	return e.em.UnsafeSignEOTS(ctx, uid, chainID, msg, height, passphrase)
+}
+
+func (e *EOTSManager) SignSchnorrSig(ctx context.Context, uid []byte, msg []byte, passphrase string) (*schnorr.Signature, error) {
+	if err := e.inject(ctx, "SignSchnorrSig"); err != nil {
+		return nil, err
+	}
+
+	return e.em.SignSchnorrSig(ctx, uid, msg, passphrase)
+}
+
+func (e *EOTSManager) SaveEOTSKeyName(ctx context.Context, pk *btcec.PublicKey, keyName string) error {
+	if err := e.inject(ctx, "SaveEOTSKeyName"); err != nil {
+		return err
+	}
+
+	return e.em.SaveEOTSKeyName(ctx, pk, keyName)
+}
+
+// Close closes the decorated manager without injecting any fault
+func (e *EOTSManager) Close() error {
+	return e.em.Close()
+}
@@ -0,0 +1,167 @@
+package faults_test
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/faults"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func newFaultyClientController(t *testing.T, rules ...*faults.Rule) (*faults.ClientController, *mocks.MockClientController, *faults.Injector) {
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+
+	in, err := faults.NewInjector(&faults.Config{Seed: 1, Rules: rules}, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+
+	return faults.NewClientController(mockClientController, in), mockClientController, in
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "faults.json")
+	err := os.WriteFile(path, []byte(`{
+		"seed": 1,
+		"rules": [
+			{"target": "clientcontroller", "method": "SubmitBatchFinalitySigs", "probability": 0.5, "error": "ErrInvalidFinalitySig"},
+			{"target": "eotsmanager", "method": "SignEOTS", "latency": "500ms", "count": 3}
+		]
+	}`), 0600)
+	require.NoError(t, err)
+
+	cfg, err := faults.LoadConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Rules, 2)
+	require.Equal(t, "ErrInvalidFinalitySig", cfg.Rules[0].Error)
+	require.Equal(t, 500*time.Millisecond, cfg.Rules[1].Latency)
+	require.Equal(t, uint64(3), cfg.Rules[1].Count)
+
+	err = os.WriteFile(path, []byte(`{"rules": [{"target": "unknown", "hang": true}]}`), 0600)
+	require.NoError(t, err)
+	_, err = faults.LoadConfig(path)
+	require.Error(t, err)
+
+	err = os.WriteFile(path, []byte(`{"rules": [{"method": "QueryBlock"}]}`), 0600)
+	require.NoError(t, err)
+	_, err = faults.LoadConfig(path)
+	require.Error(t, err)
+}
+
+func TestInjectErrors(t *testing.T) {
+	t.Parallel()
+	cc, mockClientController, in := newFaultyClientController(t,
+		&faults.Rule{Target: faults.TargetEOTSManager, Error: "unreachable"},
+		&faults.Rule{Method: "QueryBestBlock", After: 1, Count: 2, Error: "ErrBlockNotFound"},
+		&faults.Rule{Target: faults.TargetClientController, Method: "QueryBlock", Error: "connection refused"},
+	)
+	ctx := context.Background()
+
+	tip := &types.BlockInfo{Height: 10}
+	mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(tip, nil).Times(2)
+
+	// the first call is let through, then the Babylon error is injected twice
+	b, err := cc.QueryBestBlock(ctx)
+	require.NoError(t, err)
+	require.Equal(t, tip, b)
+	for i := 0; i < 2; i++ {
+		_, err = cc.QueryBestBlock(ctx)
+		require.True(t, clientcontroller.IsUnrecoverable(err))
+	}
+	_, err = cc.QueryBestBlock(ctx)
+	require.NoError(t, err)
+
+	_, err = cc.QueryBlock(ctx, 1)
+	require.ErrorContains(t, err, "connection refused")
+	require.False(t, clientcontroller.IsUnrecoverable(err))
+	require.Equal(t, uint64(3), in.NumInjected())
+
+	// the calls are let through once the rules are cleared
+	in.ClearRules()
+	mockClientController.EXPECT().QueryBlock(gomock.Any(), uint64(1)).Return(tip, nil).Times(1)
+	_, err = cc.QueryBlock(ctx, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, in.AddRule(&faults.Rule{Method: "UnjailFinalityProvider", Error: "ErrFpNotJailed"}))
+	_, err = cc.UnjailFinalityProvider(ctx, nil)
+	require.ErrorIs(t, err, btcstakingtypes.ErrFpNotJailed)
+}
+
+func TestInjectWithProbability(t *testing.T) {
+	t.Parallel()
+	cc, mockClientController, in := newFaultyClientController(t,
+		&faults.Rule{Method: "QueryActivatedHeight", Probability: 0.5, Error: "timeout"},
+	)
+	mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(uint64(1), nil).AnyTimes()
+
+	numCalls := 1000
+	numFailed := 0
+	for i := 0; i < numCalls; i++ {
+		if _, err := cc.QueryActivatedHeight(context.Background()); err != nil {
+			numFailed++
+		}
+	}
+	require.Equal(t, uint64(numFailed), in.NumInjected())
+	require.InDelta(t, numCalls/2, numFailed, float64(numCalls)/10)
+}
+
+func TestInjectLatencyAndHang(t *testing.T) {
+	t.Parallel()
+	latency := 100 * time.Millisecond
+	cc, mockClientController, _ := newFaultyClientController(t,
+		&faults.Rule{Method: "QueryNodeStatus", Latency: latency},
+		&faults.Rule{Method: "QueryBestBlock", Hang: true},
+	)
+	mockClientController.EXPECT().QueryNodeStatus(gomock.Any()).Return(&types.NodeStatus{}, nil).Times(1)
+
+	start := time.Now()
+	_, err := cc.QueryNodeStatus(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), latency)
+
+	// the hanging call returns once the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), latency)
+	defer cancel()
+	_, err = cc.QueryBestBlock(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestInjectPartialBatch(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	cc, mockClientController, _ := newFaultyClientController(t,
+		&faults.Rule{Method: "SubmitBatchFinalitySigs", PartialBatch: 2, Count: 1},
+	)
+
+	blocks := testutil.GenBlocks(r, 1, 5)
+	pubRandList := make([]*btcec.FieldVal, len(blocks))
+	proofList := make([][]byte, len(blocks))
+	sigs := make([]*btcec.ModNScalar, len(blocks))
+
+	// only the first signatures are submitted
+	mockClientController.EXPECT().
+		SubmitBatchFinalitySigs(gomock.Any(), gomock.Any(), blocks[:2], pubRandList[:2], proofList[:2], sigs[:2]).
+		Return(&types.TxResponse{TxHash: "hash"}, nil).Times(1)
+	_, err := cc.SubmitBatchFinalitySigs(context.Background(), nil, blocks, pubRandList, proofList, sigs)
+	require.Error(t, err)
+
+	// the whole batch is submitted once the rule is exhausted
+	mockClientController.EXPECT().
+		SubmitBatchFinalitySigs(gomock.Any(), gomock.Any(), blocks, pubRandList, proofList, sigs).
+		Return(&types.TxResponse{TxHash: "hash"}, nil).Times(1)
+	res, err := cc.SubmitBatchFinalitySigs(context.Background(), nil, blocks, pubRandList, proofList, sigs)
+	require.NoError(t, err)
+	require.Equal(t, "hash", res.TxHash)
+}
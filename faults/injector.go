@@ -0,0 +1,206 @@
+package faults
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+)
+
+// babylonErrors are the Babylon errors that can be injected by their names
+var babylonErrors = map[string]*sdkErr.Error{
+	"ErrBlockNotFound":              finalitytypes.ErrBlockNotFound,
+	"ErrInvalidFinalitySig":         finalitytypes.ErrInvalidFinalitySig,
+	"ErrNoPubRandYet":               finalitytypes.ErrNoPubRandYet,
+	"ErrPubRandNotFound":            finalitytypes.ErrPubRandNotFound,
+	"ErrTooFewPubRand":              finalitytypes.ErrTooFewPubRand,
+	"ErrInvalidPubRand":             finalitytypes.ErrInvalidPubRand,
+	"ErrDuplicatedFinalitySig":      finalitytypes.ErrDuplicatedFinalitySig,
+	"ErrJailingPeriodNotPassed":     finalitytypes.ErrJailingPeriodNotPassed,
+	"ErrVotingPowerTableNotUpdated": finalitytypes.ErrVotingPowerTableNotUpdated,
+	"ErrBTCStakingNotActivated":     finalitytypes.ErrBTCStakingNotActivated,
+	"ErrFpNotFound":                 btcstakingtypes.ErrFpNotFound,
+	"ErrFpAlreadySlashed":           btcstakingtypes.ErrFpAlreadySlashed,
+	"ErrFpAlreadyJailed":            btcstakingtypes.ErrFpAlreadyJailed,
+	"ErrFpNotJailed":                btcstakingtypes.ErrFpNotJailed,
+}
+
+// Injector decides which faults are injected into the calls of the
+// decorated ClientController and EOTSManager
+type Injector struct {
+	logger *zap.Logger
+
+	mu          sync.Mutex
+	rand        *rand.Rand
+	rules       []*ruleState
+	numInjected uint64
+}
+
+type ruleState struct {
+	*Rule
+	err        error
+	numMatched uint64
+	numApplied uint64
+}
+
+// fault is the fault injected into a single call
+type fault struct {
+	latency      time.Duration
+	hang         bool
+	err          error
+	partialBatch int
+}
+
+// NewInjector returns an injector with the rules of the given config
+func NewInjector(cfg *Config, logger *zap.Logger) (*Injector, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	in := &Injector{
+		logger: logger,
+		// #nosec G404 -- the faults do not need secure randomness
+		rand: rand.New(rand.NewSource(seed)),
+	}
+	for _, r := range cfg.Rules {
+		in.addRule(r)
+	}
+
+	return in, nil
+}
+
+// AddRule adds a rule after the existing ones
+func (in *Injector) AddRule(r *Rule) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.addRule(r)
+
+	return nil
+}
+
+// ClearRules removes all the rules, so that the calls are no longer affected
+func (in *Injector) ClearRules() {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.rules = nil
+}
+
+// NumInjected returns the number of faults injected so far
+func (in *Injector) NumInjected() uint64 {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return in.numInjected
+}
+
+func (in *Injector) addRule(r *Rule) {
+	var err error
+	if r.Error != "" {
+		if babylonErr, ok := babylonErrors[r.Error]; ok {
+			err = clientcontroller.ClassifyError(babylonErr)
+		} else {
+			err = errors.New(r.Error)
+		}
+	}
+
+	in.rules = append(in.rules, &ruleState{Rule: r, err: err})
+}
+
+// pick returns the fault to inject into the call, or nil if no rule applies
+func (in *Injector) pick(target, method string) *fault {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	for _, r := range in.rules {
+		if (r.Target != "" && r.Target != target) || (r.Method != "" && r.Method != method) {
+			continue
+		}
+
+		r.numMatched++
+		if r.numMatched <= r.After || (r.Count > 0 && r.numApplied >= r.Count) {
+			continue
+		}
+		if r.Probability > 0 && in.rand.Float64() >= r.Probability {
+			continue
+		}
+
+		r.numApplied++
+		in.numInjected++
+
+		f := &fault{
+			latency:      r.Latency,
+			hang:         r.Hang,
+			partialBatch: r.PartialBatch,
+		}
+		if r.err != nil {
+			f.err = fmt.Errorf("injected fault into %s: %w", method, r.err)
+		}
+
+		in.logger.Debug("injecting fault",
+			zap.String("target", target),
+			zap.String("method", method),
+			zap.Duration("latency", f.latency),
+			zap.Bool("hang", f.hang),
+			zap.Error(f.err),
+		)
+
+		return f
+	}
+
+	return nil
+}
+
+// delay applies the latency and the hang of the fault, and returns an
+// error if the context is done in the meantime
+func (f *fault) delay(ctx context.Context) error {
+	if f.latency > 0 {
+		select {
+		case <-time.After(f.latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if f.hang {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// inject applies the fault picked for the call, if any, and returns the
+// error to return instead of making the call
+func (in *Injector) inject(ctx context.Context, target, method string) error {
+	f := in.pick(target, method)
+	if f == nil {
+		return nil
+	}
+
+	if err := f.delay(ctx); err != nil {
+		return err
+	}
+
+	return f.err
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/std"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -22,6 +23,7 @@ func PersistClientCtx(ctx client.Context) func(cmd *cobra.Command, _ []string) e
 		encCfg := params.DefaultEncodingConfig()
 		std.RegisterInterfaces(encCfg.InterfaceRegistry)
 		bstypes.RegisterInterfaces(encCfg.InterfaceRegistry)
+		authz.RegisterInterfaces(encCfg.InterfaceRegistry)
 
 		ctx = ctx.
 			WithCodec(encCfg.Codec).
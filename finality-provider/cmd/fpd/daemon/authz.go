@@ -0,0 +1,143 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkflags "github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/spf13/cobra"
+)
+
+// AuthzMsgTypeURLs are the msg types that a finality provider can delegate
+// to a hot key through authz
+var AuthzMsgTypeURLs = []string{
+	sdk.MsgTypeURL(&finalitytypes.MsgAddFinalitySig{}),
+	sdk.MsgTypeURL(&finalitytypes.MsgCommitPubRandList{}),
+	sdk.MsgTypeURL(&finalitytypes.MsgUnjailFinalityProvider{}),
+}
+
+// CommandAuthz returns the authz command with its subcommands.
+func CommandAuthz() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "authz",
+		Short: "Manage the authz grants that allow a hot key to vote on behalf of the finality provider.",
+		Long: "Manage the authz grants that allow a hot key to send finality votes, public randomness " +
+			"commits and unjail transactions on behalf of the finality provider. The commands must be " +
+			"signed by the key of the finality provider's registered Babylon address.",
+		RunE: client.ValidateCmd,
+	}
+
+	cmd.AddCommand(CommandAuthzGrant(), CommandAuthzRevoke())
+
+	return cmd
+}
+
+// CommandAuthzGrant returns the authz grant command.
+func CommandAuthzGrant() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "grant [grantee-address]",
+		Short: "Grant the given address the permission to vote on behalf of the finality provider.",
+		Long: "Grant the given (hot key) address the permission to send MsgAddFinalitySig, MsgCommitPubRandList " +
+			"and MsgUnjailFinalityProvider on behalf of the finality provider. After the grant, set the hot key " +
+			"as `Key` and the finality provider's address as `AuthzGranter` in the babylon section of fpd.conf.",
+		Example: `fpd authz grant bbn1... --from <registered-bbn-address> --keyring-backend test --home <home-dir> --fees <fees>`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandAuthzGrant,
+	}
+	cmd.Flags().Duration(expirationFlag, 0, "The duration after which the grant expires; the grant never expires if not set")
+	sdkflags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func runCommandAuthzGrant(cmd *cobra.Command, args []string) error {
+	clientCtx, err := getAuthzTxContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	grantee, err := sdk.AccAddressFromBech32(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid grantee address %s: %w", args[0], err)
+	}
+
+	expirationDuration, err := cmd.Flags().GetDuration(expirationFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", expirationFlag, err)
+	}
+
+	var expiration *time.Time
+	if expirationDuration > 0 {
+		exp := time.Now().Add(expirationDuration)
+		expiration = &exp
+	}
+
+	msgs := make([]sdk.Msg, 0, len(AuthzMsgTypeURLs))
+	for _, typeURL := range AuthzMsgTypeURLs {
+		msg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grantee, authz.NewGenericAuthorization(typeURL), expiration)
+		if err != nil {
+			return fmt.Errorf("failed to create grant for %s: %w", typeURL, err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+}
+
+// CommandAuthzRevoke returns the authz revoke command.
+func CommandAuthzRevoke() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "revoke [grantee-address]",
+		Short:   "Revoke the permission of the given address to vote on behalf of the finality provider.",
+		Example: `fpd authz revoke bbn1... --from <registered-bbn-address> --keyring-backend test --home <home-dir> --fees <fees>`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandAuthzRevoke,
+	}
+	sdkflags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func runCommandAuthzRevoke(cmd *cobra.Command, args []string) error {
+	clientCtx, err := getAuthzTxContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	grantee, err := sdk.AccAddressFromBech32(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid grantee address %s: %w", args[0], err)
+	}
+
+	msgs := make([]sdk.Msg, 0, len(AuthzMsgTypeURLs))
+	for _, typeURL := range AuthzMsgTypeURLs {
+		msg := authz.NewMsgRevoke(clientCtx.GetFromAddress(), grantee, typeURL)
+		msgs = append(msgs, &msg)
+	}
+
+	return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+}
+
+// getAuthzTxContext returns the tx context of the cmd, making sure the from
+// address is resolved even if the key name was loaded from the config
+func getAuthzTxContext(cmd *cobra.Command) (client.Context, error) {
+	clientCtx, err := client.GetClientTxContext(cmd)
+	if err != nil {
+		return clientCtx, err
+	}
+
+	if clientCtx.GetFromAddress().Empty() {
+		fromAddr, fromName, _, err := client.GetFromFields(clientCtx, clientCtx.Keyring, clientCtx.From)
+		if err != nil {
+			return clientCtx, fmt.Errorf("failed to get the granter address from %s: %w", clientCtx.From, err)
+		}
+		clientCtx = clientCtx.WithFromAddress(fromAddr).WithFromName(fromName)
+	}
+
+	return clientCtx, nil
+}
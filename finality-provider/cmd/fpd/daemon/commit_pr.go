@@ -10,6 +10,7 @@ import (
 	fpcc "github.com/babylonlabs-io/finality-provider/clientcontroller"
 	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/log"
@@ -90,16 +91,22 @@ func runCommandCommitPubRand(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create EOTS manager client: %w", err)
 	}
 
+	// no notification is delivered for the manual commit
+	fpNotifier, err := notifier.NewWithSinks(cfg.Notifier, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create notifier: %w", err)
+	}
+
 	fp, err := service.NewFinalityProviderInstance(
-		fpPk, cfg, fpStore, pubRandStore, cc, em, metrics.NewFpMetrics(), "",
+		fpPk, cfg, fpStore, pubRandStore, cc, em, metrics.NewFpMetrics(), service.NewEventBus(logger), fpNotifier, "",
 		make(chan<- *service.CriticalError), logger)
 	if err != nil {
 		return fmt.Errorf("failed to create finality-provider %s instance: %w", fpPk.MarshalHex(), err)
 	}
 
 	if startHeight == math.MaxUint64 {
-		return fp.TestCommitPubRand(targetHeight)
+		return fp.TestCommitPubRand(cmd.Context(), targetHeight)
 	}
 
-	return fp.TestCommitPubRandWithStartHeight(startHeight, targetHeight)
+	return fp.TestCommitPubRandWithStartHeight(cmd.Context(), startHeight, targetHeight)
 }
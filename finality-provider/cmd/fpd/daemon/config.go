@@ -0,0 +1,82 @@
+package daemon
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/jessevdk/go-flags"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/envconfig"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandConfig returns the config command, which prints the fpd config
+func CommandConfig() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "config",
+		Short: "Print the fpd config.",
+	}
+
+	cmd.AddCommand(commandConfigShow())
+
+	return cmd
+}
+
+func commandConfigShow() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "show",
+		Short: "Print the config of the config file, with the secrets redacted.",
+		Long: "Print the config of the config file, with the secrets redacted. With --effective, the config " +
+			"printed is the one the daemon runs with, i.e., with the overrides of the FPD_* environment " +
+			"variables, which are listed first.",
+		Example: `fpd config show --effective --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandConfigShow),
+	}
+	cmd.Flags().Bool(effectiveFlag, false, "Print the config with the overrides of the environment variables")
+
+	return cmd
+}
+
+func runCommandConfigShow(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	effective, err := cmd.Flags().GetBool(effectiveFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", effectiveFlag, err)
+	}
+
+	var lookupEnv envconfig.LookupEnvFunc
+	if effective {
+		lookupEnv = os.LookupEnv
+	}
+
+	cfg, applied, err := fpcfg.LoadConfigWithEnv(homePath, lookupEnv)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	envconfig.Redact(cfg)
+
+	var buf bytes.Buffer
+	for _, name := range applied {
+		fmt.Fprintf(&buf, "; overridden by %s\n", name)
+	}
+	if len(applied) > 0 {
+		buf.WriteString("\n")
+	}
+
+	flags.NewIniParser(flags.NewParser(cfg, flags.Default)).Write(&buf, flags.IniIncludeDefaults)
+	cmd.Print(buf.String())
+
+	return nil
+}
@@ -1,7 +1,6 @@
 package daemon
 
 import (
-	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -22,6 +21,7 @@ import (
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/log"
 )
 
 var (
@@ -60,7 +60,7 @@ func runCommandGetDaemonInfo(cmd *cobra.Command, _ []string) error {
 		}
 	}()
 
-	info, err := client.GetInfo(context.Background())
+	info, err := client.GetInfo(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -70,6 +70,99 @@ func runCommandGetDaemonInfo(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// CommandReloadConfig returns the reload-config command by connecting to the fpd daemon.
+func CommandReloadConfig() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "reload-config",
+		Short: "Reload the config file of the running fpd daemon.",
+		Long: "Reload the config file of the running fpd daemon, applying the changed fields that are safe " +
+			"to change without a restart, and report the changed fields that require a restart. " +
+			"The config is also reloaded upon sending SIGHUP to the daemon.",
+		Example: fmt.Sprintf(`fpd reload-config --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandReloadConfig,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandReloadConfig(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.ReloadConfig(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(res)
+
+	return nil
+}
+
+// CommandSetLogLevel returns the set-log-level command by connecting to the fpd daemon.
+func CommandSetLogLevel() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "set-log-level [level]",
+		Short: "Set the logging level of the running fpd daemon.",
+		Long: fmt.Sprintf("Set the logging level of the running fpd daemon, or the one of its subsystem given by "+
+			"--subsystem, which is one of %s. The level is kept until the config is reloaded or the daemon restarted.",
+			strings.Join(log.Subsystems, ", ")),
+		Example: fmt.Sprintf(`fpd set-log-level debug --subsystem %s --daemon-address %s`, log.SubsystemPoller, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandSetLogLevel,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(subsystemFlag, "", "The subsystem whose logging level is set, the root logger if empty")
+
+	return cmd
+}
+
+func runCommandSetLogLevel(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	subsystem, err := flags.GetString(subsystemFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", subsystemFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.SetLogLevel(cmd.Context(), subsystem, args[0])
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(res)
+
+	return nil
+}
+
 // CommandCreateFP returns the create-finality-provider command by connecting to the fpd daemon.
 func CommandCreateFP() *cobra.Command {
 	var cmd = &cobra.Command{
@@ -112,6 +205,7 @@ Where finality-provider.json contains:
 	f.String(sdkflags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
 	f.String(chainIDFlag, "", "The identifier of the consumer chain")
 	f.String(passphraseFlag, "", "The pass phrase used to encrypt the keys")
+	f.String(passphraseFileFlag, "", "The file of the pass phrase used to encrypt the keys, instead of --passphrase")
 	f.String(commissionRateFlag, "", "The commission rate for the finality provider, e.g., 0.05")
 	f.String(monikerFlag, "", "A human-readable name for the finality provider")
 	f.String(identityFlag, "", "An optional identity signature (ex. UPort or Keybase)")
@@ -185,7 +279,7 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 	}()
 
 	res, err := client.CreateFinalityProvider(
-		context.Background(),
+		cmd.Context(),
 		fp.keyName,
 		fp.chainID,
 		fp.eotsPK,
@@ -236,7 +330,7 @@ func runCommandUnjailFP(_ client.Context, cmd *cobra.Command, args []string) err
 		}
 	}()
 
-	_, err = client.UnjailFinalityProvider(context.Background(), args[0])
+	_, err = client.UnjailFinalityProvider(cmd.Context(), args[0])
 	if err != nil {
 		return err
 	}
@@ -304,7 +398,7 @@ func runCommandLsFP(cmd *cobra.Command, _ []string) error {
 		}
 	}()
 
-	resp, err := client.QueryFinalityProviderList(context.Background())
+	resp, err := client.QueryFinalityProviderList(cmd.Context())
 	if err != nil {
 		return err
 	}
@@ -349,7 +443,7 @@ func runCommandInfoFP(cmd *cobra.Command, args []string) error {
 		}
 	}()
 
-	resp, err := client.QueryFinalityProviderInfo(context.Background(), fpPk)
+	resp, err := client.QueryFinalityProviderInfo(cmd.Context(), fpPk)
 	if err != nil {
 		return err
 	}
@@ -417,7 +511,7 @@ func runCommandAddFinalitySig(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	res, err := client.AddFinalitySignature(context.Background(), fpPk.MarshalHex(), blkHeight, appHash, checkDoubleSign)
+	res, err := client.AddFinalitySignature(cmd.Context(), fpPk.MarshalHex(), blkHeight, appHash, checkDoubleSign)
 	if err != nil {
 		return err
 	}
@@ -707,9 +801,9 @@ func parseFinalityProviderFlags(cmd *cobra.Command, homeDir string) (*parsedFina
 		return nil, fmt.Errorf("chain-id cannot be empty")
 	}
 
-	passphrase, err := flags.GetString(passphraseFlag)
+	passphrase, err := readPassphrase(flags)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+		return nil, err
 	}
 
 	eotsPkHex, err := flags.GetString(fpEotsPkFlag)
@@ -0,0 +1,187 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/backup"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/migration"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandDB returns the db command, which manages the fpd database
+func CommandDB() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "db",
+		Short: "Manage the fpd database.",
+	}
+
+	cmd.AddCommand(commandDBMigrate(), commandDBBackup(), commandDBRestore(), commandDBInspect())
+
+	return cmd
+}
+
+func commandDBMigrate() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Run the pending schema migrations of the fpd database.",
+		Long: "Run the pending schema migrations of the fpd database, which are also run upon start. " +
+			"With --dry-run, the pending migrations are only listed. fpd should be stopped beforehand.",
+		Example: `fpd db migrate --dry-run --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandDBMigrate),
+	}
+	cmd.Flags().Bool(dryRunFlag, false, "List the pending migrations without running them")
+
+	return cmd
+}
+
+func runCommandDBMigrate(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	dryRun, err := cmd.Flags().GetBool(dryRunFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", dryRunFlag, err)
+	}
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := cfg.DatabaseConfig.GetDBBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer db.Close()
+
+	var plan *migration.Plan
+	if dryRun {
+		plan, err = store.CheckDBMigrations(db)
+	} else {
+		plan, err = store.MigrateDB(db)
+	}
+	if err != nil {
+		return err
+	}
+
+	cmd.Print(plan)
+	if !dryRun && len(plan.Pending) > 0 {
+		cmd.Printf("migrated the database to schema version %d\n", plan.Target)
+	}
+
+	return nil
+}
+
+func commandDBBackup() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "backup [output-file]",
+		Short: "Write a snapshot of the database of the running fpd daemon.",
+		Long: "Write a consistent snapshot of the database of the running fpd daemon to the given file. " +
+			"The snapshot is taken within a read transaction, so the daemon keeps running meanwhile.",
+		Example: fmt.Sprintf(`fpd db backup /backups/finality-provider.db --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandDBBackup,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+
+	return cmd
+}
+
+func runCommandDBBackup(cmd *cobra.Command, args []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	output := util.CleanAndExpandPath(args[0])
+	err = backup.WriteFile(output, func(w io.Writer) error {
+		return client.BackupDB(cmd.Context(), w)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to back up the database: %w", err)
+	}
+
+	var plan *migration.Plan
+	err = backup.Validate(output, func(db kvdb.Backend) error {
+		plan, err = store.ValidateDB(db)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("invalid snapshot %s: %w", output, err)
+	}
+
+	cmd.Printf("wrote the snapshot of schema version %d to %s\n", plan.Current, output)
+
+	return nil
+}
+
+func commandDBRestore() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "restore [snapshot-file]",
+		Short: "Restore the fpd database from a snapshot.",
+		Long: "Restore the fpd database from a snapshot written by fpd db backup or by the scheduled backups, " +
+			"once the snapshot is validated. fpd should be stopped beforehand. The replaced database is kept " +
+			"next to the restored one.",
+		Example: `fpd db restore /backups/finality-provider.db --home /home/user/.fpd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    fpcmd.RunEWithClientCtx(runCommandDBRestore),
+	}
+
+	return cmd
+}
+
+func runCommandDBRestore(ctx client.Context, cmd *cobra.Command, args []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	var plan *migration.Plan
+	replaced, err := backup.Restore(util.CleanAndExpandPath(args[0]), cfg.DatabaseConfig.DBConfigToBoltBackendConfig(),
+		func(db kvdb.Backend) error {
+			plan, err = store.ValidateDB(db)
+
+			return err
+		})
+	if err != nil {
+		return fmt.Errorf("failed to restore the database: %w", err)
+	}
+
+	cmd.Printf("restored the database of schema version %d\n", plan.Current)
+	if replaced != "" {
+		cmd.Printf("the replaced database is kept at %s\n", replaced)
+	}
+
+	return nil
+}
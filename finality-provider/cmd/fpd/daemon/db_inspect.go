@@ -0,0 +1,304 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/backup"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// inspectedFp is a finality provider of the inspected database
+type inspectedFp struct {
+	BtcPkHex        string     `json:"btc_pk_hex"`
+	ChainID         string     `json:"chain_id"`
+	FpAddr          string     `json:"fp_addr"`
+	Moniker         string     `json:"moniker"`
+	Commission      string     `json:"commission"`
+	Status          string     `json:"status"`
+	LastVotedHeight uint64     `json:"last_voted_height"`
+	LastCrashReason string     `json:"last_crash_reason,omitempty"`
+	LastCrashTime   *time.Time `json:"last_crash_time,omitempty"`
+}
+
+func commandDBInspect() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "inspect",
+		Short: "Print the content of the fpd database.",
+		Long: "Print the content of the fpd database without changing it. The database of the config is " +
+			"inspected by default, which requires fpd to be stopped. Otherwise, --db-file inspects the given " +
+			"database file, e.g., a snapshot, and --daemon-address inspects a snapshot of the database of " +
+			"the running fpd.",
+	}
+	cmd.PersistentFlags().String(dbFileFlag, "", "The database file to inspect, e.g., a snapshot")
+	cmd.PersistentFlags().String(fpdDaemonAddressFlag, "", "The RPC server address of the running fpd whose database is inspected")
+	cmd.PersistentFlags().String(outputFlag, outputTable, "The output format, either table or json")
+	cmd.PersistentFlags().String(fpEotsPkFlag, "", "Only print the records of the finality provider of the given EOTS public key in hex")
+	cmd.PersistentFlags().String(chainIDFlag, "", "Only print the records of the given chain")
+
+	cmd.AddCommand(commandDBInspectFps(), commandDBInspectPubRand())
+
+	return cmd
+}
+
+func commandDBInspectFps() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "finality-providers",
+		Short:   "Print the finality providers stored in the fpd database.",
+		Example: `fpd db inspect finality-providers --db-file /backups/finality-provider.db --output json`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandDBInspectFps),
+	}
+
+	return cmd
+}
+
+func runCommandDBInspectFps(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	output, filter, err := readInspectFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	db, closeDB, err := openDBToInspect(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	fps, err := store.ListFinalityProviders(db, filter)
+	if err != nil {
+		return err
+	}
+
+	res := make([]*inspectedFp, 0, len(fps))
+	for _, fp := range fps {
+		inspected := &inspectedFp{
+			BtcPkHex:        fp.GetBIP340BTCPK().MarshalHex(),
+			ChainID:         fp.ChainID,
+			FpAddr:          fp.FPAddr,
+			Moniker:         fp.Description.Moniker,
+			Commission:      fp.Commission.String(),
+			Status:          fp.Status.String(),
+			LastVotedHeight: fp.LastVotedHeight,
+			LastCrashReason: fp.LastCrashReason,
+		}
+		if !fp.LastCrashTime.IsZero() {
+			crashTime := fp.LastCrashTime.UTC()
+			inspected.LastCrashTime = &crashTime
+		}
+		res = append(res, inspected)
+	}
+
+	return printInspected(cmd, output, res, func(w io.Writer) {
+		fmt.Fprintln(w, "BTC PK\tCHAIN ID\tADDRESS\tMONIKER\tSTATUS\tLAST VOTED HEIGHT")
+		for _, fp := range res {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\n",
+				fp.BtcPkHex, fp.ChainID, fp.FpAddr, fp.Moniker, fp.Status, fp.LastVotedHeight)
+		}
+	})
+}
+
+func commandDBInspectPubRand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "pub-rand",
+		Short: "Print the ranges of heights of the public randomness proofs stored in the fpd database.",
+		Example: `fpd db inspect pub-rand --daemon-address 127.0.0.1:12581 ` +
+			`--eots-pk 3d0bebcbe800236ce8603c5bb1ab6c2af0932e947db4956a338f119797c37f1e --from-height 1000`,
+		Args: cobra.NoArgs,
+		RunE: fpcmd.RunEWithClientCtx(runCommandDBInspectPubRand),
+	}
+	cmd.Flags().Uint64(fromHeightFlag, 0, "Only print the proofs from the given height")
+	cmd.Flags().Uint64(toHeightFlag, 0, "Only print the proofs up to the given height, 0 for no bound")
+
+	return cmd
+}
+
+func runCommandDBInspectPubRand(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	output, filter, err := readInspectFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if filter.FromHeight, err = cmd.Flags().GetUint64(fromHeightFlag); err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fromHeightFlag, err)
+	}
+	if filter.ToHeight, err = cmd.Flags().GetUint64(toHeightFlag); err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", toHeightFlag, err)
+	}
+
+	db, closeDB, err := openDBToInspect(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ranges, err := store.ListPubRandProofRanges(db, filter)
+	if err != nil {
+		return err
+	}
+	if ranges == nil {
+		ranges = []*store.PubRandProofRange{}
+	}
+
+	return printInspected(cmd, output, ranges, func(w io.Writer) {
+		fmt.Fprintln(w, "CHAIN ID\tBTC PK\tSTART HEIGHT\tEND HEIGHT")
+		for _, r := range ranges {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", r.ChainID, r.BtcPkHex, r.StartHeight, r.EndHeight)
+		}
+	})
+}
+
+// readInspectFlags reads the output format and the filter of the records to
+// inspect
+func readInspectFlags(cmd *cobra.Command) (string, *store.Filter, error) {
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read flag %s: %w", outputFlag, err)
+	}
+	if output != outputTable && output != outputJSON {
+		return "", nil, fmt.Errorf("invalid output format %s, expected %s or %s", output, outputTable, outputJSON)
+	}
+
+	filter := &store.Filter{}
+	if filter.ChainID, err = cmd.Flags().GetString(chainIDFlag); err != nil {
+		return "", nil, fmt.Errorf("failed to read flag %s: %w", chainIDFlag, err)
+	}
+
+	eotsPkHex, err := cmd.Flags().GetString(fpEotsPkFlag)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read flag %s: %w", fpEotsPkFlag, err)
+	}
+	if eotsPkHex != "" {
+		eotsPk, err := bbntypes.NewBIP340PubKeyFromHex(eotsPkHex)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid %s: %w", fpEotsPkFlag, err)
+		}
+		filter.BtcPkHex = eotsPk.MarshalHex()
+	}
+
+	return output, filter, nil
+}
+
+// openDBToInspect opens the database to inspect, which is a snapshot of the
+// database of the running fpd if --daemon-address is set, the database file
+// of --db-file if set, or the database of the config otherwise. The returned
+// function closes the database
+func openDBToInspect(ctx client.Context, cmd *cobra.Command) (kvdb.Backend, func(), error) {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	dbFile, err := cmd.Flags().GetString(dbFileFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read flag %s: %w", dbFileFlag, err)
+	}
+
+	var (
+		db      kvdb.Backend
+		closeDB func()
+	)
+	switch {
+	case daemonAddress != "" && dbFile != "":
+		return nil, nil, fmt.Errorf("only one of --%s and --%s can be set", fpdDaemonAddressFlag, dbFileFlag)
+	case daemonAddress != "":
+		fpdClient, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() {
+			if err := cleanUp(); err != nil {
+				fmt.Printf("Failed to clean up grpc client: %v\n", err)
+			}
+		}()
+
+		db, closeDB, err = backup.OpenCopy(func(w io.Writer) error {
+			return fpdClient.BackupDB(cmd.Context(), w)
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to take a snapshot of the database: %w", err)
+		}
+	case dbFile != "":
+		db, err = backup.Open(util.CleanAndExpandPath(dbFile))
+		if err != nil {
+			return nil, nil, err
+		}
+	default:
+		homePath, err := filepath.Abs(ctx.HomeDir)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cfg, err := fpcfg.LoadConfig(util.CleanAndExpandPath(homePath))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		db, err = backup.Open(filepath.Join(cfg.DatabaseConfig.DBPath, cfg.DatabaseConfig.DBFileName))
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, err
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w, the database of a running fpd is inspected with --%s",
+				err, fpdDaemonAddressFlag)
+		}
+	}
+	if closeDB == nil {
+		closeDB = func() {
+			_ = db.Close()
+		}
+	}
+
+	if _, err := store.ValidateDB(db); err != nil {
+		closeDB()
+
+		return nil, nil, fmt.Errorf("invalid database: %w", err)
+	}
+
+	return db, closeDB, nil
+}
+
+// printInspected prints the inspected records as JSON, or as the table
+// written by the given function
+func printInspected(cmd *cobra.Command, output string, records any, writeTable func(w io.Writer)) error {
+	if output == outputJSON {
+		jsonBytes, err := json.MarshalIndent(records, "", "    ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(jsonBytes))
+
+		return nil
+	}
+
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	writeTable(tw)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	cmd.Print(sb.String())
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+// CommandEvents returns the events command by connecting to the fpd daemon.
+func CommandEvents() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "events [eots-pk]",
+		Short: "Print the recent events of the running fpd daemon.",
+		Long: "Print the recent events of the running fpd daemon, such as status transitions, " +
+			"votes, public randomness commits and chain poller lag. If the EOTS public key " +
+			"is given, only the events of that finality provider are printed.",
+		Example: fmt.Sprintf(`fpd events --follow --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runCommandEvents,
+	}
+
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Bool(followFlag, false, "Keep streaming the upcoming events")
+
+	return cmd
+}
+
+func runCommandEvents(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	follow, err := flags.GetBool(followFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", followFlag, err)
+	}
+
+	var fpPk string
+	if len(args) > 0 {
+		fpPk = args[0]
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	stream, err := client.SubscribeEvents(cmd.Context(), fpPk, follow)
+	if err != nil {
+		return err
+	}
+
+	for {
+		ev, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(ev)
+	}
+}
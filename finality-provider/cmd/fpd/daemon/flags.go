@@ -8,12 +8,26 @@ const (
 	keyNameFlag          = "key-name"
 	appHashFlag          = "app-hash"
 	passphraseFlag       = "passphrase"
+	passphraseFileFlag   = "passphrase-file"
 	hdPathFlag           = "hd-path"
 	chainIDFlag          = "chain-id"
 	signedFlag           = "signed"
 	checkDoubleSignFlag  = "check-double-sign"
 	fromFile             = "from-file"
 	upToHeight           = "up-to-height"
+	expirationFlag       = "expiration"
+	followFlag           = "follow"
+	shadowFlag           = "shadow"
+	simulateFlag         = "simulate"
+	faultsFlag           = "faults"
+	recordFlag           = "record"
+	dryRunFlag           = "dry-run"
+	dbFileFlag           = "db-file"
+	outputFlag           = "output"
+	fromHeightFlag       = "from-height"
+	toHeightFlag         = "to-height"
+	effectiveFlag        = "effective"
+	subsystemFlag        = "subsystem"
 
 	// flags for description
 	monikerFlag         = "moniker"
@@ -0,0 +1,32 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/babylonlabs-io/finality-provider/envconfig"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// readPassphrase reads the passphrase of --passphrase, of the file of
+// --passphrase-file, or of the FPD_PASSPHRASE environment variable, in order
+// of precedence
+func readPassphrase(flags *pflag.FlagSet) (string, error) {
+	passphrase, err := flags.GetString(passphraseFlag)
+	if err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+	}
+
+	passphraseFile, err := flags.GetString(passphraseFileFlag)
+	if err != nil {
+		return "", fmt.Errorf("failed to read flag %s: %w", passphraseFileFlag, err)
+	}
+
+	passphrase, err = envconfig.ResolveSecret(passphrase, passphraseFile, envconfig.EnvName(fpcfg.EnvPrefix, "", passphraseFlag))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the passphrase: %w", err)
+	}
+
+	return passphrase, nil
+}
@@ -11,10 +11,19 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/replay"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/faults"
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -30,7 +39,12 @@ func CommandStart() *cobra.Command {
 	}
 	cmd.Flags().String(fpEotsPkFlag, "", "The EOTS public key of the finality-provider to start")
 	cmd.Flags().String(passphraseFlag, "", "The pass phrase used to decrypt the private key")
+	cmd.Flags().String(passphraseFileFlag, "", "The file of the pass phrase used to decrypt the private key, instead of --passphrase")
 	cmd.Flags().String(rpcListenerFlag, "", "The address that the RPC server listens to")
+	cmd.Flags().Bool(shadowFlag, false, "Run in shadow mode, in which no transaction is broadcast (overrides the config)")
+	cmd.Flags().Bool(simulateFlag, false, "Run against an in-memory simulated consumer chain for development instead of the configured one")
+	cmd.Flags().String(faultsFlag, "", "The path to a JSON file of faults to inject into the calls to the consumer chain and the EOTS manager, for testing only")
+	cmd.Flags().String(recordFlag, "", "The path to a file to record the calls to the consumer chain and their responses to, which can be replayed in tests")
 
 	return cmd
 }
@@ -53,14 +67,29 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", rpcListenerFlag, err)
 	}
 
-	passphrase, err := flags.GetString(passphraseFlag)
+	passphrase, err := readPassphrase(flags)
 	if err != nil {
-		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+		return err
 	}
 
-	cfg, err := fpcfg.LoadConfig(homePath)
+	shadow, err := flags.GetBool(shadowFlag)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return fmt.Errorf("failed to read flag %s: %w", shadowFlag, err)
+	}
+
+	simulate, err := flags.GetBool(simulateFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", simulateFlag, err)
+	}
+
+	faultsPath, err := flags.GetString(faultsFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", faultsFlag, err)
+	}
+
+	recordPath, err := flags.GetString(recordFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", recordFlag, err)
 	}
 
 	if rpcListener != "" {
@@ -68,23 +97,93 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
 		if err != nil {
 			return fmt.Errorf("invalid RPC listener address %s, %w", rpcListener, err)
 		}
-		cfg.RPCListener = rpcListener
 	}
 
-	logger, err := log.NewRootLoggerWithFile(fpcfg.LogFile(homePath), cfg.LogLevel)
+	// the config is loaded the same way upon reload, so that the flags are
+	// not reported as changes
+	loadConfig := func() (*fpcfg.Config, error) {
+		cfg, err := fpcfg.LoadConfig(homePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if shadow {
+			cfg.ShadowMode = true
+		}
+		if rpcListener != "" {
+			cfg.RPCListener = rpcListener
+		}
+		if simulate {
+			// the simulated chain does not timestamp public randomness, so
+			// that it can be used right after being committed
+			cfg.TimestampingDelayBlocks = 0
+		}
+
+		return cfg, nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	logLevel, subsystemLogLevels, err := cfg.LogLevels()
+	if err != nil {
+		return fmt.Errorf("failed to initialize the logger: %w", err)
+	}
+	logLevels := log.NewLevels(logLevel, subsystemLogLevels)
+	logger, logFile, err := log.NewRootLoggerWithRotation(fpcfg.LogFile(homePath), cfg.Log, logLevels)
 	if err != nil {
 		return fmt.Errorf("failed to initialize the logger: %w", err)
 	}
+	defer logFile.Close()
+
+	shutdownTracing, err := tracing.Setup(cmd.Context(), cfg.Tracing, "fpd")
+	if err != nil {
+		return fmt.Errorf("failed to set up the tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(); err != nil {
+			logger.Error("failed to export the pending spans", zap.Error(err))
+		}
+	}()
 
 	dbBackend, err := cfg.DatabaseConfig.GetDBBackend()
 	if err != nil {
 		return fmt.Errorf("failed to create db backend: %w", err)
 	}
 
-	fpApp, err := loadApp(logger, cfg, dbBackend)
+	plan, err := store.MigrateDB(dbBackend)
+	if err != nil {
+		return fmt.Errorf("failed to migrate the database: %w", err)
+	}
+	if len(plan.Pending) > 0 {
+		logger.Named(log.SubsystemStore).Info("migrated the database",
+			zap.Uint32("from_version", plan.Current), zap.Uint32("to_version", plan.Target))
+	}
+
+	wrappers := &clientWrappers{recordPath: recordPath}
+	if faultsPath != "" {
+		faultsCfg, err := faults.LoadConfig(faultsPath)
+		if err != nil {
+			return err
+		}
+		wrappers.in, err = faults.NewInjector(faultsCfg, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create the fault injector: %w", err)
+		}
+	}
+
+	var fpApp *service.FinalityProviderApp
+	if simulate {
+		fpApp, err = loadSimulatedApp(logger, cfg, dbBackend, wrappers)
+	} else {
+		fpApp, err = loadApp(logger, cfg, dbBackend, wrappers)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to load app: %w", err)
 	}
+	fpApp.EnableConfigReload(loadConfig, logLevels)
 
 	if err := startApp(fpApp, fpStr, passphrase); err != nil {
 		return fmt.Errorf("failed to start app: %w", err)
@@ -100,15 +199,115 @@ func loadApp(
 	logger *zap.Logger,
 	cfg *fpcfg.Config,
 	dbBackend walletdb.DB,
+	wrappers *clientWrappers,
+) (*service.FinalityProviderApp, error) {
+	if wrappers.empty() {
+		fpApp, err := service.NewFinalityProviderAppFromConfig(cfg, dbBackend, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create finality-provider app: %w", err)
+		}
+
+		return fpApp, nil
+	}
+
+	cc, err := clientcontroller.NewClientController(cfg.ChainType, cfg.BabylonConfig, &cfg.BTCNetParams,
+		logger.Named(log.SubsystemClientController))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client for the consumer chain %s: %w", cfg.ChainType, err)
+	}
+
+	em, err := eotsclient.NewEOTSManagerGRpcClient(cfg.EOTSManagerAddress,
+		eotsclient.WithLogger(logger.Named(log.SubsystemEOTSRPC)), eotsclient.WithMetrics(metrics.NewFpMetrics()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
+	}
+
+	wrappedCc, wrappedEm, err := wrappers.wrap(logger, cc, em)
+	if err != nil {
+		return nil, err
+	}
+
+	fpApp, err := service.NewFinalityProviderApp(cfg, wrappedCc, wrappedEm, dbBackend, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finality-provider app: %w", err)
+	}
+
+	return fpApp, nil
+}
+
+// loadSimulatedApp initializes a finality provider app running against an
+// in-memory simulated consumer chain, whose state is lost upon shutdown
+func loadSimulatedApp(
+	logger *zap.Logger,
+	cfg *fpcfg.Config,
+	dbBackend walletdb.DB,
+	wrappers *clientWrappers,
 ) (*service.FinalityProviderApp, error) {
-	fpApp, err := service.NewFinalityProviderAppFromConfig(cfg, dbBackend, logger)
+	simChain, err := simulation.NewChain(simulation.DefaultConfig(), logger.Named(log.SubsystemClientController))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the simulated consumer chain: %w", err)
+	}
+
+	eotsClient, err := eotsclient.NewEOTSManagerGRpcClient(cfg.EOTSManagerAddress,
+		eotsclient.WithLogger(logger.Named(log.SubsystemEOTSRPC)), eotsclient.WithMetrics(metrics.NewFpMetrics()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
+	}
+
+	cc, em, err := wrappers.wrap(logger, simChain, eotsClient)
+	if err != nil {
+		return nil, err
+	}
+
+	fpApp, err := service.NewFinalityProviderApp(cfg, cc, em, dbBackend, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create finality-provider app: %w", err)
 	}
 
+	logger.Warn("running against a simulated consumer chain, nothing is sent to the configured consumer chain")
+	simChain.Start()
+
 	return fpApp, nil
 }
 
+// clientWrappers are the decorators of the client controller and the EOTS
+// manager requested by the flags
+type clientWrappers struct {
+	// in injects faults into the calls if set
+	in *faults.Injector
+	// recordPath is the file to record the calls to the consumer chain to if set
+	recordPath string
+}
+
+func (w *clientWrappers) empty() bool {
+	return w.in == nil && w.recordPath == ""
+}
+
+// wrap decorates the client controller and the EOTS manager. The calls to the
+// consumer chain are recorded as the finality provider sees them, i.e., with
+// the injected faults
+func (w *clientWrappers) wrap(
+	logger *zap.Logger,
+	cc clientcontroller.ClientController,
+	em eotsmanager.EOTSManager,
+) (clientcontroller.ClientController, eotsmanager.EOTSManager, error) {
+	if w.in != nil {
+		logger.Warn("injecting faults into the calls to the consumer chain and the EOTS manager, which must never be done in production")
+		cc, em = faults.NewClientController(cc, w.in), faults.NewEOTSManager(em, w.in)
+	}
+
+	if w.recordPath != "" {
+		recorder, err := replay.NewRecorder(cc, w.recordPath, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.Info("recording the calls to the consumer chain", zap.String("path", w.recordPath))
+		cc = recorder
+	}
+
+	return cc, em, nil
+}
+
 // startApp starts the app and the handle of finality providers if needed based on flags.
 func startApp(
 	fpApp *service.FinalityProviderApp,
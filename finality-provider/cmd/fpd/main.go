@@ -36,11 +36,13 @@ func main() {
 	cmd := NewRootCmd()
 	cmd.AddCommand(
 		daemon.CommandInit(), daemon.CommandStart(), daemon.CommandKeys(),
-		daemon.CommandGetDaemonInfo(), daemon.CommandCreateFP(), daemon.CommandLsFP(),
+		daemon.CommandGetDaemonInfo(), daemon.CommandReloadConfig(), daemon.CommandSetLogLevel(),
+		daemon.CommandCreateFP(), daemon.CommandLsFP(),
 		daemon.CommandInfoFP(), daemon.CommandAddFinalitySig(), daemon.CommandUnjailFP(),
-		daemon.CommandEditFinalityDescription(), daemon.CommandCommitPubRand(),
+		daemon.CommandEditFinalityDescription(), daemon.CommandCommitPubRand(), daemon.CommandEvents(),
 		incentivecli.NewWithdrawRewardCmd(), incentivecli.NewSetWithdrawAddressCmd(),
-		version.CommandVersion("fpd"), daemon.CommandUnsafePruneMerkleProof(),
+		version.CommandVersion("fpd"), daemon.CommandUnsafePruneMerkleProof(), daemon.CommandAuthz(), daemon.CommandDB(),
+		daemon.CommandConfig(),
 	)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -1,26 +1,37 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	bbncfg "github.com/babylonlabs-io/babylon/client/config"
 )
 
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultMaxBlockLag         = 5
+)
+
 type BBNConfig struct {
-	Key            string        `long:"key" description:"name of the key to sign transactions with"`
-	ChainID        string        `long:"chain-id" description:"chain id of the chain to connect to"`
-	RPCAddr        string        `long:"rpc-address" description:"address of the rpc server to connect to"`
-	GRPCAddr       string        `long:"grpc-address" description:"address of the grpc server to connect to"`
-	AccountPrefix  string        `long:"acc-prefix" description:"account prefix to use for addresses"`
-	KeyringBackend string        `long:"keyring-type" description:"type of keyring to use"`
-	GasAdjustment  float64       `long:"gas-adjustment" description:"adjustment factor when using gas estimation"`
-	GasPrices      string        `long:"gas-prices" description:"comma separated minimum gas prices to accept for transactions"`
-	KeyDirectory   string        `long:"key-dir" description:"directory to store keys in"`
-	Debug          bool          `long:"debug" description:"flag to print debug output"`
-	Timeout        time.Duration `long:"timeout" description:"client timeout when doing queries"`
-	BlockTimeout   time.Duration `long:"block-timeout" description:"block timeout when waiting for block events"`
-	OutputFormat   string        `long:"output-format" description:"default output when printint responses"`
-	SignModeStr    string        `long:"sign-mode" description:"sign mode to use"`
+	Key                 string        `long:"key" description:"name of the key to sign transactions with"`
+	ChainID             string        `long:"chain-id" description:"chain id of the chain to connect to"`
+	RPCAddr             string        `long:"rpc-address" description:"address of the rpc server to connect to"`
+	BackupRPCAddrs      []string      `long:"backup-rpc-address" description:"address of a backup rpc server to fail over to when the active one is unhealthy; can be specified multiple times, in order of preference"`
+	GRPCAddr            string        `long:"grpc-address" description:"address of the grpc server to connect to"`
+	BackupGRPCAddrs     []string      `long:"backup-grpc-address" description:"address of the grpc server of the backup rpc server at the same position; can be specified multiple times, once per backup rpc server"`
+	AccountPrefix       string        `long:"acc-prefix" description:"account prefix to use for addresses"`
+	KeyringBackend      string        `long:"keyring-type" description:"type of keyring to use"`
+	GasAdjustment       float64       `long:"gas-adjustment" description:"adjustment factor when using gas estimation"`
+	GasPrices           string        `long:"gas-prices" description:"comma separated minimum gas prices to accept for transactions"`
+	KeyDirectory        string        `long:"key-dir" description:"directory to store keys in"`
+	Debug               bool          `long:"debug" description:"flag to print debug output"`
+	Timeout             time.Duration `long:"timeout" description:"client timeout when doing queries"`
+	BlockTimeout        time.Duration `long:"block-timeout" description:"block timeout when waiting for block events"`
+	OutputFormat        string        `long:"output-format" description:"default output when printint responses"`
+	SignModeStr         string        `long:"sign-mode" description:"sign mode to use"`
+	AuthzGranter        string        `long:"authz-granter" description:"bech32 address of the finality provider that granted authz to the signing key; if set, finality votes, public randomness commits and unjail transactions are wrapped in an authz MsgExec signed by the key"`
+	HealthCheckInterval time.Duration `long:"health-check-interval" description:"the interval between health checks of the rpc servers; only used if backup rpc servers are set"`
+	MaxBlockLag         uint64        `long:"max-block-lag" description:"the maximum number of blocks an rpc server can lag behind the highest one before it is considered unhealthy"`
 }
 
 func DefaultBBNConfig() BBNConfig {
@@ -39,10 +50,21 @@ func DefaultBBNConfig() BBNConfig {
 		Timeout:        dc.Timeout,
 		// Setting this to relatively low value, out current babylon client (lens) will
 		// block for this amout of time to wait for transaction inclusion in block
-		BlockTimeout: 1 * time.Minute,
-		OutputFormat: dc.OutputFormat,
-		SignModeStr:  dc.SignModeStr,
+		BlockTimeout:        1 * time.Minute,
+		OutputFormat:        dc.OutputFormat,
+		SignModeStr:         dc.SignModeStr,
+		HealthCheckInterval: defaultHealthCheckInterval,
+		MaxBlockLag:         defaultMaxBlockLag,
+	}
+}
+
+func (cfg *BBNConfig) Validate() error {
+	if len(cfg.BackupGRPCAddrs) > 0 && len(cfg.BackupGRPCAddrs) != len(cfg.BackupRPCAddrs) {
+		return fmt.Errorf("the number of backup grpc addresses %d should match the number of backup rpc addresses %d",
+			len(cfg.BackupGRPCAddrs), len(cfg.BackupRPCAddrs))
 	}
+
+	return nil
 }
 
 func BBNConfigToBabylonConfig(bc *BBNConfig) bbncfg.BabylonConfig {
@@ -50,6 +72,7 @@ func BBNConfigToBabylonConfig(bc *BBNConfig) bbncfg.BabylonConfig {
 		Key:              bc.Key,
 		ChainID:          bc.ChainID,
 		RPCAddr:          bc.RPCAddr,
+		GRPCAddr:         bc.GRPCAddr,
 		AccountPrefix:    bc.AccountPrefix,
 		KeyringBackend:   bc.KeyringBackend,
 		GasAdjustment:    bc.GasAdjustment,
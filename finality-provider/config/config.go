@@ -3,17 +3,22 @@ package config
 import (
 	"fmt"
 	"net"
+	"os"
 	"path/filepath"
 	"strconv"
 	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/jessevdk/go-flags"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/babylonlabs-io/finality-provider/backup"
+	"github.com/babylonlabs-io/finality-provider/envconfig"
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -30,13 +35,16 @@ const (
 	defaultTimestampingDelayBlocks     = 6000 // 100 BTC blocks * 600s / 10s
 	defaultBatchSubmissionSize         = 1000
 	defaultRandomInterval              = 30 * time.Second
-	defaultSubmitRetryInterval         = 1 * time.Second
 	defaultSignatureSubmissionInterval = 1 * time.Second
-	defaultMaxSubmissionRetries        = 20
+	defaultAutoUnjailInterval          = 1 * time.Minute
 	defaultBitcoinNetwork              = "signet"
 	defaultDataDirname                 = "data"
+	defaultBackupDirname               = "backups"
 )
 
+// EnvPrefix is the prefix of the environment variables overriding the config
+const EnvPrefix = "FPD"
+
 var (
 	//   C:\Users\<username>\AppData\Local\ on Windows
 	//   ~/.fpd on Linux
@@ -52,17 +60,26 @@ var (
 // Config is the main config for the fpd cli command
 type Config struct {
 	LogLevel string `long:"loglevel" description:"Logging level for all subsystems" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error" choice:"fatal"`
+	// SubsystemLogLevels override LogLevel for the given subsystems
+	SubsystemLogLevels []string `long:"subsystemloglevel" description:"The logging level of a subsystem in the form subsystem=level, overriding loglevel for the subsystem; can be specified multiple times. The subsystems are poller, submission, randomness, clientcontroller, eotsrpc and store"`
 	// ChainType and ChainID (if any) of the chain config identify a consumer chain
 	ChainType                   string        `long:"chaintype" description:"the type of the consumer chain" choice:"babylon"`
 	NumPubRand                  uint32        `long:"numPubRand" description:"The number of Schnorr public randomness for each commitment"`
 	NumPubRandMax               uint32        `long:"numpubrandmax" description:"The upper bound of the number of Schnorr public randomness for each commitment"`
 	TimestampingDelayBlocks     uint32        `long:"timestampingdelayblocks" description:"The delay, measured in blocks, between a randomness commit submission and the randomness is BTC-timestamped"`
-	MaxSubmissionRetries        uint32        `long:"maxsubmissionretries" description:"The maximum number of retries to submit finality signature or public randomness"`
 	EOTSManagerAddress          string        `long:"eotsmanageraddress" description:"The address of the remote EOTS manager; Empty if the EOTS manager is running locally"`
 	BatchSubmissionSize         uint32        `long:"batchsubmissionsize" description:"The size of a batch in one submission"`
 	RandomnessCommitInterval    time.Duration `long:"randomnesscommitinterval" description:"The interval between each attempt to commit public randomness"`
-	SubmissionRetryInterval     time.Duration `long:"submissionretryinterval" description:"The interval between each attempt to submit finality signature or public randomness after a failure"`
 	SignatureSubmissionInterval time.Duration `long:"signaturesubmissioninterval" description:"The interval between each finality signature(s) submission"`
+	AutoUnjail                  bool          `long:"autounjail" description:"Automatically unjail the finality provider once its jail period elapses"`
+	AutoUnjailInterval          time.Duration `long:"autounjailinterval" description:"The interval between each check of whether the jailed finality provider can be unjailed"`
+	DoppelgangerBlocks          uint32        `long:"doppelgangerblocks" description:"The number of blocks observed on start for votes sent by another signer of the finality provider before signing, which stops the daemon if any; 0 disables the protection"`
+	ShadowMode                  bool          `long:"shadowmode" description:"Run the finality provider without broadcasting any transaction, logging the finality signatures and public randomness commits it would have sent instead"`
+
+	// Deprecated: MaxSubmissionRetries and SubmissionRetryInterval are mapped
+	// onto the vote submission retry policy, use [retry.votesubmission] instead
+	MaxSubmissionRetries    uint32        `long:"maxsubmissionretries" hidden:"true" description:"Deprecated: use retry.votesubmission.attempts, which counts the first attempt, instead"`
+	SubmissionRetryInterval time.Duration `long:"submissionretryinterval" hidden:"true" description:"Deprecated: use retry.votesubmission.basedelay and retry.votesubmission.maxdelay instead"`
 
 	BitcoinNetwork string `long:"bitcoinnetwork" description:"Bitcoin network to run on" choise:"mainnet" choice:"regtest" choice:"testnet" choice:"simnet" choice:"signet"`
 
@@ -70,13 +87,31 @@ type Config struct {
 
 	PollerConfig *ChainPollerConfig `group:"chainpollerconfig" namespace:"chainpollerconfig"`
 
+	SupervisorConfig *SupervisorConfig `group:"supervisor" namespace:"supervisor"`
+
+	RetryConfig *RetryConfig `group:"retry" namespace:"retry"`
+
+	HealthConfig *HealthConfig `group:"health" namespace:"health"`
+
+	LeaseConfig *LeaseConfig `group:"lease" namespace:"lease"`
+
 	DatabaseConfig *DBConfig `group:"dbconfig" namespace:"dbconfig"`
 
+	Log *log.Config `group:"log" namespace:"log"`
+
+	Backup *backup.Config `group:"backup" namespace:"backup"`
+
 	BabylonConfig *BBNConfig `group:"babylon" namespace:"babylon"`
 
 	RPCListener string `long:"rpclistener" description:"the listener for RPC connections, e.g., 127.0.0.1:1234"`
 
+	HTTPListener string `long:"httplistener" description:"the loopback listener for the REST gateway of the RPC server, e.g., 127.0.0.1:1235; the gateway is disabled if empty"`
+
 	Metrics *metrics.Config `group:"metrics" namespace:"metrics"`
+
+	Tracing *tracing.Config `group:"tracing" namespace:"tracing"`
+
+	Notifier *notifier.Config `group:"notifier" namespace:"notifier"`
 }
 
 func DefaultConfigWithHome(homePath string) Config {
@@ -84,25 +119,36 @@ func DefaultConfigWithHome(homePath string) Config {
 	bbnCfg.Key = defaultFinalityProviderKeyName
 	bbnCfg.KeyDirectory = homePath
 	pollerCfg := DefaultChainPollerConfig()
+	supervisorCfg := DefaultSupervisorConfig()
+	retryCfg := DefaultRetryConfig()
+	healthCfg := DefaultHealthConfig()
+	leaseCfg := DefaultLeaseConfig()
 	cfg := Config{
 		ChainType:                   defaultChainType,
 		LogLevel:                    defaultLogLevel.String(),
 		DatabaseConfig:              DefaultDBConfigWithHomePath(homePath),
+		Log:                         log.DefaultConfig(),
+		Backup:                      backup.DefaultConfig(BackupDir(homePath)),
 		BabylonConfig:               &bbnCfg,
 		PollerConfig:                &pollerCfg,
+		SupervisorConfig:            &supervisorCfg,
+		RetryConfig:                 &retryCfg,
+		HealthConfig:                &healthCfg,
+		LeaseConfig:                 &leaseCfg,
 		NumPubRand:                  defaultNumPubRand,
 		NumPubRandMax:               defaultNumPubRandMax,
 		TimestampingDelayBlocks:     defaultTimestampingDelayBlocks,
 		BatchSubmissionSize:         defaultBatchSubmissionSize,
 		RandomnessCommitInterval:    defaultRandomInterval,
-		SubmissionRetryInterval:     defaultSubmitRetryInterval,
 		SignatureSubmissionInterval: defaultSignatureSubmissionInterval,
-		MaxSubmissionRetries:        defaultMaxSubmissionRetries,
+		AutoUnjailInterval:          defaultAutoUnjailInterval,
 		BitcoinNetwork:              defaultBitcoinNetwork,
 		BTCNetParams:                defaultBTCNetParams,
 		EOTSManagerAddress:          defaultEOTSManagerAddress,
 		RPCListener:                 DefaultRPCListener,
 		Metrics:                     metrics.DefaultFpConfig(),
+		Tracing:                     tracing.DefaultConfig(),
+		Notifier:                    notifier.DefaultConfig(),
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -132,6 +178,10 @@ func DataDir(homePath string) string {
 	return filepath.Join(homePath, defaultDataDirname)
 }
 
+func BackupDir(homePath string) string {
+	return filepath.Join(homePath, defaultBackupDirname)
+}
+
 // LoadConfig initializes and parses the config using a config file and command
 // line options.
 //
@@ -139,30 +189,79 @@ func DataDir(homePath string) string {
 //  1. Start with a default config with sane settings
 //  2. Pre-parse the command line to check for an alternative config file
 //  3. Load configuration file overwriting defaults with any specified options
-//  4. Parse CLI options and overwrite/add any specified options
+//  4. Apply the overrides of the FPD_* environment variables
+//  5. Parse CLI options and overwrite/add any specified options
 func LoadConfig(homePath string) (*Config, error) {
+	cfg, _, err := LoadConfigWithEnv(homePath, os.LookupEnv)
+
+	return cfg, err
+}
+
+// LoadConfigWithEnv loads the config as LoadConfig, with the overrides of the
+// environment variables looked up by lookupEnv, none if nil, and returns the
+// names of the applied environment variables
+func LoadConfigWithEnv(homePath string, lookupEnv envconfig.LookupEnvFunc) (*Config, []string, error) {
 	// The home directory is required to have a configuration file with a specific name
 	// under it.
 	cfgFile := CfgFile(homePath)
 	if !util.FileExists(cfgFile) {
-		return nil, fmt.Errorf("specified config file does "+
+		return nil, nil, fmt.Errorf("specified config file does "+
 			"not exist in %s", cfgFile)
 	}
 
-	// Next, load any additional configuration options from the file.
+	// Next, load any additional configuration options from the file and the
+	// environment.
 	var cfg Config
-	fileParser := flags.NewParser(&cfg, flags.Default)
-	err := flags.NewIniParser(fileParser).ParseFile(cfgFile)
+	applied, err := envconfig.Load(&cfg, cfgFile, EnvPrefix, lookupEnv)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Make sure everything we just loaded makes sense.
 	if err := cfg.Validate(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &cfg, nil
+	return &cfg, applied, nil
+}
+
+// LogLevels returns the logging level of the root logger and the ones of the
+// subsystems set apart from it
+func (cfg *Config) LogLevels() (zapcore.Level, map[string]zapcore.Level, error) {
+	level, err := log.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return zapcore.InvalidLevel, nil, err
+	}
+
+	subsystemLevels, err := log.ParseSubsystemLevels(cfg.SubsystemLogLevels)
+	if err != nil {
+		return zapcore.InvalidLevel, nil, err
+	}
+
+	return level, subsystemLevels, nil
+}
+
+// applyDeprecatedRetryOptions maps the deprecated MaxSubmissionRetries and
+// SubmissionRetryInterval options onto the vote submission retry policy,
+// unless the policy is set in the [retry.votesubmission] section
+func (cfg *Config) applyDeprecatedRetryOptions() {
+	if cfg.MaxSubmissionRetries == 0 && cfg.SubmissionRetryInterval == 0 {
+		return
+	}
+
+	if p := cfg.RetryConfig.VoteSubmission; p != nil && *p != (RetryPolicy{}) {
+		return
+	}
+
+	policy := *DefaultRetryConfig().VoteSubmission
+	if cfg.MaxSubmissionRetries > 0 {
+		policy.Attempts = cfg.MaxSubmissionRetries + 1
+	}
+	if cfg.SubmissionRetryInterval > 0 {
+		policy.BaseDelay = cfg.SubmissionRetryInterval
+		policy.MaxDelay = cfg.SubmissionRetryInterval
+	}
+	cfg.RetryConfig.VoteSubmission = &policy
 }
 
 // Validate checks the given configuration to be sane. This makes sure no
@@ -186,6 +285,85 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid RPC listener address %s, %w", cfg.RPCListener, err)
 	}
 
+	if cfg.HTTPListener != "" {
+		if _, err := net.ResolveTCPAddr("tcp", cfg.HTTPListener); err != nil {
+			return fmt.Errorf("invalid HTTP listener address %s, %w", cfg.HTTPListener, err)
+		}
+	}
+
+	if cfg.BabylonConfig == nil {
+		return fmt.Errorf("empty babylon config")
+	}
+
+	if err := cfg.BabylonConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid babylon config: %w", err)
+	}
+
+	if cfg.AutoUnjail && cfg.AutoUnjailInterval <= 0 {
+		return fmt.Errorf("auto unjail interval should be positive")
+	}
+
+	if _, err := log.ParseSubsystemLevels(cfg.SubsystemLogLevels); err != nil {
+		return err
+	}
+
+	if cfg.Log == nil {
+		return fmt.Errorf("empty log config")
+	}
+
+	if err := cfg.Log.Validate(); err != nil {
+		return fmt.Errorf("invalid log config: %w", err)
+	}
+
+	if cfg.SupervisorConfig == nil {
+		return fmt.Errorf("empty supervisor config")
+	}
+
+	if err := cfg.SupervisorConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid supervisor config: %w", err)
+	}
+
+	if cfg.RetryConfig == nil {
+		cfg.RetryConfig = &RetryConfig{}
+	}
+
+	cfg.applyDeprecatedRetryOptions()
+	cfg.RetryConfig.fillDefaults()
+
+	if err := cfg.RetryConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid retry config: %w", err)
+	}
+
+	if cfg.HealthConfig == nil {
+		return fmt.Errorf("empty health config")
+	}
+
+	if err := cfg.HealthConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid health config: %w", err)
+	}
+
+	if cfg.LeaseConfig == nil {
+		return fmt.Errorf("empty lease config")
+	}
+
+	if err := cfg.LeaseConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid lease config: %w", err)
+	}
+
+	// the daemons sharing the lease vote in turn, which the doppelganger
+	// protection would take for another signer
+	if cfg.LeaseConfig.Enabled() && cfg.DoppelgangerBlocks > 0 {
+		return fmt.Errorf("the doppelganger protection cannot be enabled along with the lease")
+	}
+
+	if cfg.Backup == nil {
+		return fmt.Errorf("empty backup config")
+	}
+
+	if err := cfg.Backup.Validate(); err != nil {
+		return fmt.Errorf("invalid backup config: %w", err)
+	}
+
 	if cfg.Metrics == nil {
 		return fmt.Errorf("empty metrics config")
 	}
@@ -194,6 +372,22 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid metrics config")
 	}
 
+	if cfg.Tracing == nil {
+		return fmt.Errorf("empty tracing config")
+	}
+
+	if err := cfg.Tracing.Validate(); err != nil {
+		return fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	if cfg.Notifier == nil {
+		return fmt.Errorf("empty notifier config")
+	}
+
+	if err := cfg.Notifier.Validate(); err != nil {
+		return fmt.Errorf("invalid notifier config: %w", err)
+	}
+
 	// All good, return the sanitized result.
 	return nil
 }
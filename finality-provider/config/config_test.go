@@ -0,0 +1,50 @@
+package config_test
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// TestLoadLegacyConfig tests that a config file written before the retry
+// policies is still loaded, with the deprecated submission retry options
+// mapped onto the vote submission retry policy
+func TestLoadLegacyConfig(t *testing.T) {
+	t.Parallel()
+	homePath := t.TempDir()
+	defaultCfg := fpcfg.DefaultConfigWithHome(homePath)
+	err := flags.NewIniParser(flags.NewParser(&defaultCfg, flags.Default)).
+		WriteFile(fpcfg.CfgFile(homePath), flags.IniIncludeDefaults)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(fpcfg.CfgFile(homePath))
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "MaxSubmissionRetries")
+
+	// drop the retry sections and add the deprecated options
+	legacy := regexp.MustCompile(`(?s)\[retry\.[a-z]+\][^\[]*`).ReplaceAllString(string(content), "")
+	legacy = strings.Replace(legacy, "[Application Options]\n",
+		"[Application Options]\nMaxSubmissionRetries = 10\nSubmissionRetryInterval = 3s\n", 1)
+	require.NoError(t, os.WriteFile(fpcfg.CfgFile(homePath), []byte(legacy), 0600))
+
+	cfg, err := fpcfg.LoadConfig(homePath)
+	require.NoError(t, err)
+	require.Equal(t, &fpcfg.RetryPolicy{
+		Attempts:      11,
+		BaseDelay:     3 * time.Second,
+		MaxDelay:      3 * time.Second,
+		BackoffFactor: 1,
+	}, cfg.RetryConfig.VoteSubmission)
+
+	defaultRetryCfg := fpcfg.DefaultRetryConfig()
+	require.Equal(t, defaultRetryCfg.BlockPolling, cfg.RetryConfig.BlockPolling)
+	require.Equal(t, defaultRetryCfg.VotingPower, cfg.RetryConfig.VotingPower)
+	require.Equal(t, defaultRetryCfg.PubRandCommit, cfg.RetryConfig.PubRandCommit)
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	defaultHealthCheckTimeout = 5 * time.Second
+	defaultMaxBlockAge        = 2 * time.Minute
+	defaultMaxPollerLag       = uint64(100)
+	defaultMinPubRandRunway   = uint64(1000)
+)
+
+// HealthConfig defines the thresholds of the health checks served at
+// /healthz, /readyz and through the gRPC health service
+type HealthConfig struct {
+	CheckTimeout     time.Duration `long:"checktimeout" description:"The timeout of each health check; 0 uses the default timeout"`
+	MaxBlockAge      time.Duration `long:"maxblockage" description:"The maximum age of the latest block of the Babylon node for the node to be considered in sync; 0 disables the check of the block age"`
+	MaxPollerLag     uint64        `long:"maxpollerlag" description:"The maximum number of blocks the chain poller can lag behind the tip for the daemon to be ready; 0 disables the check"`
+	MinPubRandRunway uint64        `long:"minpubrandrunway" description:"The minimum number of blocks beyond the tip covered by the committed public randomness for the daemon to be ready; 0 disables the check"`
+}
+
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{
+		CheckTimeout:     defaultHealthCheckTimeout,
+		MaxBlockAge:      defaultMaxBlockAge,
+		MaxPollerLag:     defaultMaxPollerLag,
+		MinPubRandRunway: defaultMinPubRandRunway,
+	}
+}
+
+func (cfg *HealthConfig) Validate() error {
+	if cfg.CheckTimeout < 0 {
+		return fmt.Errorf("health check timeout should not be negative")
+	}
+
+	if cfg.MaxBlockAge < 0 {
+		return fmt.Errorf("max block age should not be negative")
+	}
+
+	return nil
+}
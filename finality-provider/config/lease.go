@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// LeaseBackendFile stores the lease in a lock-protected file on the host
+	// of the daemons
+	LeaseBackendFile = "file"
+)
+
+var (
+	defaultLeaseTTL           = 30 * time.Second
+	defaultLeaseRenewInterval = 5 * time.Second
+)
+
+// LeaseConfig defines the lease through which only one of the finality
+// provider daemons sharing it votes while the others stand by
+type LeaseConfig struct {
+	Backend       string        `long:"backend" description:"The backend of the lease, only file is supported, which elects a holder among the daemons on the same host; the lease is disabled if empty"`
+	HolderID      string        `long:"holderid" description:"The unique ID of the daemon among the ones sharing the lease; the hostname is used if empty"`
+	TTL           time.Duration `long:"ttl" description:"The duration of the lease after which a standby daemon takes over if it is not renewed"`
+	RenewInterval time.Duration `long:"renewinterval" description:"The interval between each attempt to acquire or renew the lease"`
+	FilePath      string        `long:"filepath" description:"The path of the lease file of the file backend"`
+}
+
+func DefaultLeaseConfig() LeaseConfig {
+	return LeaseConfig{
+		TTL:           defaultLeaseTTL,
+		RenewInterval: defaultLeaseRenewInterval,
+	}
+}
+
+// Enabled returns true if the lease is configured
+func (cfg *LeaseConfig) Enabled() bool {
+	return cfg.Backend != ""
+}
+
+func (cfg *LeaseConfig) Validate() error {
+	switch cfg.Backend {
+	case "":
+		return nil
+	case LeaseBackendFile:
+		if cfg.FilePath == "" {
+			return fmt.Errorf("the lease file path should be specified for the file backend")
+		}
+	default:
+		return fmt.Errorf("invalid lease backend %s, should be %s", cfg.Backend, LeaseBackendFile)
+	}
+
+	if cfg.TTL <= 0 {
+		return fmt.Errorf("lease TTL should be positive")
+	}
+
+	if cfg.RenewInterval <= 0 || cfg.RenewInterval >= cfg.TTL {
+		return fmt.Errorf("lease renew interval should be positive and less than the lease TTL")
+	}
+
+	return nil
+}
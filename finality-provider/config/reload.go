@@ -0,0 +1,88 @@
+package config
+
+import (
+	"reflect"
+)
+
+// reloadableFields are the fields of the config, by their path, that are
+// applied to the running daemon without a restart
+var reloadableFields = map[string]bool{
+	"LogLevel":                    true,
+	"SubsystemLogLevels":          true,
+	"NumPubRand":                  true,
+	"BatchSubmissionSize":         true,
+	"SignatureSubmissionInterval": true,
+	"PollerConfig.PollInterval":   true,
+}
+
+// ReloadResult is the outcome of reloading the config
+type ReloadResult struct {
+	// Applied are the changed fields that are applied without a restart
+	Applied []string
+	// RestartRequired are the changed fields that are only applied once the
+	// daemon is restarted
+	RestartRequired []string
+}
+
+// Reload returns a copy of the config with the reloadable fields set to the
+// values of newCfg, which should be validated beforehand, along with the
+// fields changed by newCfg
+func (cfg *Config) Reload(newCfg *Config) (*Config, *ReloadResult) {
+	res := &ReloadResult{}
+	for _, field := range changedFields("", reflect.ValueOf(*cfg), reflect.ValueOf(*newCfg)) {
+		if reloadableFields[field] {
+			res.Applied = append(res.Applied, field)
+		} else {
+			res.RestartRequired = append(res.RestartRequired, field)
+		}
+	}
+
+	reloaded := *cfg
+	pollerCfg := *cfg.PollerConfig
+	reloaded.PollerConfig = &pollerCfg
+
+	reloaded.LogLevel = newCfg.LogLevel
+	reloaded.SubsystemLogLevels = newCfg.SubsystemLogLevels
+	reloaded.NumPubRand = newCfg.NumPubRand
+	reloaded.BatchSubmissionSize = newCfg.BatchSubmissionSize
+	reloaded.SignatureSubmissionInterval = newCfg.SignatureSubmissionInterval
+	reloaded.PollerConfig.PollInterval = newCfg.PollerConfig.PollInterval
+
+	return &reloaded, res
+}
+
+// changedFields returns the paths of the fields that differ between the given
+// structs, descending into the nested config groups
+func changedFields(prefix string, oldVal, newVal reflect.Value) []string {
+	var fields []string
+	for i := 0; i < oldVal.NumField(); i++ {
+		field := oldVal.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		// BTCNetParams is derived from BitcoinNetwork
+		if prefix == "" && field.Name == "BTCNetParams" {
+			continue
+		}
+
+		path := prefix + field.Name
+		oldField, newField := oldVal.Field(i), newVal.Field(i)
+		if oldField.Kind() == reflect.Ptr && newField.Kind() == reflect.Ptr &&
+			oldField.Type().Elem().Kind() == reflect.Struct &&
+			!oldField.IsNil() && !newField.IsNil() {
+			oldField, newField = oldField.Elem(), newField.Elem()
+		}
+
+		if oldField.Kind() == reflect.Struct && field.Tag.Get("group") != "" {
+			fields = append(fields, changedFields(path+".", oldField, newField)...)
+
+			continue
+		}
+
+		if !reflect.DeepEqual(oldField.Interface(), newField.Interface()) {
+			fields = append(fields, path)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,145 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var (
+	defaultQueryRetryAttempts      = uint32(5)
+	defaultQueryRetryBaseDelay     = 400 * time.Millisecond
+	defaultQueryRetryMaxDelay      = 10 * time.Second
+	defaultQueryRetryBackoffFactor = 2.0
+	defaultQueryRetryJitter        = 0.25
+
+	defaultVoteSubmissionAttempts  = uint32(21)
+	defaultVoteSubmissionBaseDelay = 1 * time.Second
+	defaultVoteSubmissionMaxDelay  = 1 * time.Second
+)
+
+// RetryPolicy defines how an operation against the consumer chain is retried
+// after a failure
+type RetryPolicy struct {
+	Attempts      uint32        `long:"attempts" description:"The maximum number of attempts of the operation, including the first one"`
+	BaseDelay     time.Duration `long:"basedelay" description:"The delay before the first retry"`
+	MaxDelay      time.Duration `long:"maxdelay" description:"The upper bound of the delay before a retry"`
+	BackoffFactor float64       `long:"backofffactor" description:"The factor by which the delay is multiplied after each retry; 1 keeps the delay fixed"`
+	Jitter        float64       `long:"jitter" description:"The fraction of the delay, between 0 and 1, by which the delay is randomly lengthened or shortened"`
+}
+
+// RetryConfig defines the retry policies of each class of operations
+type RetryConfig struct {
+	BlockPolling   *RetryPolicy `group:"retry.blockpolling" namespace:"blockpolling"`
+	VotingPower    *RetryPolicy `group:"retry.votingpower" namespace:"votingpower"`
+	VoteSubmission *RetryPolicy `group:"retry.votesubmission" namespace:"votesubmission"`
+	PubRandCommit  *RetryPolicy `group:"retry.pubrandcommit" namespace:"pubrandcommit"`
+}
+
+func defaultQueryRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		Attempts:      defaultQueryRetryAttempts,
+		BaseDelay:     defaultQueryRetryBaseDelay,
+		MaxDelay:      defaultQueryRetryMaxDelay,
+		BackoffFactor: defaultQueryRetryBackoffFactor,
+		Jitter:        defaultQueryRetryJitter,
+	}
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		BlockPolling: defaultQueryRetryPolicy(),
+		VotingPower:  defaultQueryRetryPolicy(),
+		VoteSubmission: &RetryPolicy{
+			Attempts:      defaultVoteSubmissionAttempts,
+			BaseDelay:     defaultVoteSubmissionBaseDelay,
+			MaxDelay:      defaultVoteSubmissionMaxDelay,
+			BackoffFactor: 1,
+		},
+		PubRandCommit: defaultQueryRetryPolicy(),
+	}
+}
+
+// fillDefaults sets the missing or zero policies, e.g., the ones of a config
+// file written before the retry policies, to their defaults
+func (cfg *RetryConfig) fillDefaults() {
+	defaults := DefaultRetryConfig()
+	policies := []struct {
+		policy        **RetryPolicy
+		defaultPolicy *RetryPolicy
+	}{
+		{&cfg.BlockPolling, defaults.BlockPolling},
+		{&cfg.VotingPower, defaults.VotingPower},
+		{&cfg.VoteSubmission, defaults.VoteSubmission},
+		{&cfg.PubRandCommit, defaults.PubRandCommit},
+	}
+
+	for _, p := range policies {
+		if *p.policy == nil || **p.policy == (RetryPolicy{}) {
+			*p.policy = p.defaultPolicy
+		}
+	}
+}
+
+func (cfg *RetryConfig) Validate() error {
+	policies := []struct {
+		name   string
+		policy *RetryPolicy
+	}{
+		{"block polling", cfg.BlockPolling},
+		{"voting power", cfg.VotingPower},
+		{"vote submission", cfg.VoteSubmission},
+		{"pubrand commit", cfg.PubRandCommit},
+	}
+
+	for _, p := range policies {
+		if p.policy == nil {
+			return fmt.Errorf("empty %s retry policy", p.name)
+		}
+		if err := p.policy.Validate(); err != nil {
+			return fmt.Errorf("invalid %s retry policy: %w", p.name, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *RetryPolicy) Validate() error {
+	if p.Attempts == 0 {
+		return fmt.Errorf("attempts should be positive")
+	}
+
+	if p.BaseDelay < 0 {
+		return fmt.Errorf("base delay should not be negative")
+	}
+
+	if p.MaxDelay < p.BaseDelay {
+		return fmt.Errorf("max delay %v should not be lower than the base delay %v", p.MaxDelay, p.BaseDelay)
+	}
+
+	if p.BackoffFactor < 1 {
+		return fmt.Errorf("backoff factor %v should be at least 1", p.BackoffFactor)
+	}
+
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("jitter %v should be between 0 and 1", p.Jitter)
+	}
+
+	return nil
+}
+
+// Delay returns the delay before the given retry, counted from 0, which is
+// the base delay multiplied by the backoff factor once per previous retry,
+// capped at the max delay and randomized by the jitter
+func (p *RetryPolicy) Delay(retry uint) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(p.BackoffFactor, float64(retry))
+	delay = math.Min(delay, float64(p.MaxDelay))
+
+	if p.Jitter > 0 {
+		// #nosec G404 -- the jitter does not need to be cryptographically random
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
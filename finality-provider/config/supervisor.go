@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	defaultMaxRestarts         = uint32(5)
+	defaultInitialBackoff      = 5 * time.Second
+	defaultMaxBackoff          = 5 * time.Minute
+	defaultBudgetResetInterval = 1 * time.Hour
+)
+
+// SupervisorConfig defines how the finality provider instance is restarted
+// after it runs into a transient critical error
+type SupervisorConfig struct {
+	MaxRestarts         uint32        `long:"maxrestarts" description:"The maximum number of consecutive restarts of the finality provider instance after transient critical errors before the daemon exits; 0 disables restarts"`
+	InitialBackoff      time.Duration `long:"initialbackoff" description:"The delay before the first restart, which is doubled for each subsequent restart"`
+	MaxBackoff          time.Duration `long:"maxbackoff" description:"The upper bound of the delay before a restart"`
+	BudgetResetInterval time.Duration `long:"budgetresetinterval" description:"The duration the instance should run after the last restart for the restart budget to be replenished"`
+}
+
+func DefaultSupervisorConfig() SupervisorConfig {
+	return SupervisorConfig{
+		MaxRestarts:         defaultMaxRestarts,
+		InitialBackoff:      defaultInitialBackoff,
+		MaxBackoff:          defaultMaxBackoff,
+		BudgetResetInterval: defaultBudgetResetInterval,
+	}
+}
+
+func (cfg *SupervisorConfig) Validate() error {
+	if cfg.MaxRestarts == 0 {
+		return nil
+	}
+
+	if cfg.InitialBackoff <= 0 {
+		return fmt.Errorf("initial backoff should be positive")
+	}
+
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		return fmt.Errorf("max backoff %v should not be lower than the initial backoff %v", cfg.MaxBackoff, cfg.InitialBackoff)
+	}
+
+	if cfg.BudgetResetInterval <= 0 {
+		return fmt.Errorf("budget reset interval should be positive")
+	}
+
+	return nil
+}
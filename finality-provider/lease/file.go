@@ -0,0 +1,134 @@
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// lockRetryInterval is the interval between each attempt to lock the lease
+// file while it is locked by another daemon
+const lockRetryInterval = 10 * time.Millisecond
+
+// FileLease is a lease stored in a file, whose updates are serialized by an
+// exclusive lock on the file. It elects a single holder among the daemons
+// running on the same host, as the file locks are not reliable across hosts
+type FileLease struct {
+	path string
+}
+
+var _ Lease = (*FileLease)(nil)
+
+// NewFileLease returns the lease stored in the file at the given path, which
+// is created upon the first acquisition
+func NewFileLease(path string) *FileLease {
+	return &FileLease{path: path}
+}
+
+func (l *FileLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	acquired := false
+	err := l.update(ctx, func(cur *Record) (*Record, error) {
+		next := acquire(cur, holder, time.Now(), ttl)
+		acquired = next != nil
+
+		return next, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return acquired, nil
+}
+
+func (l *FileLease) Release(ctx context.Context, holder string) error {
+	return l.update(ctx, func(cur *Record) (*Record, error) {
+		if !cur.IsHeldBy(holder, time.Now()) {
+			return nil, nil
+		}
+
+		return &Record{}, nil
+	})
+}
+
+func (l *FileLease) Record(ctx context.Context) (*Record, error) {
+	var r *Record
+	err := l.update(ctx, func(cur *Record) (*Record, error) {
+		r = cur
+
+		return nil, nil
+	})
+
+	return r, err
+}
+
+// update locks the lease file and replaces the record with the one returned
+// by f unless it is nil. It gives up waiting for the lock once ctx is done
+func (l *FileLease) update(ctx context.Context, f func(cur *Record) (*Record, error)) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open the lease file: %w", err)
+	}
+	defer file.Close()
+
+	if err := lockFileWithContext(ctx, file); err != nil {
+		return fmt.Errorf("failed to lock the lease file: %w", err)
+	}
+	defer func() {
+		if unlockErr := unlockFile(file); unlockErr != nil && err == nil {
+			err = fmt.Errorf("failed to unlock the lease file: %w", unlockErr)
+		}
+	}()
+
+	bz, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("failed to read the lease file: %w", err)
+	}
+
+	cur, err := decodeRecord(bz)
+	if err != nil {
+		return fmt.Errorf("failed to decode the lease record: %w", err)
+	}
+
+	next, err := f(cur)
+	if err != nil || next == nil {
+		return err
+	}
+
+	bz, err = json.Marshal(next)
+	if err != nil {
+		return err
+	}
+
+	if err := file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to write the lease file: %w", err)
+	}
+	if _, err := file.WriteAt(bz, 0); err != nil {
+		return fmt.Errorf("failed to write the lease file: %w", err)
+	}
+
+	return file.Sync()
+}
+
+// lockFileWithContext retries taking the exclusive lock on the file until it
+// succeeds or ctx is done
+func lockFileWithContext(ctx context.Context, f *os.File) error {
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil || locked {
+			return err
+		}
+
+		select {
+		case <-time.After(lockRetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
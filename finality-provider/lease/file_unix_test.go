@@ -0,0 +1,40 @@
+//go:build !windows
+
+package lease_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/lease"
+)
+
+func TestFileLeaseLockTimeout(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "fpd.lease")
+	l := lease.NewFileLease(path)
+
+	// another process holds the lock on the lease file
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, unix.Flock(int(f.Fd()), unix.LOCK_EX))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, "a", time.Second)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the lease is acquired once the lock is released
+	require.NoError(t, unix.Flock(int(f.Fd()), unix.LOCK_UN))
+	acquired, err := l.Acquire(context.Background(), "a", time.Second)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
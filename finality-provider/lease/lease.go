@@ -0,0 +1,61 @@
+package lease
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Lease elects a single holder among the finality provider daemons sharing
+// it. The lease is held until it expires unless it is renewed by its holder
+type Lease interface {
+	// Acquire acquires the lease for the holder, or renews it if the holder
+	// already holds it, for the given duration. It returns false if the lease
+	// is held by another holder and has not expired
+	Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+	// Release releases the lease if it is held by the holder so that another
+	// holder can acquire it without waiting for it to expire
+	Release(ctx context.Context, holder string) error
+	// Record returns the current record of the lease, which is nil if the
+	// lease has never been acquired
+	Record(ctx context.Context) (*Record, error)
+}
+
+// Record is the persisted state of a lease
+type Record struct {
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IsHeldBy returns true if the lease is held by the holder at the given time
+func (r *Record) IsHeldBy(holder string, now time.Time) bool {
+	return r != nil && r.Holder == holder && now.Before(r.ExpiresAt)
+}
+
+// IsExpired returns true if the lease is not held by anyone at the given time
+func (r *Record) IsExpired(now time.Time) bool {
+	return r == nil || r.Holder == "" || !now.Before(r.ExpiresAt)
+}
+
+// acquire returns the record after the holder acquires the lease, or nil
+// if the lease is held by another holder
+func acquire(cur *Record, holder string, now time.Time, ttl time.Duration) *Record {
+	if !cur.IsExpired(now) && cur.Holder != holder {
+		return nil
+	}
+
+	return &Record{Holder: holder, ExpiresAt: now.Add(ttl)}
+}
+
+func decodeRecord(bz []byte) (*Record, error) {
+	if len(bz) == 0 {
+		return nil, nil
+	}
+
+	var r Record
+	if err := json.Unmarshal(bz, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,70 @@
+package lease_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/lease"
+)
+
+func TestLease(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	l := lease.NewFileLease(filepath.Join(t.TempDir(), "fpd.lease"))
+	ttl := 200 * time.Millisecond
+
+	r, err := l.Record(ctx)
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	// the first holder acquires the lease
+	acquired, err := l.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// the other holder cannot acquire it until it expires
+	acquired, err = l.Acquire(ctx, "b", ttl)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	// the holder renews the lease
+	acquired, err = l.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	r, err = l.Record(ctx)
+	require.NoError(t, err)
+	require.True(t, r.IsHeldBy("a", time.Now()))
+
+	// the other holder takes over once the lease expires
+	time.Sleep(ttl)
+	acquired, err = l.Acquire(ctx, "b", ttl)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// releasing the lease of another holder has no effect
+	require.NoError(t, l.Release(ctx, "a"))
+	acquired, err = l.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	// the released lease can be acquired right away
+	require.NoError(t, l.Release(ctx, "b"))
+	acquired, err = l.Acquire(ctx, "a", ttl)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// the requests with a cancelled context are not served
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = l.Acquire(cancelledCtx, "a", ttl)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, l.Release(cancelledCtx, "a"), context.Canceled)
+	r, err = l.Record(ctx)
+	require.NoError(t, err)
+	require.True(t, r.IsHeldBy("a", time.Now()))
+}
@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMinSeverity        = "warning"
+	defaultDedupWindow        = 10 * time.Minute
+	defaultWebhookTimeout     = 10 * time.Second
+	defaultWebhookMaxAttempts = 3
+	defaultWebhookRetryDelay  = 2 * time.Second
+)
+
+// Config defines the notifications sent upon the events of the finality
+// provider and the sinks they are sent to
+type Config struct {
+	MinSeverity        string        `long:"minseverity" description:"The minimum severity of the notifications to be sent" choice:"info" choice:"warning" choice:"critical"`
+	Severities         []string      `long:"severity" description:"Override the severity of a kind of notification in the form kind=severity, where severity can be none to mute it; can be specified multiple times"`
+	DedupWindow        time.Duration `long:"dedupwindow" description:"The period during which a kind of notification is sent only once per finality provider; 0 disables de-duplication"`
+	WebhookURL         string        `long:"webhookurl" description:"The URL the notifications are POSTed to as JSON; the webhook sink is disabled if empty" secret:"true"`
+	WebhookSecret      string        `long:"webhooksecret" description:"The secret used to sign the webhook payloads with HMAC-SHA256, carried in the X-Fpd-Signature header" secret:"true"`
+	WebhookTimeout     time.Duration `long:"webhooktimeout" description:"The timeout of each webhook request"`
+	WebhookMaxAttempts uint          `long:"webhookmaxattempts" description:"The maximum number of attempts to deliver a notification to the webhook"`
+	WebhookRetryDelay  time.Duration `long:"webhookretrydelay" description:"The delay between the attempts to deliver a notification to the webhook"`
+	FilePath           string        `long:"filepath" description:"The file the notifications are appended to as JSON lines; the file sink is disabled if empty"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		MinSeverity:        defaultMinSeverity,
+		DedupWindow:        defaultDedupWindow,
+		WebhookTimeout:     defaultWebhookTimeout,
+		WebhookMaxAttempts: defaultWebhookMaxAttempts,
+		WebhookRetryDelay:  defaultWebhookRetryDelay,
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.MinSeverity != "" {
+		if _, err := ParseSeverity(cfg.MinSeverity); err != nil {
+			return fmt.Errorf("invalid min severity: %w", err)
+		}
+	}
+
+	if _, err := parseSeverityOverrides(cfg.Severities); err != nil {
+		return err
+	}
+
+	if cfg.DedupWindow < 0 {
+		return fmt.Errorf("dedup window should not be negative")
+	}
+
+	if cfg.WebhookURL != "" {
+		u, err := url.Parse(cfg.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook url: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("invalid webhook url scheme %q", u.Scheme)
+		}
+		if cfg.WebhookTimeout <= 0 {
+			return fmt.Errorf("webhook timeout should be positive")
+		}
+		if cfg.WebhookRetryDelay < 0 {
+			return fmt.Errorf("webhook retry delay should not be negative")
+		}
+	}
+
+	return nil
+}
+
+// parseSeverityOverrides parses the overrides in the form kind=severity
+func parseSeverityOverrides(overrides []string) (map[Kind]Severity, error) {
+	severities := make(map[Kind]Severity, len(overrides))
+	for _, o := range overrides {
+		kindStr, severityStr, ok := strings.Cut(o, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid severity override %q, expected kind=severity", o)
+		}
+
+		kind := Kind(strings.TrimSpace(kindStr))
+		if _, ok := defaultSeverities[kind]; !ok {
+			return nil, fmt.Errorf("unknown notification kind %q", kind)
+		}
+
+		severity, err := ParseSeverity(strings.TrimSpace(severityStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid severity override %q: %w", o, err)
+		}
+		severities[kind] = severity
+	}
+
+	return severities, nil
+}
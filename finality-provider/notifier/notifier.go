@@ -0,0 +1,298 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// notificationQueueSize is the number of notifications waiting to be
+// delivered before new ones are dropped
+const notificationQueueSize = 100
+
+type Severity uint8
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+	// SeverityNone mutes the notification
+	SeverityNone
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityCritical:
+		return "critical"
+	case SeverityNone:
+		return "none"
+	default:
+		return fmt.Sprintf("unknown(%d)", s)
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+
+	return nil
+}
+
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "info":
+		return SeverityInfo, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "critical":
+		return SeverityCritical, nil
+	case "none":
+		return SeverityNone, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q", s)
+	}
+}
+
+// Kind is the kind of event a notification is sent for
+type Kind string
+
+const (
+	KindStatusChanged          Kind = "status_changed"
+	KindJailed                 Kind = "jailed"
+	KindSlashed                Kind = "slashed"
+	KindVoteFailed             Kind = "vote_failed"
+	KindRandomnessCommitFailed Kind = "randomness_commit_failed"
+	KindCriticalError          Kind = "critical_error"
+)
+
+var defaultSeverities = map[Kind]Severity{
+	KindStatusChanged:          SeverityInfo,
+	KindJailed:                 SeverityCritical,
+	KindSlashed:                SeverityCritical,
+	KindVoteFailed:             SeverityWarning,
+	KindRandomnessCommitFailed: SeverityWarning,
+	KindCriticalError:          SeverityCritical,
+}
+
+type Notification struct {
+	Kind       Kind      `json:"kind"`
+	Severity   Severity  `json:"severity"`
+	FpBtcPkHex string    `json:"fp_btc_pk_hex"`
+	Message    string    `json:"message"`
+	Time       time.Time `json:"time"`
+}
+
+// Sink delivers the notifications to an external system
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, n *Notification) error
+}
+
+// Notifier filters the notifications by severity, de-duplicates them and
+// delivers them to the sinks in the background
+type Notifier struct {
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+	quit      chan struct{}
+
+	minSeverity Severity
+	severities  map[Kind]Severity
+	dedupWindow time.Duration
+	sinks       []Sink
+	logger      *zap.Logger
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+	queue    chan *Notification
+}
+
+// New creates a notifier with the sinks enabled in the config. The notifier
+// does nothing if no sink is enabled
+func New(cfg *Config, logger *zap.Logger) (*Notifier, error) {
+	var sinks []Sink
+	if cfg.WebhookURL != "" {
+		sinks = append(sinks, NewWebhookSink(cfg.WebhookURL, cfg.WebhookSecret,
+			cfg.WebhookTimeout, cfg.WebhookMaxAttempts, cfg.WebhookRetryDelay))
+	}
+	if cfg.FilePath != "" {
+		sinks = append(sinks, NewFileSink(cfg.FilePath))
+	}
+
+	return NewWithSinks(cfg, logger, sinks...)
+}
+
+// NewWithSinks creates a notifier delivering the notifications to the given
+// sinks instead of the ones enabled in the config
+func NewWithSinks(cfg *Config, logger *zap.Logger, sinks ...Sink) (*Notifier, error) {
+	minSeverityStr := cfg.MinSeverity
+	if minSeverityStr == "" {
+		minSeverityStr = defaultMinSeverity
+	}
+	minSeverity, err := ParseSeverity(minSeverityStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid min severity: %w", err)
+	}
+
+	severities, err := parseSeverityOverrides(cfg.Severities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notifier{
+		quit:        make(chan struct{}),
+		minSeverity: minSeverity,
+		severities:  severities,
+		dedupWindow: cfg.DedupWindow,
+		sinks:       sinks,
+		logger:      logger,
+		lastSent:    make(map[string]time.Time),
+		queue:       make(chan *Notification, notificationQueueSize),
+	}, nil
+}
+
+func (n *Notifier) Start() {
+	n.startOnce.Do(func() {
+		if len(n.sinks) == 0 {
+			return
+		}
+
+		n.wg.Add(1)
+		go n.dispatchLoop()
+	})
+}
+
+// Stop delivers the pending notifications and stops the notifier
+func (n *Notifier) Stop() {
+	n.stopOnce.Do(func() {
+		close(n.quit)
+		n.wg.Wait()
+	})
+}
+
+// Notify queues a notification of the given kind unless it is filtered out
+// by its severity or one of the same kind was sent for the finality provider
+// within the dedup window. The message, which may vary for the same event,
+// e.g., with the heights or the wrapped errors, is not de-duplicated on. It
+// never blocks on the delivery
+func (n *Notifier) Notify(kind Kind, fpBtcPkHex, message string) {
+	n.NotifyClass(kind, fpBtcPkHex, "", message)
+}
+
+// NotifyClass is like Notify, but the notifications of the given kind are
+// de-duplicated per class, e.g., the new status of the finality provider
+func (n *Notifier) NotifyClass(kind Kind, fpBtcPkHex, class, message string) {
+	if len(n.sinks) == 0 {
+		return
+	}
+
+	severity := n.severityOf(kind)
+	if severity == SeverityNone || severity < n.minSeverity {
+		return
+	}
+
+	now := time.Now()
+	if n.isDuplicate(string(kind)+"/"+fpBtcPkHex+"/"+class, now) {
+		n.logger.Debug("skipping duplicate notification",
+			zap.String("kind", string(kind)), zap.String("pk", fpBtcPkHex))
+
+		return
+	}
+
+	notification := &Notification{
+		Kind:       kind,
+		Severity:   severity,
+		FpBtcPkHex: fpBtcPkHex,
+		Message:    message,
+		Time:       now,
+	}
+
+	select {
+	case n.queue <- notification:
+	default:
+		n.logger.Warn("dropping notification as the queue is full",
+			zap.String("kind", string(kind)), zap.String("pk", fpBtcPkHex))
+	}
+}
+
+func (n *Notifier) severityOf(kind Kind) Severity {
+	if s, ok := n.severities[kind]; ok {
+		return s
+	}
+
+	return defaultSeverities[kind]
+}
+
+// isDuplicate returns true if the notification with the given key was sent
+// within the dedup window, and records it otherwise
+func (n *Notifier) isDuplicate(key string, now time.Time) bool {
+	if n.dedupWindow == 0 {
+		return false
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for k, t := range n.lastSent {
+		if now.Sub(t) >= n.dedupWindow {
+			delete(n.lastSent, k)
+		}
+	}
+
+	if _, ok := n.lastSent[key]; ok {
+		return true
+	}
+	n.lastSent[key] = now
+
+	return false
+}
+
+func (n *Notifier) dispatchLoop() {
+	defer n.wg.Done()
+
+	for {
+		select {
+		case notification := <-n.queue:
+			n.dispatch(notification)
+		case <-n.quit:
+			// deliver the pending notifications, e.g., the critical error
+			// that makes the daemon exit
+			for {
+				select {
+				case notification := <-n.queue:
+					n.dispatch(notification)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (n *Notifier) dispatch(notification *Notification) {
+	for _, sink := range n.sinks {
+		if err := sink.Send(context.Background(), notification); err != nil {
+			n.logger.Error("failed to send notification",
+				zap.String("sink", sink.Name()),
+				zap.String("kind", string(notification.Kind)),
+				zap.String("pk", notification.FpBtcPkHex),
+				zap.Error(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package notifier_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/envconfig"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
+)
+
+const fpPkHex = "fp"
+
+// webhookReceiver records the notifications received by an httptest server
+type webhookReceiver struct {
+	mu            sync.Mutex
+	notifications []*notifier.Notification
+	signatures    []string
+	payloads      [][]byte
+	failures      int
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// respond with server errors the first times to test the retries
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+
+		return
+	}
+
+	payload, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	var n notifier.Notification
+	if err := json.Unmarshal(payload, &n); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	r.notifications = append(r.notifications, &n)
+	r.signatures = append(r.signatures, req.Header.Get(notifier.SignatureHeader))
+	r.payloads = append(r.payloads, payload)
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestWebhookSink(t *testing.T) {
+	t.Parallel()
+
+	receiver := &webhookReceiver{failures: 2}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	cfg := notifier.DefaultConfig()
+	cfg.WebhookURL = server.URL
+	cfg.WebhookSecret = "secret"
+	cfg.WebhookRetryDelay = 10 * time.Millisecond
+	require.NoError(t, cfg.Validate())
+
+	n, err := notifier.New(cfg, zap.NewNop())
+	require.NoError(t, err)
+	n.Start()
+
+	n.Notify(notifier.KindJailed, fpPkHex, "the finality provider is jailed")
+	// the stop delivers the pending notifications
+	n.Stop()
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	require.Len(t, receiver.notifications, 1)
+	require.Equal(t, notifier.KindJailed, receiver.notifications[0].Kind)
+	require.Equal(t, notifier.SeverityCritical, receiver.notifications[0].Severity)
+	require.Equal(t, fpPkHex, receiver.notifications[0].FpBtcPkHex)
+	require.Equal(t, "sha256="+notifier.Signature(cfg.WebhookSecret, receiver.payloads[0]), receiver.signatures[0])
+}
+
+func TestWebhookSinkGivesUpOnClientError(t *testing.T) {
+	t.Parallel()
+
+	var attempts int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	sink := notifier.NewWebhookSink(server.URL, "", time.Second, 3, time.Millisecond)
+	err := sink.Send(context.Background(), &notifier.Notification{Kind: notifier.KindSlashed})
+	require.ErrorContains(t, err, "401")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 1, attempts)
+}
+
+// recordingSink records the notifications sent to it
+type recordingSink struct {
+	mu            sync.Mutex
+	notifications []*notifier.Notification
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Send(_ context.Context, n *notifier.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notifications = append(s.notifications, n)
+
+	return nil
+}
+
+func TestNotifierFilters(t *testing.T) {
+	t.Parallel()
+
+	cfg := notifier.DefaultConfig()
+	cfg.MinSeverity = "warning"
+	cfg.Severities = []string{"vote_failed=none", "status_changed=critical"}
+	cfg.DedupWindow = time.Hour
+	require.NoError(t, cfg.Validate())
+
+	sink := &recordingSink{}
+	n, err := notifier.NewWithSinks(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+	n.Start()
+
+	// muted by the override
+	n.Notify(notifier.KindVoteFailed, fpPkHex, "failed to vote")
+	// raised by the override
+	n.Notify(notifier.KindStatusChanged, fpPkHex, "ACTIVE to INACTIVE")
+	// the duplicates are dropped whatever their message, while another
+	// class is not
+	n.Notify(notifier.KindRandomnessCommitFailed, fpPkHex, "failed to commit")
+	n.Notify(notifier.KindRandomnessCommitFailed, fpPkHex, "failed to commit again")
+	n.NotifyClass(notifier.KindStatusChanged, fpPkHex, "ACTIVE", "INACTIVE to ACTIVE")
+	n.Stop()
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	require.Len(t, sink.notifications, 3)
+	require.Equal(t, notifier.KindStatusChanged, sink.notifications[0].Kind)
+	require.Equal(t, notifier.SeverityCritical, sink.notifications[0].Severity)
+	require.Equal(t, "failed to commit", sink.notifications[1].Message)
+	require.Equal(t, "INACTIVE to ACTIVE", sink.notifications[2].Message)
+}
+
+// TestNotifierDedupRepeatedFailures tests that the repeated vote failures,
+// whose messages differ in the heights, are sent once per dedup window
+func TestNotifierDedupRepeatedFailures(t *testing.T) {
+	t.Parallel()
+
+	dedupWindow := 200 * time.Millisecond
+	cfg := notifier.DefaultConfig()
+	cfg.DedupWindow = dedupWindow
+	require.NoError(t, cfg.Validate())
+
+	sink := &recordingSink{}
+	n, err := notifier.NewWithSinks(cfg, zap.NewNop(), sink)
+	require.NoError(t, err)
+	n.Start()
+
+	for height := 10; height < 15; height++ {
+		n.Notify(notifier.KindVoteFailed, fpPkHex, fmt.Sprintf("failed to submit finality signatures for blocks %d-%d", height, height))
+	}
+	time.Sleep(dedupWindow)
+	n.Notify(notifier.KindVoteFailed, fpPkHex, "failed to submit finality signatures for blocks 15-15")
+	n.Stop()
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	require.Len(t, sink.notifications, 2)
+	require.Contains(t, sink.notifications[0].Message, "blocks 10-10")
+	require.Contains(t, sink.notifications[1].Message, "blocks 15-15")
+}
+
+func TestFileSink(t *testing.T) {
+	t.Parallel()
+
+	cfg := notifier.DefaultConfig()
+	cfg.FilePath = filepath.Join(t.TempDir(), "notifications.jsonl")
+
+	n, err := notifier.New(cfg, zap.NewNop())
+	require.NoError(t, err)
+	n.Start()
+	n.Notify(notifier.KindSlashed, fpPkHex, "the finality provider is slashed")
+	n.Notify(notifier.KindCriticalError, fpPkHex, "the finality provider is slashed")
+	n.Stop()
+
+	f, err := os.Open(cfg.FilePath)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var kinds []notifier.Kind
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var notification notifier.Notification
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &notification))
+		kinds = append(kinds, notification.Kind)
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, []notifier.Kind{notifier.KindSlashed, notifier.KindCriticalError}, kinds)
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&notifier.Config{}).Validate())
+
+	cfg := notifier.DefaultConfig()
+	cfg.Severities = []string{"unknown=info"}
+	require.Error(t, cfg.Validate())
+
+	cfg = notifier.DefaultConfig()
+	cfg.Severities = []string{"jailed"}
+	require.Error(t, cfg.Validate())
+
+	cfg = notifier.DefaultConfig()
+	cfg.WebhookURL = "ftp://example.com"
+	require.Error(t, cfg.Validate())
+}
+
+func TestRedactWebhook(t *testing.T) {
+	t.Parallel()
+	cfg := notifier.DefaultConfig()
+	cfg.WebhookURL = "https://hooks.example.com/services/token"
+	cfg.WebhookSecret = "secret"
+	envconfig.Redact(cfg)
+	require.Equal(t, envconfig.Redacted, cfg.WebhookURL)
+	require.Equal(t, envconfig.Redacted, cfg.WebhookSecret)
+}
@@ -0,0 +1,130 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the webhook payload
+const SignatureHeader = "X-Fpd-Signature"
+
+// Signature returns the hex-encoded HMAC-SHA256 of the payload with the secret
+func Signature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSink POSTs the notifications as JSON to a URL
+type WebhookSink struct {
+	url         string
+	secret      string
+	client      *http.Client
+	maxAttempts uint
+	retryDelay  time.Duration
+}
+
+func NewWebhookSink(url, secret string, timeout time.Duration, maxAttempts uint, retryDelay time.Duration) *WebhookSink {
+	return &WebhookSink{
+		url:         url,
+		secret:      secret,
+		client:      &http.Client{Timeout: timeout},
+		maxAttempts: max(maxAttempts, 1),
+		retryDelay:  retryDelay,
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Send delivers the notification and retries if the request fails or the
+// receiver responds with a server error
+func (s *WebhookSink) Send(ctx context.Context, n *Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	return retry.Do(func() error {
+		return s.post(ctx, payload)
+	}, retry.Context(ctx), retry.Attempts(s.maxAttempts), retry.Delay(s.retryDelay),
+		retry.DelayType(retry.FixedDelay), retry.LastErrorOnly(true))
+}
+
+func (s *WebhookSink) post(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return retry.Unrecoverable(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Signature(s.secret, payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	default:
+		return retry.Unrecoverable(fmt.Errorf("webhook responded with status %d", resp.StatusCode))
+	}
+}
+
+// FileSink appends the notifications to a file as JSON lines
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+func (s *FileSink) Send(_ context.Context, n *Notification) error {
+	line, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the file is opened upon each notification so that it can be rotated
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open notification file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+
+	return nil
+}
@@ -27,6 +27,10 @@ const (
 	FinalityProviders_QueryFinalityProviderList_FullMethodName = "/proto.FinalityProviders/QueryFinalityProviderList"
 	FinalityProviders_EditFinalityProvider_FullMethodName      = "/proto.FinalityProviders/EditFinalityProvider"
 	FinalityProviders_UnsafeRemoveMerkleProof_FullMethodName   = "/proto.FinalityProviders/UnsafeRemoveMerkleProof"
+	FinalityProviders_SubscribeEvents_FullMethodName           = "/proto.FinalityProviders/SubscribeEvents"
+	FinalityProviders_ReloadConfig_FullMethodName              = "/proto.FinalityProviders/ReloadConfig"
+	FinalityProviders_SetLogLevel_FullMethodName               = "/proto.FinalityProviders/SetLogLevel"
+	FinalityProviders_BackupDB_FullMethodName                  = "/proto.FinalityProviders/BackupDB"
 )
 
 // FinalityProvidersClient is the client API for FinalityProviders service.
@@ -51,6 +55,16 @@ type FinalityProvidersClient interface {
 	EditFinalityProvider(ctx context.Context, in *EditFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	// UnsafeRemoveMerkleProof removes merkle proofs up to target height
 	UnsafeRemoveMerkleProof(ctx context.Context, in *RemoveMerkleProofRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// SubscribeEvents streams the events of the finality providers run by the daemon
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (FinalityProviders_SubscribeEventsClient, error)
+	// ReloadConfig re-reads the config file of the daemon and applies the changed
+	// fields that are safe to change to the running finality provider
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	// SetLogLevel sets the logging level of a subsystem of the daemon, or the
+	// one of its root logger, until the config is reloaded
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// BackupDB streams a consistent snapshot of the database of the daemon
+	BackupDB(ctx context.Context, in *BackupDBRequest, opts ...grpc.CallOption) (FinalityProviders_BackupDBClient, error)
 }
 
 type finalityProvidersClient struct {
@@ -133,6 +147,88 @@ func (c *finalityProvidersClient) UnsafeRemoveMerkleProof(ctx context.Context, i
 	return out, nil
 }
 
+func (c *finalityProvidersClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (FinalityProviders_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FinalityProviders_ServiceDesc.Streams[0], FinalityProviders_SubscribeEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &finalityProvidersSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinalityProviders_SubscribeEventsClient interface {
+	Recv() (*FinalityProviderEvent, error)
+	grpc.ClientStream
+}
+
+type finalityProvidersSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *finalityProvidersSubscribeEventsClient) Recv() (*FinalityProviderEvent, error) {
+	m := new(FinalityProviderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *finalityProvidersClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_ReloadConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_SetLogLevel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) BackupDB(ctx context.Context, in *BackupDBRequest, opts ...grpc.CallOption) (FinalityProviders_BackupDBClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FinalityProviders_ServiceDesc.Streams[1], FinalityProviders_BackupDB_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &finalityProvidersBackupDBClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinalityProviders_BackupDBClient interface {
+	Recv() (*BackupDBResponse, error)
+	grpc.ClientStream
+}
+
+type finalityProvidersBackupDBClient struct {
+	grpc.ClientStream
+}
+
+func (x *finalityProvidersBackupDBClient) Recv() (*BackupDBResponse, error) {
+	m := new(BackupDBResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // FinalityProvidersServer is the server API for FinalityProviders service.
 // All implementations must embed UnimplementedFinalityProvidersServer
 // for forward compatibility
@@ -155,6 +251,16 @@ type FinalityProvidersServer interface {
 	EditFinalityProvider(context.Context, *EditFinalityProviderRequest) (*EmptyResponse, error)
 	// UnsafeRemoveMerkleProof removes merkle proofs up to target height
 	UnsafeRemoveMerkleProof(context.Context, *RemoveMerkleProofRequest) (*EmptyResponse, error)
+	// SubscribeEvents streams the events of the finality providers run by the daemon
+	SubscribeEvents(*SubscribeEventsRequest, FinalityProviders_SubscribeEventsServer) error
+	// ReloadConfig re-reads the config file of the daemon and applies the changed
+	// fields that are safe to change to the running finality provider
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	// SetLogLevel sets the logging level of a subsystem of the daemon, or the
+	// one of its root logger, until the config is reloaded
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// BackupDB streams a consistent snapshot of the database of the daemon
+	BackupDB(*BackupDBRequest, FinalityProviders_BackupDBServer) error
 	mustEmbedUnimplementedFinalityProvidersServer()
 }
 
@@ -186,6 +292,18 @@ func (UnimplementedFinalityProvidersServer) EditFinalityProvider(context.Context
 func (UnimplementedFinalityProvidersServer) UnsafeRemoveMerkleProof(context.Context, *RemoveMerkleProofRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnsafeRemoveMerkleProof not implemented")
 }
+func (UnimplementedFinalityProvidersServer) SubscribeEvents(*SubscribeEventsRequest, FinalityProviders_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedFinalityProvidersServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedFinalityProvidersServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedFinalityProvidersServer) BackupDB(*BackupDBRequest, FinalityProviders_BackupDBServer) error {
+	return status.Errorf(codes.Unimplemented, "method BackupDB not implemented")
+}
 func (UnimplementedFinalityProvidersServer) mustEmbedUnimplementedFinalityProvidersServer() {}
 
 // UnsafeFinalityProvidersServer may be embedded to opt out of forward compatibility for this service.
@@ -343,6 +461,84 @@ func _FinalityProviders_UnsafeRemoveMerkleProof_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FinalityProviders_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FinalityProvidersServer).SubscribeEvents(m, &finalityProvidersSubscribeEventsServer{stream})
+}
+
+type FinalityProviders_SubscribeEventsServer interface {
+	Send(*FinalityProviderEvent) error
+	grpc.ServerStream
+}
+
+type finalityProvidersSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *finalityProvidersSubscribeEventsServer) Send(m *FinalityProviderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FinalityProviders_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_BackupDB_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupDBRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FinalityProvidersServer).BackupDB(m, &finalityProvidersBackupDBServer{stream})
+}
+
+type FinalityProviders_BackupDBServer interface {
+	Send(*BackupDBResponse) error
+	grpc.ServerStream
+}
+
+type finalityProvidersBackupDBServer struct {
+	grpc.ServerStream
+}
+
+func (x *finalityProvidersBackupDBServer) Send(m *BackupDBResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // FinalityProviders_ServiceDesc is the grpc.ServiceDesc for FinalityProviders service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -382,7 +578,26 @@ var FinalityProviders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnsafeRemoveMerkleProof",
 			Handler:    _FinalityProviders_UnsafeRemoveMerkleProof_Handler,
 		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _FinalityProviders_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _FinalityProviders_SetLogLevel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _FinalityProviders_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BackupDB",
+			Handler:       _FinalityProviders_BackupDB_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "finality_providers.proto",
 }
@@ -0,0 +1,9 @@
+package proto
+
+import _ "embed"
+
+// OpenAPIDocument is the OpenAPI document of the REST gateway of the
+// FinalityProviders service
+//
+//go:embed finality_providers.swagger.json
+var OpenAPIDocument []byte
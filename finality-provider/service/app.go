@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -21,9 +22,12 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/lease"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 )
 
@@ -41,10 +45,31 @@ type FinalityProviderApp struct {
 	logger       *zap.Logger
 	input        *strings.Reader
 
+	// reloadMu guards the config reloaded while running, with which the
+	// finality provider instance is created
+	reloadMu    sync.Mutex
+	reloadedCfg *fpcfg.Config
+	loadConfig  ConfigLoader
+	logLevels   *log.Levels
+
+	// fpInsMu guards the finality provider instance, which is accessed by
+	// the event loops and health checks
+	fpInsMu     sync.RWMutex
 	fpIns       *FinalityProviderInstance
 	eotsManager eotsmanager.EOTSManager
+	supervisor  *instanceSupervisor
+
+	// lease is nil if the daemon does not share the finality provider with
+	// standby daemons
+	lease         lease.Lease
+	leaseHolder   string
+	leaseOnce     sync.Once
+	leaseMu       sync.Mutex
+	isLeaseHolder bool
 
-	metrics *metrics.FpMetrics
+	metrics  *metrics.FpMetrics
+	events   *EventBus
+	notifier *notifier.Notifier
 
 	createFinalityProviderRequestChan chan *CreateFinalityProviderRequest
 	unjailFinalityProviderRequestChan chan *UnjailFinalityProviderRequest
@@ -56,14 +81,16 @@ func NewFinalityProviderAppFromConfig(
 	db kvdb.Backend,
 	logger *zap.Logger,
 ) (*FinalityProviderApp, error) {
-	cc, err := clientcontroller.NewClientController(cfg.ChainType, cfg.BabylonConfig, &cfg.BTCNetParams, logger)
+	cc, err := clientcontroller.NewClientController(cfg.ChainType, cfg.BabylonConfig, &cfg.BTCNetParams,
+		logger.Named(log.SubsystemClientController))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rpc client for the consumer chain %s: %w", cfg.ChainType, err)
 	}
 
 	// if the EOTSManagerAddress is empty, run a local EOTS manager;
 	// otherwise connect a remote one with a gRPC client
-	em, err := client.NewEOTSManagerGRpcClient(cfg.EOTSManagerAddress)
+	em, err := client.NewEOTSManagerGRpcClient(cfg.EOTSManagerAddress,
+		client.WithLogger(logger.Named(log.SubsystemEOTSRPC)), client.WithMetrics(metrics.NewFpMetrics()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
 	}
@@ -102,17 +129,33 @@ func NewFinalityProviderApp(
 
 	fpMetrics := metrics.NewFpMetrics()
 
+	fpNotifier, err := notifier.New(config.Notifier, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notifier: %w", err)
+	}
+
+	fpLease, leaseHolder, err := newLease(config.LeaseConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create lease: %w", err)
+	}
+
 	return &FinalityProviderApp{
 		cc:                                cc,
 		fps:                               fpStore,
 		pubRandStore:                      pubRandStore,
 		kr:                                kr,
 		config:                            config,
+		reloadedCfg:                       config,
 		logger:                            logger,
 		input:                             input,
 		fpIns:                             nil,
 		eotsManager:                       em,
+		supervisor:                        newInstanceSupervisor(config.SupervisorConfig),
+		lease:                             fpLease,
+		leaseHolder:                       leaseHolder,
 		metrics:                           fpMetrics,
+		events:                            NewEventBus(logger),
+		notifier:                          fpNotifier,
 		quit:                              make(chan struct{}),
 		unjailFinalityProviderRequestChan: make(chan *UnjailFinalityProviderRequest),
 		createFinalityProviderRequestChan: make(chan *CreateFinalityProviderRequest),
@@ -120,6 +163,12 @@ func NewFinalityProviderApp(
 	}, nil
 }
 
+// SubscribeEvents returns the recent events of the daemon along with a
+// channel of the upcoming ones and a function to cancel the subscription
+func (app *FinalityProviderApp) SubscribeEvents() ([]*proto.FinalityProviderEvent, <-chan *proto.FinalityProviderEvent, func()) {
+	return app.events.Subscribe()
+}
+
 func (app *FinalityProviderApp) GetConfig() *fpcfg.Config {
 	return app.config
 }
@@ -169,6 +218,9 @@ func (app *FinalityProviderApp) ListAllFinalityProvidersInfo() ([]*proto.Finalit
 
 // GetFinalityProviderInstance returns the finality-provider instance with the given Babylon public key
 func (app *FinalityProviderApp) GetFinalityProviderInstance() (*FinalityProviderInstance, error) {
+	app.fpInsMu.RLock()
+	defer app.fpInsMu.RUnlock()
+
 	if app.fpIns == nil {
 		return nil, fmt.Errorf("finality provider does not exist")
 	}
@@ -192,20 +244,20 @@ func (app *FinalityProviderApp) StartFinalityProvider(fpPk *bbntypes.BIP340PubKe
 
 // SyncAllFinalityProvidersStatus syncs the status of all the stored finality providers with the chain.
 // it should be called before a fp instance is started
-func (app *FinalityProviderApp) SyncAllFinalityProvidersStatus() error {
+func (app *FinalityProviderApp) SyncAllFinalityProvidersStatus(ctx context.Context) error {
 	fps, err := app.fps.GetAllStoredFinalityProviders()
 	if err != nil {
 		return err
 	}
 
 	for _, fp := range fps {
-		latestBlock, err := app.cc.QueryBestBlock()
+		latestBlock, err := app.cc.QueryBestBlock(ctx)
 		if err != nil {
 			return err
 		}
 
 		pkHex := fp.GetBIP340BTCPK().MarshalHex()
-		power, err := app.cc.QueryFinalityProviderVotingPower(fp.BtcPk, latestBlock.Height)
+		power, err := app.cc.QueryFinalityProviderVotingPower(ctx, fp.BtcPk, latestBlock.Height)
 		if err != nil {
 			return fmt.Errorf("failed to query voting power for finality provider %s at height %d: %w",
 				fp.GetBIP340BTCPK().MarshalHex(), latestBlock.Height, err)
@@ -227,7 +279,7 @@ func (app *FinalityProviderApp) SyncAllFinalityProvidersStatus() error {
 
 			continue
 		}
-		slashed, jailed, err := app.cc.QueryFinalityProviderSlashedOrJailed(fp.BtcPk)
+		slashed, jailed, err := app.cc.QueryFinalityProviderSlashedOrJailed(ctx, fp.BtcPk)
 		if err != nil {
 			return err
 		}
@@ -272,16 +324,29 @@ func (app *FinalityProviderApp) Start() error {
 	app.startOnce.Do(func() {
 		app.logger.Info("Starting FinalityProviderApp")
 
-		startErr = app.SyncAllFinalityProvidersStatus()
+		ctx, cancel := contextWithQuit(context.Background(), app.quit)
+		defer cancel()
+
+		startErr = app.SyncAllFinalityProvidersStatus(ctx)
 		if startErr != nil {
 			return
 		}
 
+		app.notifier.Start()
+
 		app.wg.Add(4)
 		go app.metricsUpdateLoop()
 		go app.monitorCriticalErr()
 		go app.registrationLoop()
 		go app.unjailFpLoop()
+
+		switch {
+		case app.config.AutoUnjail && app.config.ShadowMode:
+			app.logger.Warn("automatic unjailing is disabled in shadow mode")
+		case app.config.AutoUnjail:
+			app.wg.Add(1)
+			go app.autoUnjailLoop()
+		}
 	})
 
 	return startErr
@@ -295,11 +360,11 @@ func (app *FinalityProviderApp) Stop() error {
 		close(app.quit)
 		app.wg.Wait()
 
-		if app.fpIns != nil && app.fpIns.IsRunning() {
-			pkHex := app.fpIns.GetBtcPkHex()
+		if fpi, err := app.GetFinalityProviderInstance(); err == nil && fpi.IsRunning() {
+			pkHex := fpi.GetBtcPkHex()
 			app.logger.Info("stopping finality provider", zap.String("pk", pkHex))
 
-			if err := app.fpIns.Stop(); err != nil {
+			if err := fpi.Stop(); err != nil {
 				stopErr = fmt.Errorf("failed to close the fp instance: %w", err)
 
 				return
@@ -308,6 +373,22 @@ func (app *FinalityProviderApp) Stop() error {
 			app.logger.Info("finality provider is stopped", zap.String("pk", pkHex))
 		}
 
+		if app.lease != nil {
+			app.releaseLease()
+		}
+
+		// end the event subscriptions and deliver the pending notifications
+		// after the instance stops publishing
+		app.events.Close()
+		app.notifier.Stop()
+
+		app.logger.Debug("Stopping the consumer chain client")
+		if err := app.cc.Close(); err != nil {
+			stopErr = fmt.Errorf("failed to close the consumer chain client: %w", err)
+
+			return
+		}
+
 		app.logger.Debug("Stopping EOTS manager")
 		if err := app.eotsManager.Close(); err != nil {
 			stopErr = fmt.Errorf("failed to close the EOTS manager: %w", err)
@@ -322,11 +403,16 @@ func (app *FinalityProviderApp) Stop() error {
 }
 
 func (app *FinalityProviderApp) CreateFinalityProvider(
+	ctx context.Context,
 	keyName, chainID, passPhrase string,
 	eotsPk *bbntypes.BIP340PubKey,
 	description *stakingtypes.Description,
 	commission *sdkmath.LegacyDec,
 ) (*CreateFinalityProviderResult, error) {
+	if app.config.ShadowMode {
+		return nil, ErrShadowMode
+	}
+
 	// 1. check if the chain key exists
 	kr, err := fpkr.NewChainKeyringControllerWithKeyring(app.kr, keyName, app.input)
 	if err != nil {
@@ -343,7 +429,7 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 	if eotsPk == nil {
 		return nil, fmt.Errorf("eots pk cannot be nil")
 	}
-	pop, err := app.CreatePop(fpAddr, eotsPk, passPhrase)
+	pop, err := app.CreatePop(ctx, fpAddr, eotsPk, passPhrase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create proof-of-possession of the finality-provider: %w", err)
 	}
@@ -351,9 +437,9 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 	// Query the consumer chain to check if the fp is already registered
 	// if true, update db with the fp info from the consumer chain
 	// otherwise, proceed registration
-	resp, err := app.cc.QueryFinalityProvider(eotsPk.MustToBTCPK())
+	resp, err := app.cc.QueryFinalityProvider(ctx, eotsPk.MustToBTCPK())
 	if err != nil {
-		if !strings.Contains(err.Error(), "the finality provider is not found") {
+		if !errors.Is(err, bstypes.ErrFpNotFound) {
 			return nil, fmt.Errorf("err getting finality provider: %w", err)
 		}
 	}
@@ -363,7 +449,7 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 			zap.String("addr", resp.FinalityProvider.Addr),
 		)
 
-		if err := app.putFpFromResponse(resp.FinalityProvider, chainID); err != nil {
+		if err := app.putFpFromResponse(ctx, resp.FinalityProvider, chainID); err != nil {
 			return nil, err
 		}
 
@@ -380,6 +466,7 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 
 	// 3. register the finality provider on the consumer chain
 	request := &CreateFinalityProviderRequest{
+		ctx:             ctx,
 		fpAddr:          fpAddr,
 		btcPubKey:       eotsPk,
 		pop:             pop,
@@ -389,7 +476,13 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 		successResponse: make(chan *RegisterFinalityProviderResponse, 1),
 	}
 
-	app.createFinalityProviderRequestChan <- request
+	select {
+	case app.createFinalityProviderRequestChan <- request:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-app.quit:
+		return nil, fmt.Errorf("finality-provider app is shutting down")
+	}
 
 	select {
 	case err := <-request.errResponse:
@@ -407,6 +500,7 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 		}
 
 		app.metrics.RecordFpStatus(pkHex, proto.FinalityProviderStatus_REGISTERED)
+		app.events.Publish(newStatusChangedEvent(pkHex, "", proto.FinalityProviderStatus_REGISTERED.String()))
 
 		app.logger.Info("successfully saved the finality-provider",
 			zap.String("eots_pk", pkHex),
@@ -430,27 +524,40 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 			FpInfo: storedFp.ToFinalityProviderInfo(),
 			TxHash: successResponse.txHash,
 		}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	case <-app.quit:
 		return nil, fmt.Errorf("finality-provider app is shutting down")
 	}
 }
 
 // UnjailFinalityProvider sends a transaction to unjail a finality-provider
-func (app *FinalityProviderApp) UnjailFinalityProvider(fpPk *bbntypes.BIP340PubKey) (*UnjailFinalityProviderResponse, error) {
+func (app *FinalityProviderApp) UnjailFinalityProvider(ctx context.Context, fpPk *bbntypes.BIP340PubKey) (*UnjailFinalityProviderResponse, error) {
+	if app.config.ShadowMode {
+		return nil, ErrShadowMode
+	}
+
 	// send request to the loop to avoid blocking the main thread
 	request := &UnjailFinalityProviderRequest{
+		ctx:             ctx,
 		btcPubKey:       fpPk,
 		errResponse:     make(chan error, 1),
 		successResponse: make(chan *UnjailFinalityProviderResponse, 1),
 	}
 
-	app.unjailFinalityProviderRequestChan <- request
+	select {
+	case app.unjailFinalityProviderRequestChan <- request:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-app.quit:
+		return nil, fmt.Errorf("finality-provider app is shutting down")
+	}
 
 	select {
 	case err := <-request.errResponse:
 		return nil, err
 	case successResponse := <-request.successResponse:
-		_, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+		storedFp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
 		if err != nil {
 			return nil, fmt.Errorf("failed to get finality provider from db: %w", err)
 		}
@@ -464,14 +571,20 @@ func (app *FinalityProviderApp) UnjailFinalityProvider(fpPk *bbntypes.BIP340PubK
 		}
 
 		app.metrics.RecordFpStatus(fpPk.MarshalHex(), proto.FinalityProviderStatus_INACTIVE)
+		if storedFp.Status != proto.FinalityProviderStatus_INACTIVE {
+			app.events.Publish(newStatusChangedEvent(fpPk.MarshalHex(),
+				storedFp.Status.String(), proto.FinalityProviderStatus_INACTIVE.String()))
+		}
 
 		return successResponse, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	case <-app.quit:
 		return nil, fmt.Errorf("finality-provider app is shutting down")
 	}
 }
 
-func (app *FinalityProviderApp) CreatePop(fpAddress sdk.AccAddress, fpPk *bbntypes.BIP340PubKey, passphrase string) (*bstypes.ProofOfPossessionBTC, error) {
+func (app *FinalityProviderApp) CreatePop(ctx context.Context, fpAddress sdk.AccAddress, fpPk *bbntypes.BIP340PubKey, passphrase string) (*bstypes.ProofOfPossessionBTC, error) {
 	pop := &bstypes.ProofOfPossessionBTC{
 		BtcSigType: bstypes.BTCSigType_BIP340, // by default, we use BIP-340 encoding for BTC signature
 	}
@@ -481,7 +594,7 @@ func (app *FinalityProviderApp) CreatePop(fpAddress sdk.AccAddress, fpPk *bbntyp
 	// So we have to hash the address before signing
 	hash := tmhash.Sum(fpAddress.Bytes())
 
-	sig, err := app.eotsManager.SignSchnorrSig(fpPk.MustMarshal(), hash, passphrase)
+	sig, err := app.eotsManager.SignSchnorrSig(ctx, fpPk.MustMarshal(), hash, passphrase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schnorr signature from the EOTS manager: %w", err)
 	}
@@ -496,39 +609,75 @@ func (app *FinalityProviderApp) startFinalityProviderInstance(
 	passphrase string,
 ) error {
 	pkHex := pk.MarshalHex()
-	if app.fpIns == nil {
-		fpIns, err := NewFinalityProviderInstance(
-			pk, app.config, app.fps, app.pubRandStore, app.cc, app.eotsManager,
-			app.metrics, passphrase, app.criticalErrChan, app.logger,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create finality provider instance %s: %w", pkHex, err)
+	fpi, err := app.createFinalityProviderInstance(pk, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if app.lease != nil {
+		// the instance is started once the daemon acquires the lease
+		app.leaseOnce.Do(func() {
+			app.logger.Info("waiting for the lease to start the finality provider",
+				zap.String("pk", pkHex), zap.String("holder", app.leaseHolder))
+			app.wg.Add(1)
+			go app.leaseLoop(fpi)
+		})
+
+		return nil
+	}
+
+	return fpi.Start()
+}
+
+// createFinalityProviderInstance creates the finality provider instance with
+// the reloaded config unless it already exists
+func (app *FinalityProviderApp) createFinalityProviderInstance(pk *bbntypes.BIP340PubKey, passphrase string) (*FinalityProviderInstance, error) {
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	if fpi, err := app.GetFinalityProviderInstance(); err == nil {
+		if !pk.Equals(fpi.btcPk) {
+			return nil, fmt.Errorf("the finality provider daemon is already bonded with the finality provider %s,"+
+				"please restart the daemon to switch to another instance", fpi.btcPk.MarshalHex())
 		}
 
-		app.fpIns = fpIns
-	} else if !pk.Equals(app.fpIns.btcPk) {
-		return fmt.Errorf("the finality provider daemon is already bonded with the finality provider %s,"+
-			"please restart the daemon to switch to another instance", app.fpIns.btcPk.MarshalHex())
+		return fpi, nil
+	}
+
+	fpIns, err := NewFinalityProviderInstance(
+		pk, app.reloadedCfg, app.fps, app.pubRandStore, app.cc, app.eotsManager,
+		app.metrics, app.events, app.notifier, passphrase, app.criticalErrChan, app.logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finality provider instance %s: %w", pk.MarshalHex(), err)
 	}
+	if app.lease != nil {
+		fpIns.checkLease = app.checkLeaseHeld
+	}
+
+	app.fpInsMu.Lock()
+	app.fpIns = fpIns
+	app.fpInsMu.Unlock()
 
-	return app.fpIns.Start()
+	return fpIns, nil
 }
 
 func (app *FinalityProviderApp) IsFinalityProviderRunning(fpPk *bbntypes.BIP340PubKey) bool {
-	if app.fpIns == nil {
+	fpi, err := app.GetFinalityProviderInstance()
+	if err != nil {
 		return false
 	}
 
-	if app.fpIns.GetBtcPkHex() != fpPk.MarshalHex() {
+	if fpi.GetBtcPkHex() != fpPk.MarshalHex() {
 		return false
 	}
 
-	return app.fpIns.IsRunning()
+	return fpi.IsRunning()
 }
 
 func (app *FinalityProviderApp) removeFinalityProviderInstance() error {
-	fpi := app.fpIns
-	if fpi == nil {
+	fpi, err := app.GetFinalityProviderInstance()
+	if err != nil {
 		return fmt.Errorf("the finality provider instance does not exist")
 	}
 	if fpi.IsRunning() {
@@ -537,7 +686,9 @@ func (app *FinalityProviderApp) removeFinalityProviderInstance() error {
 		}
 	}
 
+	app.fpInsMu.Lock()
 	app.fpIns = nil
+	app.fpInsMu.Unlock()
 
 	return nil
 }
@@ -550,8 +701,8 @@ func (app *FinalityProviderApp) setFinalityProviderSlashed(fpi *FinalityProvider
 }
 
 // NOTE: this is not safe in production, so only used for testing purpose
-func (app *FinalityProviderApp) getFpPrivKey(fpPk []byte) (*btcec.PrivateKey, error) {
-	record, err := app.eotsManager.KeyRecord(fpPk, "")
+func (app *FinalityProviderApp) getFpPrivKey(ctx context.Context, fpPk []byte) (*btcec.PrivateKey, error) {
+	record, err := app.eotsManager.KeyRecord(ctx, fpPk, "")
 	if err != nil {
 		return nil, err
 	}
@@ -560,7 +711,7 @@ func (app *FinalityProviderApp) getFpPrivKey(fpPk []byte) (*btcec.PrivateKey, er
 }
 
 // putFpFromResponse creates or updates finality-provider in the local store
-func (app *FinalityProviderApp) putFpFromResponse(fp *bstypes.FinalityProviderResponse, chainID string) error {
+func (app *FinalityProviderApp) putFpFromResponse(ctx context.Context, fp *bstypes.FinalityProviderResponse, chainID string) error {
 	btcPk := fp.BtcPk.MustToBTCPK()
 	_, err := app.fps.GetFinalityProvider(btcPk)
 	if err != nil {
@@ -592,7 +743,7 @@ func (app *FinalityProviderApp) putFpFromResponse(fp *bstypes.FinalityProviderRe
 		return err
 	}
 
-	power, err := app.cc.QueryFinalityProviderVotingPower(btcPk, fp.Height)
+	power, err := app.cc.QueryFinalityProviderVotingPower(ctx, btcPk, fp.Height)
 	if err != nil {
 		return fmt.Errorf("failed to query voting power for finality provider %s: %w",
 			fp.BtcPk.MarshalHex(), err)
@@ -1,12 +1,14 @@
 package service_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,18 +18,22 @@ import (
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
 	sdkkeyring "github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/faults"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/keyring"
 	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
@@ -62,10 +68,10 @@ func FuzzCreateFinalityProvider(f *testing.F) {
 		randomStartingHeight := uint64(r.Int63n(100) + 1)
 		currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
 		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
-		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
-		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(),
+		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(),
 			gomock.Any()).Return(uint64(0), nil).AnyTimes()
-		mockClientController.EXPECT().QueryFinalityProvider(gomock.Any()).Return(nil, nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProvider(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 
 		// Create randomized config
 		fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
@@ -93,7 +99,7 @@ func FuzzCreateFinalityProvider(f *testing.F) {
 		var eotsPk *bbntypes.BIP340PubKey
 		eotsKeyName := testutil.GenRandomHexStr(r, 4)
 		require.NoError(t, err)
-		eotsPkBz, err := em.CreateKey(eotsKeyName, passphrase, hdPath)
+		eotsPkBz, err := em.CreateKey(context.Background(), eotsKeyName, passphrase, hdPath)
 		require.NoError(t, err)
 		eotsPk, err = bbntypes.NewBIP340PubKey(eotsPkBz)
 		require.NoError(t, err)
@@ -109,12 +115,13 @@ func FuzzCreateFinalityProvider(f *testing.F) {
 		txHash := testutil.GenRandomHexStr(r, 32)
 		mockClientController.EXPECT().
 			RegisterFinalityProvider(
+				gomock.Any(),
 				eotsPk.MustToBTCPK(),
 				gomock.Any(),
 				testutil.ZeroCommissionRate(),
 				gomock.Any(),
 			).Return(&types.TxResponse{TxHash: txHash}, nil).AnyTimes()
-		res, err := app.CreateFinalityProvider(keyName, chainID, passphrase, eotsPk, testutil.RandomDescription(r), testutil.ZeroCommissionRate())
+		res, err := app.CreateFinalityProvider(context.Background(), keyName, chainID, passphrase, eotsPk, testutil.RandomDescription(r), testutil.ZeroCommissionRate())
 		require.NoError(t, err)
 		require.Equal(t, txHash, res.TxHash)
 
@@ -135,33 +142,33 @@ func FuzzSyncFinalityProviderStatus(f *testing.F) {
 
 		blkInfo := &types.BlockInfo{Height: currentHeight}
 
-		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
-		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
-		mockClientController.EXPECT().QueryBestBlock().Return(blkInfo, nil).Return(blkInfo, nil).AnyTimes()
-		mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(blkInfo, nil).Return(blkInfo, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBlock(gomock.Any(), gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
 
 		noVotingPowerTable := r.Int31n(10) > 5
 		if noVotingPowerTable {
 			allowedErr := fmt.Sprintf("failed to query Finality Voting Power at Height %d: rpc error: code = Unknown desc = %s: unknown request",
 				currentHeight, finalitytypes.ErrVotingPowerTableNotUpdated.Wrapf("height: %d", currentHeight).Error())
-			mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
-			mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(0), errors.New(allowedErr)).AnyTimes()
+			mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+			mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(uint64(0), errors.New(allowedErr)).AnyTimes()
 		} else {
-			mockClientController.EXPECT().QueryActivatedHeight().Return(currentHeight, nil).AnyTimes()
-			mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(2), nil).AnyTimes()
+			mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(currentHeight, nil).AnyTimes()
+			mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(2), nil).AnyTimes()
 		}
-		mockClientController.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
 		var isSlashedOrJailed int
 		if noVotingPowerTable {
 			// 0 means is slashed, 1 means is jailed, 2 means neither slashed nor jailed
 			isSlashedOrJailed = r.Intn(3)
 			switch isSlashedOrJailed {
 			case 0:
-				mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(true, false, nil).AnyTimes()
+				mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(true, false, nil).AnyTimes()
 			case 1:
-				mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, true, nil).AnyTimes()
+				mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(false, true, nil).AnyTimes()
 			case 2:
-				mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+				mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(false, false, nil).AnyTimes()
 			}
 		}
 
@@ -170,7 +177,8 @@ func FuzzSyncFinalityProviderStatus(f *testing.F) {
 		fpHomeDir := filepath.Join(t.TempDir(), "fp-home", pathSuffix)
 		fpCfg := config.DefaultConfigWithHome(fpHomeDir)
 		// no need for other intervals to run
-		fpCfg.SubmissionRetryInterval = time.Minute * 10
+		fpCfg.RetryConfig.VoteSubmission.BaseDelay = time.Minute * 10
+		fpCfg.RetryConfig.VoteSubmission.MaxDelay = time.Minute * 10
 
 		// Create fp app
 		app, fpPk, cleanup := startFPAppWithRegisteredFp(t, r, fpHomeDir, &fpCfg, mockClientController)
@@ -209,34 +217,35 @@ func FuzzUnjailFinalityProvider(f *testing.F) {
 		fpHomeDir := filepath.Join(t.TempDir(), "fp-home", pathSuffix)
 		fpCfg := config.DefaultConfigWithHome(fpHomeDir)
 		// use shorter interval for the test to end faster
-		fpCfg.SubmissionRetryInterval = time.Millisecond * 10
+		fpCfg.RetryConfig.VoteSubmission.BaseDelay = time.Millisecond * 10
+		fpCfg.RetryConfig.VoteSubmission.MaxDelay = time.Millisecond * 10
 		fpCfg.SignatureSubmissionInterval = time.Millisecond * 10
 
 		blkInfo := &types.BlockInfo{Height: currentHeight}
 
-		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
-		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
-		mockClientController.EXPECT().QueryBestBlock().Return(blkInfo, nil).Return(blkInfo, nil).AnyTimes()
-		mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(blkInfo, nil).Return(blkInfo, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBlock(gomock.Any(), gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
 
 		// set voting power to be positive so that the fp should eventually become ACTIVE
-		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
-		mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
-		mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, true, nil).AnyTimes()
-		mockClientController.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+		mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(uint64(1), nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(false, true, nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
 
 		// Create fp app
 		app, fpPk, cleanup := startFPAppWithRegisteredFp(t, r, fpHomeDir, &fpCfg, mockClientController)
 		defer cleanup()
 
 		expectedTxHash := datagen.GenRandomHexStr(r, 32)
-		mockClientController.EXPECT().UnjailFinalityProvider(fpPk.MustToBTCPK()).Return(&types.TxResponse{TxHash: expectedTxHash}, nil)
+		mockClientController.EXPECT().UnjailFinalityProvider(gomock.Any(), fpPk.MustToBTCPK()).Return(&types.TxResponse{TxHash: expectedTxHash}, nil)
 		err := app.StartFinalityProvider(fpPk, "")
 		require.NoError(t, err)
 		fpIns, err := app.GetFinalityProviderInstance()
 		require.NoError(t, err)
 		require.True(t, fpIns.IsJailed())
-		res, err := app.UnjailFinalityProvider(fpPk)
+		res, err := app.UnjailFinalityProvider(context.Background(), fpPk)
 		require.NoError(t, err)
 		require.Equal(t, expectedTxHash, res.TxHash)
 		require.Eventually(t, func() bool {
@@ -245,6 +254,295 @@ func FuzzUnjailFinalityProvider(f *testing.F) {
 	})
 }
 
+func TestAutoUnjailFinalityProvider(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.AutoUnjail = true
+	// use shorter interval for the test to end faster
+	fpCfg.AutoUnjailInterval = time.Millisecond * 10
+	fpCfg.RetryConfig.VoteSubmission.BaseDelay = time.Millisecond * 10
+	fpCfg.RetryConfig.VoteSubmission.MaxDelay = time.Millisecond * 10
+	fpCfg.SignatureSubmissionInterval = time.Millisecond * 10
+
+	blkInfo := &types.BlockInfo{Height: currentHeight}
+
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(blkInfo, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any(), gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(false, true, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+
+	// the finality provider is jailed on start
+	app, fpPk, cleanup := startFPAppWithRegisteredFp(t, r, fpHomeDir, &fpCfg, mockClientController)
+	defer cleanup()
+
+	// the unjail tx is only sent after the jail period elapses
+	jailedUntil := time.Now().Add(time.Millisecond * 200)
+	mockClientController.EXPECT().QueryFinalityProviderJailedUntil(gomock.Any(), fpPk.MustToBTCPK()).Return(jailedUntil, nil).AnyTimes()
+	mockClientController.EXPECT().UnjailFinalityProvider(gomock.Any(), fpPk.MustToBTCPK()).
+		DoAndReturn(func(_ context.Context, _ any) (*types.TxResponse, error) {
+			require.False(t, time.Now().Before(jailedUntil))
+
+			return &types.TxResponse{TxHash: datagen.GenRandomHexStr(r, 32)}, nil
+		}).Times(1)
+
+	err := app.StartFinalityProvider(fpPk, "")
+	require.NoError(t, err)
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	require.True(t, fpIns.IsJailed())
+
+	require.Eventually(t, func() bool {
+		return !fpIns.IsJailed()
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	require.Equal(t, proto.FinalityProviderStatus_INACTIVE, fpIns.GetStatus())
+}
+
+func TestLeaseFailover(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+	mockClientController := newLeaseMockedClientController(t, r, randomStartingHeight, currentHeight)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(map[uint64]*finalitytypes.PubRandCommitResponse{1: {NumPubRand: 1_000_000}}, nil).AnyTimes()
+
+	// both daemons share the lease file
+	leasePath := filepath.Join(t.TempDir(), "fpd.lease")
+
+	// the first daemon acquires the lease and runs the finality provider
+	activeIns, activeCleanUp := startLeaseDaemon(t, r, leasePath, "active", randomStartingHeight, mockClientController)
+	require.Eventually(t, activeIns.IsRunning, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	// the second daemon stands by while the lease is renewed
+	standbyIns, standbyCleanUp := startLeaseDaemon(t, r, leasePath, "standby", randomStartingHeight, mockClientController)
+	defer standbyCleanUp()
+	require.Never(t, standbyIns.IsRunning, 500*time.Millisecond, eventuallyPollTime)
+	require.True(t, activeIns.IsRunning())
+
+	// the standby daemon takes over once the active one shuts down
+	activeCleanUp()
+	require.Eventually(t, standbyIns.IsRunning, eventuallyWaitTimeOut, eventuallyPollTime)
+}
+
+// TestLeaseFailoverOnCrash tests that the standby daemon takes over while the
+// instance of the active daemon is stopped after a critical error
+func TestLeaseFailoverOnCrash(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+	leasePath := filepath.Join(t.TempDir(), "fpd.lease")
+
+	// the randomness of the active daemon is sufficient until it crashes
+	var crashed atomic.Bool
+	sufficientPubRand := map[uint64]*finalitytypes.PubRandCommitResponse{1: {NumPubRand: 1_000_000}}
+	activeCC := newLeaseMockedClientController(t, r, randomStartingHeight, currentHeight)
+	activeCC.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ any, _ uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+			if crashed.Load() {
+				return nil, errors.New("connection refused")
+			}
+
+			return sufficientPubRand, nil
+		}).AnyTimes()
+	standbyCC := newLeaseMockedClientController(t, r, randomStartingHeight, currentHeight)
+	standbyCC.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), gomock.Any()).Return(sufficientPubRand, nil).AnyTimes()
+
+	activeIns, activeCleanUp := startLeaseDaemon(t, r, leasePath, "active", randomStartingHeight, activeCC)
+	defer activeCleanUp()
+	require.Eventually(t, activeIns.IsRunning, eventuallyWaitTimeOut, eventuallyPollTime)
+	standbyIns, standbyCleanUp := startLeaseDaemon(t, r, leasePath, "standby", randomStartingHeight, standbyCC)
+	defer standbyCleanUp()
+	require.Never(t, standbyIns.IsRunning, 300*time.Millisecond, eventuallyPollTime)
+
+	// the instance of the active daemon is stopped for the restart backoff,
+	// during which the standby daemon takes over
+	crashed.Store(true)
+	require.Eventually(t, standbyIns.IsRunning, eventuallyWaitTimeOut, eventuallyPollTime)
+	require.False(t, activeIns.IsRunning())
+}
+
+func newLeaseMockedClientController(t *testing.T, r *rand.Rand, startHeight, currentHeight uint64) *mocks.MockClientController {
+	cc := testutil.PrepareMockedClientController(t, r, startHeight, currentHeight, 0)
+	cc.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	cc.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	cc.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(false, false, nil).AnyTimes()
+	cc.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	cc.EXPECT().QueryBlock(gomock.Any(), gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+
+	return cc
+}
+
+// sharedClientController and sharedEOTSManager are not closed along with
+// one of the daemons sharing them
+type sharedClientController struct {
+	clientcontroller.ClientController
+}
+
+func (sharedClientController) Close() error {
+	return nil
+}
+
+type sharedEOTSManager struct {
+	eotsmanager.EOTSManager
+}
+
+func (sharedEOTSManager) Close() error {
+	return nil
+}
+
+// setTestLease sets the lease shared by the daemons in the given file with a
+// short TTL
+func setTestLease(fpCfg *config.Config, leasePath, holderID string) {
+	fpCfg.LeaseConfig.Backend = config.LeaseBackendFile
+	fpCfg.LeaseConfig.FilePath = leasePath
+	fpCfg.LeaseConfig.HolderID = holderID
+	fpCfg.LeaseConfig.TTL = 300 * time.Millisecond
+	fpCfg.LeaseConfig.RenewInterval = 50 * time.Millisecond
+}
+
+// startLeaseDaemon starts a daemon sharing the lease file with the given
+// holder ID, whose instance is only started once it holds the lease
+func startLeaseDaemon(
+	t *testing.T,
+	r *rand.Rand,
+	leasePath, holderID string,
+	startHeight uint64,
+	cc clientcontroller.ClientController,
+) (*service.FinalityProviderInstance, func()) {
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home-"+holderID)
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.PollerConfig.AutoChainScanningMode = false
+	fpCfg.PollerConfig.StaticChainScanningStartHeight = startHeight
+	fpCfg.RandomnessCommitInterval = 10 * time.Millisecond
+	fpCfg.RetryConfig.PubRandCommit.Attempts = 1
+	fpCfg.SupervisorConfig.InitialBackoff = time.Hour
+	fpCfg.SupervisorConfig.MaxBackoff = time.Hour
+	setTestLease(&fpCfg, leasePath, holderID)
+
+	app, fpPk, cleanUp := startFPAppWithRegisteredFp(t, r, fpHomeDir, &fpCfg, cc)
+	err := app.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+
+	return fpIns, cleanUp
+}
+
+// TestLeaseFailoverOnSimulatedChain tests that the standby daemon keeps
+// voting once it takes over, with the public randomness committed by the
+// active daemon
+func TestLeaseFailoverOnSimulatedChain(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+	leasePath := filepath.Join(t.TempDir(), "fpd.lease")
+
+	// the daemons share the EOTS key, e.g., through a remote EOTS manager,
+	// which is kept open when the active daemon stops
+	em := newLocalEOTSManager(t)
+	cc := sharedClientController{simChain}
+
+	activeCfg := newSimulatedChainFpConfig(t, simCfg)
+	setTestLease(activeCfg, leasePath, "active")
+	activeApp := newFPApp(t, activeCfg, cc, sharedEOTSManager{em})
+	fpPk := registerFpOnSimulatedChain(t, r, activeApp, em)
+	requireFinalizedHeight(t, simChain, 5)
+
+	standbyCfg := newSimulatedChainFpConfig(t, simCfg)
+	setTestLease(standbyCfg, leasePath, "standby")
+	standbyApp := newFPApp(t, standbyCfg, cc, sharedEOTSManager{em})
+	storedFp, err := activeApp.GetFinalityProviderStore().GetFinalityProvider(fpPk.MustToBTCPK())
+	require.NoError(t, err)
+	fpAddr, err := sdk.AccAddressFromBech32(storedFp.FPAddr)
+	require.NoError(t, err)
+	err = standbyApp.GetFinalityProviderStore().CreateFinalityProvider(fpAddr, storedFp.BtcPk,
+		storedFp.Description, storedFp.Commission, storedFp.ChainID)
+	require.NoError(t, err)
+	require.NoError(t, standbyApp.StartFinalityProvider(fpPk, passphrase))
+
+	// the randomness committed by the active daemon covers the next blocks,
+	// whose inclusion proofs are only stored by the active daemon
+	activeIns, err := activeApp.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	require.NoError(t, activeApp.Stop())
+	lastVotedHeight := activeIns.GetLastVotedHeight()
+	lastCommittedHeight, err := activeIns.GetLastCommittedHeight(context.Background())
+	require.NoError(t, err)
+	require.Greater(t, lastCommittedHeight, lastVotedHeight+5)
+
+	standbyIns, err := standbyApp.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return standbyIns.GetLastVotedHeight() >= lastVotedHeight+5
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+	require.True(t, standbyIns.IsRunning())
+}
+
+// TestCriticalErrDuringRestartBackoff tests that the critical errors are
+// still handled while the restart of the instance is pending
+func TestCriticalErrDuringRestartBackoff(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any(), gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any(), gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+	// the randomness commitment keeps failing with a transient error
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection refused")).AnyTimes()
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.PollerConfig.AutoChainScanningMode = false
+	fpCfg.PollerConfig.StaticChainScanningStartHeight = randomStartingHeight
+	fpCfg.RandomnessCommitInterval = 10 * time.Millisecond
+	fpCfg.RetryConfig.PubRandCommit.Attempts = 1
+	fpCfg.SupervisorConfig.InitialBackoff = time.Hour
+	fpCfg.SupervisorConfig.MaxBackoff = time.Hour
+
+	app, fpPk, cleanUp := startFPAppWithRegisteredFp(t, r, fpHomeDir, &fpCfg, mockClientController)
+	defer cleanUp()
+	err := app.StartFinalityProvider(fpPk, passphrase)
+	require.NoError(t, err)
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+
+	// the instance is stopped and its restart is pending for the backoff
+	require.Eventually(t, func() bool {
+		return !fpIns.IsRunning()
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	// the critical error of the instance started by other means is handled
+	// without waiting for the backoff, once the loops of the stopped
+	// instance have exited
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, fpIns.Start())
+	require.Eventually(t, func() bool {
+		return !fpIns.IsRunning()
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+}
+
 func FuzzSaveAlreadyRegisteredFinalityProvider(f *testing.F) {
 	testutil.AddRandomSeedsToFuzzer(f, 10)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -298,7 +596,7 @@ func FuzzSaveAlreadyRegisteredFinalityProvider(f *testing.F) {
 		var eotsPk *bbntypes.BIP340PubKey
 		eotsKeyName := testutil.GenRandomHexStr(r, 4)
 		require.NoError(t, err)
-		eotsPkBz, err := em.CreateKey(eotsKeyName, passphrase, hdPath)
+		eotsPkBz, err := em.CreateKey(context.Background(), eotsKeyName, passphrase, hdPath)
 		require.NoError(t, err)
 		eotsPk, err = bbntypes.NewBIP340PubKey(eotsPkBz)
 		require.NoError(t, err)
@@ -323,9 +621,9 @@ func FuzzSaveAlreadyRegisteredFinalityProvider(f *testing.F) {
 			HighestVotedHeight:   rndFp.HighestVotedHeight,
 		}}
 
-		mockClientController.EXPECT().QueryFinalityProvider(gomock.Any()).Return(fpRes, nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProvider(gomock.Any(), gomock.Any()).Return(fpRes, nil).AnyTimes()
 
-		res, err := app.CreateFinalityProvider(keyName, chainID, passphrase, eotsPk, testutil.RandomDescription(r), testutil.ZeroCommissionRate())
+		res, err := app.CreateFinalityProvider(context.Background(), keyName, chainID, passphrase, eotsPk, testutil.RandomDescription(r), testutil.ZeroCommissionRate())
 		require.NoError(t, err)
 		require.Equal(t, res.FpInfo.BtcPkHex, eotsPk.MarshalHex())
 
@@ -335,6 +633,123 @@ func FuzzSaveAlreadyRegisteredFinalityProvider(f *testing.F) {
 	})
 }
 
+func TestSimulatedChain(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+
+	fpCfg := newSimulatedChainFpConfig(t, simCfg)
+	app, _ := startFPAppOnSimulatedChain(t, r, fpCfg, simChain, nil)
+
+	// the finality provider commits randomness and finalizes blocks by itself
+	require.Eventually(t, func() bool {
+		blocks, err := simChain.QueryLatestFinalizedBlocks(context.Background(), 1)
+		require.NoError(t, err)
+
+		return len(blocks) == 1 && blocks[0].Height >= 5
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	require.Equal(t, proto.FinalityProviderStatus_ACTIVE, fpIns.GetStatus())
+	require.Positive(t, fpIns.GetLastVotedHeight())
+}
+
+// newSimulatedChainFpConfig returns the config for a finality provider to
+// quickly start voting on a simulated chain
+func newSimulatedChainFpConfig(t *testing.T, simCfg *simulation.Config) *config.Config {
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.NumPubRand = uint32(simCfg.MinPubRand)
+	fpCfg.TimestampingDelayBlocks = 0
+	fpCfg.RandomnessCommitInterval = simCfg.BlockInterval
+	fpCfg.SignatureSubmissionInterval = simCfg.BlockInterval
+	fpCfg.RetryConfig.VoteSubmission.BaseDelay = simCfg.BlockInterval
+	fpCfg.RetryConfig.VoteSubmission.MaxDelay = simCfg.BlockInterval
+	fpCfg.PollerConfig.PollInterval = simCfg.BlockInterval
+
+	return &fpCfg
+}
+
+// startFPAppOnSimulatedChain starts an app and registers a finality provider
+// on the simulated chain, or a client controller wrapping it, which starts
+// the finality provider instance. The calls to the chain and the EOTS manager
+// go through the injector if given
+func startFPAppOnSimulatedChain(
+	t *testing.T,
+	r *rand.Rand,
+	fpCfg *config.Config,
+	simChain clientcontroller.ClientController,
+	in *faults.Injector,
+) (*service.FinalityProviderApp, *bbntypes.BIP340PubKey) {
+	localEm := newLocalEOTSManager(t)
+
+	var (
+		cc clientcontroller.ClientController = simChain
+		em eotsmanager.EOTSManager           = localEm
+	)
+	if in != nil {
+		cc = faults.NewClientController(cc, in)
+		em = faults.NewEOTSManager(em, in)
+	}
+
+	app := newFPApp(t, fpCfg, cc, em)
+
+	return app, registerFpOnSimulatedChain(t, r, app, localEm)
+}
+
+// registerFpOnSimulatedChain registers a finality provider with a new EOTS
+// key, which starts the finality provider instance
+func registerFpOnSimulatedChain(t *testing.T, r *rand.Rand, app *service.FinalityProviderApp, em eotsmanager.EOTSManager) *bbntypes.BIP340PubKey {
+	fpCfg := app.GetConfig()
+	eotsPkBz, err := em.CreateKey(context.Background(), testutil.GenRandomHexStr(r, 4), passphrase, hdPath)
+	require.NoError(t, err)
+	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
+	require.NoError(t, err)
+	keyName := testutil.GenRandomHexStr(r, 4)
+	_, err = testutil.CreateChainKey(fpCfg.BabylonConfig.KeyDirectory, fpCfg.BabylonConfig.ChainID, keyName, sdkkeyring.BackendTest, passphrase, hdPath, "")
+	require.NoError(t, err)
+	_, err = app.CreateFinalityProvider(context.Background(), keyName, fpCfg.BabylonConfig.ChainID, passphrase, eotsPk,
+		testutil.RandomDescription(r), testutil.ZeroCommissionRate())
+	require.NoError(t, err)
+
+	return eotsPk
+}
+
+func newLocalEOTSManager(t *testing.T) *eotsmanager.LocalEOTSManager {
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, eotsdb.Close())
+	})
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+
+	return em
+}
+
+// newFPApp starts an app, which is stopped when the test ends
+func newFPApp(t *testing.T, fpCfg *config.Config, cc clientcontroller.ClientController, em eotsmanager.EOTSManager) *service.FinalityProviderApp {
+	fpdb, err := fpCfg.DatabaseConfig.GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, fpdb.Close())
+	})
+	app, err := service.NewFinalityProviderApp(fpCfg, cc, em, fpdb, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, app.Stop())
+	})
+
+	return app
+}
+
 func startFPAppWithRegisteredFp(t *testing.T, r *rand.Rand, homePath string, cfg *config.Config, cc clientcontroller.ClientController) (*service.FinalityProviderApp, *bbntypes.BIP340PubKey, func()) {
 	logger := testutil.GetTestLogger(t)
 	// create an EOTS manager
@@ -369,7 +784,7 @@ func startFPAppWithRegisteredFp(t *testing.T, r *rand.Rand, homePath string, cfg
 	require.NoError(t, err)
 	kc, err := keyring.NewChainKeyringControllerWithKeyring(kr, keyName, input)
 	require.NoError(t, err)
-	btcPkBytes, err := em.CreateKey(keyName, passphrase, hdPath)
+	btcPkBytes, err := em.CreateKey(context.Background(), keyName, passphrase, hdPath)
 	require.NoError(t, err)
 	btcPk, err := bbntypes.NewBIP340PubKey(btcPkBytes)
 	require.NoError(t, err)
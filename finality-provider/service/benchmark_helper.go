@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -17,8 +18,8 @@ type CommitPubRandTiming struct {
 }
 
 // HelperCommitPubRand used for benchmark
-func (fp *FinalityProviderInstance) HelperCommitPubRand(tipHeight uint64) (*types.TxResponse, *CommitPubRandTiming, error) {
-	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+func (fp *FinalityProviderInstance) HelperCommitPubRand(ctx context.Context, tipHeight uint64) (*types.TxResponse, *CommitPubRandTiming, error) {
+	lastCommittedHeight, err := fp.GetLastCommittedHeight(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -43,13 +44,13 @@ func (fp *FinalityProviderInstance) HelperCommitPubRand(tipHeight uint64) (*type
 		return nil, nil, nil
 	}
 
-	return fp.commitPubRandPairsWithTiming(startHeight)
+	return fp.commitPubRandPairsWithTiming(ctx, startHeight)
 }
 
-func (fp *FinalityProviderInstance) commitPubRandPairsWithTiming(startHeight uint64) (*types.TxResponse, *CommitPubRandTiming, error) {
+func (fp *FinalityProviderInstance) commitPubRandPairsWithTiming(ctx context.Context, startHeight uint64) (*types.TxResponse, *CommitPubRandTiming, error) {
 	timing := &CommitPubRandTiming{}
 
-	activationBlkHeight, err := fp.cc.QueryFinalityActivationBlockHeight()
+	activationBlkHeight, err := fp.cc.QueryFinalityActivationBlockHeight(ctx)
 	if err != nil {
 		return nil, timing, err
 	}
@@ -58,7 +59,7 @@ func (fp *FinalityProviderInstance) commitPubRandPairsWithTiming(startHeight uin
 
 	// Measure getPubRandList
 	pubRandListStart := time.Now()
-	pubRandList, err := fp.getPubRandList(startHeight, fp.cfg.NumPubRand)
+	pubRandList, err := fp.getPubRandList(ctx, startHeight, fp.numPubRand.Load())
 	if err != nil {
 		return nil, timing, fmt.Errorf("failed to generate randomness: %w", err)
 	}
@@ -69,19 +70,19 @@ func (fp *FinalityProviderInstance) commitPubRandPairsWithTiming(startHeight uin
 
 	// Measure addPubRandProofList
 	addProofStart := time.Now()
-	if err := fp.pubRandState.addPubRandProofList(fp.GetChainID(), fp.btcPk.MustMarshal(), startHeight, uint64(fp.cfg.NumPubRand), proofList); err != nil {
+	if err := fp.pubRandState.addPubRandProofList(fp.GetChainID(), fp.btcPk.MustMarshal(), startHeight, numPubRand, proofList); err != nil {
 		return nil, timing, fmt.Errorf("failed to save public randomness to DB: %w", err)
 	}
 	timing.AddPubRandProofListTime = time.Since(addProofStart)
 
 	// Measure CommitPubRandList
 	commitListStart := time.Now()
-	schnorrSig, err := fp.signPubRandCommit(startHeight, numPubRand, commitment)
+	schnorrSig, err := fp.signPubRandCommit(ctx, startHeight, numPubRand, commitment)
 	if err != nil {
 		return nil, timing, fmt.Errorf("failed to sign the Schnorr signature: %w", err)
 	}
 
-	res, err := fp.cc.CommitPubRandList(fp.GetBtcPk(), startHeight, numPubRand, commitment, schnorrSig)
+	res, err := fp.cc.CommitPubRandList(ctx, fp.GetBtcPk(), startHeight, numPubRand, commitment, schnorrSig)
 	if err != nil {
 		return nil, timing, fmt.Errorf("failed to commit public randomness to the consumer chain: %w", err)
 	}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -12,15 +13,22 @@ import (
 	"github.com/babylonlabs-io/finality-provider/clientcontroller"
 	cfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
 
-var (
-	RtyAttNum = uint(5)
-	RtyAtt    = retry.Attempts(RtyAttNum)
-	RtyDel    = retry.Delay(time.Millisecond * 400)
-	RtyErr    = retry.LastErrorOnly(true)
-)
+// retryOptions returns the options of retry.Do following the given policy
+func retryOptions(ctx context.Context, policy *cfg.RetryPolicy, onRetry retry.OnRetryFunc) []retry.Option {
+	return []retry.Option{
+		retry.Context(ctx),
+		retry.Attempts(uint(policy.Attempts)),
+		retry.DelayType(func(n uint, _ error, _ *retry.Config) time.Duration {
+			return policy.Delay(n)
+		}),
+		retry.LastErrorOnly(true),
+		retry.OnRetry(onRetry),
+	}
+}
 
 const (
 	maxFailedCycles = 20
@@ -42,16 +50,20 @@ type ChainPoller struct {
 
 	cc             clientcontroller.ClientController
 	cfg            *cfg.ChainPollerConfig
+	retryPolicy    *cfg.RetryPolicy
+	pollInterval   *atomic.Duration
 	metrics        *metrics.FpMetrics
 	blockInfoChan  chan *types.BlockInfo
 	skipHeightChan chan *skipHeightRequest
-	nextHeight     uint64
+	errChan        chan error
+	nextHeight     *atomic.Uint64
 	logger         *zap.Logger
 }
 
 func NewChainPoller(
 	logger *zap.Logger,
 	cfg *cfg.ChainPollerConfig,
+	retryPolicy *cfg.RetryPolicy,
 	cc clientcontroller.ClientController,
 	metrics *metrics.FpMetrics,
 ) *ChainPoller {
@@ -59,10 +71,14 @@ func NewChainPoller(
 		isStarted:      atomic.NewBool(false),
 		logger:         logger,
 		cfg:            cfg,
+		retryPolicy:    retryPolicy,
+		pollInterval:   atomic.NewDuration(cfg.PollInterval),
 		cc:             cc,
 		metrics:        metrics,
 		blockInfoChan:  make(chan *types.BlockInfo, cfg.BufferSize),
 		skipHeightChan: make(chan *skipHeightRequest),
+		errChan:        make(chan error, 1),
+		nextHeight:     atomic.NewUint64(0),
 		quit:           make(chan struct{}),
 	}
 }
@@ -74,7 +90,7 @@ func (cp *ChainPoller) Start(startHeight uint64) error {
 
 	cp.logger.Info("starting the chain poller")
 
-	cp.nextHeight = startHeight
+	cp.nextHeight.Store(startHeight)
 
 	cp.wg.Add(1)
 
@@ -92,10 +108,6 @@ func (cp *ChainPoller) Stop() error {
 	}
 
 	cp.logger.Info("stopping the chain poller")
-	err := cp.cc.Close()
-	if err != nil {
-		return err
-	}
 	close(cp.quit)
 	cp.wg.Wait()
 
@@ -113,27 +125,33 @@ func (cp *ChainPoller) GetBlockInfoChan() <-chan *types.BlockInfo {
 	return cp.blockInfoChan
 }
 
-func (cp *ChainPoller) blockWithRetry(height uint64) (*types.BlockInfo, error) {
+// GetErrChan returns the read-only channel for the error that stopped
+// the poller from retrieving blocks
+func (cp *ChainPoller) GetErrChan() <-chan error {
+	return cp.errChan
+}
+
+func (cp *ChainPoller) blockWithRetry(ctx context.Context, height uint64) (*types.BlockInfo, error) {
 	var (
 		block *types.BlockInfo
 		err   error
 	)
 	if err := retry.Do(func() error {
-		block, err = cp.cc.QueryBlock(height)
+		block, err = cp.cc.QueryBlock(ctx, height)
 		if err != nil {
 			return err
 		}
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, cp.retryPolicy, func(n uint, err error) {
 		cp.logger.Debug(
 			"failed to query the consumer chain for the latest block",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", cp.retryPolicy.Attempts),
 			zap.Uint64("height", height),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return nil, err
 	}
 
@@ -141,21 +159,21 @@ func (cp *ChainPoller) blockWithRetry(height uint64) (*types.BlockInfo, error) {
 }
 
 // waitForActivation waits until BTC staking is activated
-func (cp *ChainPoller) waitForActivation() {
+func (cp *ChainPoller) waitForActivation(ctx context.Context) {
 	// ensure that the startHeight is no lower than the activated height
 	for {
-		activatedHeight, err := cp.cc.QueryActivatedHeight()
+		activatedHeight, err := cp.cc.QueryActivatedHeight(ctx)
 		if err != nil {
 			cp.logger.Debug("failed to query the consumer chain for the activated height", zap.Error(err))
 		} else {
-			if cp.nextHeight < activatedHeight {
-				cp.nextHeight = activatedHeight
+			if cp.nextHeight.Load() < activatedHeight {
+				cp.nextHeight.Store(activatedHeight)
 			}
 
 			return
 		}
 		select {
-		case <-time.After(cp.cfg.PollInterval):
+		case <-time.After(cp.pollInterval.Load()):
 			continue
 		case <-cp.quit:
 			return
@@ -166,15 +184,24 @@ func (cp *ChainPoller) waitForActivation() {
 func (cp *ChainPoller) pollChain() {
 	defer cp.wg.Done()
 
-	cp.waitForActivation()
+	ctx, cancel := contextWithQuit(context.Background(), cp.quit)
+	defer cancel()
 
-	var failedCycles uint32
+	cp.waitForActivation(ctx)
+
+	var (
+		failedCycles uint32
+		lastErr      error
+	)
 
 	for {
 		// start polling in the first iteration
-		blockToRetrieve := cp.nextHeight
-		block, err := cp.blockWithRetry(blockToRetrieve)
+		blockToRetrieve := cp.nextHeight.Load()
+		pollCtx, span := tracing.Start(ctx, "ChainPoller.pollBlock", tracing.Uint64("height", blockToRetrieve))
+		block, err := cp.blockWithRetry(pollCtx, blockToRetrieve)
+		tracing.End(span, err)
 		if err != nil {
+			lastErr = err
 			failedCycles++
 			cp.logger.Debug(
 				"failed to query the consumer chain for the block",
@@ -185,8 +212,9 @@ func (cp *ChainPoller) pollChain() {
 		} else {
 			// no error and we got the header we wanted to get, bump the state and push
 			// notification about data
-			cp.nextHeight = blockToRetrieve + 1
+			cp.nextHeight.Store(blockToRetrieve + 1)
 			failedCycles = 0
+			block.SeenAt = time.Now()
 			cp.metrics.RecordLastPolledHeight(block.Height)
 
 			cp.logger.Info("the poller retrieved the block from the consumer chain",
@@ -199,20 +227,27 @@ func (cp *ChainPoller) pollChain() {
 		}
 
 		if failedCycles > maxFailedCycles {
-			cp.logger.Fatal("the poller has reached the max failed cycles, exiting")
+			cp.logger.Error("the poller has reached the max failed cycles, stop polling",
+				zap.Uint64("block_to_retrieve", blockToRetrieve),
+				zap.Error(lastErr),
+			)
+			// the buffered channel is written only once before exiting
+			cp.errChan <- fmt.Errorf("%w: %w", ErrChainPollerMaxFailedCycles, lastErr)
+
+			return
 		}
 		select {
-		case <-time.After(cp.cfg.PollInterval):
+		case <-time.After(cp.pollInterval.Load()):
 			continue
 		case req := <-cp.skipHeightChan:
 			// no need to skip heights if the target height is not higher
 			// than the next height to retrieve
 			targetHeight := req.height
-			if targetHeight <= cp.nextHeight {
+			if nextHeight := cp.nextHeight.Load(); targetHeight <= nextHeight {
 				resp := &skipHeightResponse{
 					err: fmt.Errorf(
 						"the target height %d is not higher than the next height %d to retrieve",
-						targetHeight, cp.nextHeight)}
+						targetHeight, nextHeight)}
 				req.resp <- resp
 
 				continue
@@ -222,7 +257,7 @@ func (cp *ChainPoller) pollChain() {
 			cp.clearChanBufferUpToHeight(targetHeight)
 
 			// set the next height to the skip height
-			cp.nextHeight = targetHeight
+			cp.nextHeight.Store(targetHeight)
 
 			cp.logger.Debug("the poller has skipped height(s)",
 				zap.Uint64("next_height", req.height))
@@ -261,7 +296,13 @@ func (cp *ChainPoller) SkipToHeight(height uint64) error {
 }
 
 func (cp *ChainPoller) NextHeight() uint64 {
-	return cp.nextHeight
+	return cp.nextHeight.Load()
+}
+
+// SetPollInterval changes the interval between each polling of blocks, which
+// is applied from the next polling
+func (cp *ChainPoller) SetPollInterval(interval time.Duration) {
+	cp.pollInterval.Store(interval)
 }
 
 func (cp *ChainPoller) clearChanBufferUpToHeight(upToHeight uint64) {
@@ -31,24 +31,25 @@ func FuzzChainPoller_Start(f *testing.F) {
 		ctl := gomock.NewController(t)
 		mockClientController := mocks.NewMockClientController(ctl)
 		mockClientController.EXPECT().Close().Return(nil).AnyTimes()
-		mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+		mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(uint64(1), nil).AnyTimes()
 
 		currentBlockRes := &types.BlockInfo{
 			Height: currentHeight,
 		}
-		mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
 
 		for i := startHeight; i <= endHeight; i++ {
 			resBlock := &types.BlockInfo{
 				Height: i,
 			}
-			mockClientController.EXPECT().QueryBlock(i).Return(resBlock, nil).AnyTimes()
+			mockClientController.EXPECT().QueryBlock(gomock.Any(), i).Return(resBlock, nil).AnyTimes()
 		}
 
 		m := metrics.NewFpMetrics()
 		pollerCfg := fpcfg.DefaultChainPollerConfig()
+		retryCfg := fpcfg.DefaultRetryConfig()
 		pollerCfg.PollInterval = 10 * time.Millisecond
-		poller := service.NewChainPoller(testutil.GetTestLogger(t), &pollerCfg, mockClientController, m)
+		poller := service.NewChainPoller(testutil.GetTestLogger(t), &pollerCfg, retryCfg.BlockPolling, mockClientController, m)
 		err := poller.Start(startHeight)
 		require.NoError(t, err)
 		defer func() {
@@ -82,24 +83,25 @@ func FuzzChainPoller_SkipHeight(f *testing.F) {
 		ctl := gomock.NewController(t)
 		mockClientController := mocks.NewMockClientController(ctl)
 		mockClientController.EXPECT().Close().Return(nil).AnyTimes()
-		mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+		mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(uint64(1), nil).AnyTimes()
 
 		currentBlockRes := &types.BlockInfo{
 			Height: currentHeight,
 		}
-		mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
 
 		for i := startHeight; i <= skipHeight; i++ {
 			resBlock := &types.BlockInfo{
 				Height: i,
 			}
-			mockClientController.EXPECT().QueryBlock(i).Return(resBlock, nil).AnyTimes()
+			mockClientController.EXPECT().QueryBlock(gomock.Any(), i).Return(resBlock, nil).AnyTimes()
 		}
 
 		m := metrics.NewFpMetrics()
 		pollerCfg := fpcfg.DefaultChainPollerConfig()
+		retryCfg := fpcfg.DefaultRetryConfig()
 		pollerCfg.PollInterval = 1 * time.Second
-		poller := service.NewChainPoller(testutil.GetTestLogger(t), &pollerCfg, mockClientController, m)
+		poller := service.NewChainPoller(testutil.GetTestLogger(t), &pollerCfg, retryCfg.BlockPolling, mockClientController, m)
 		// should expect error if the poller is not started
 		err := poller.SkipToHeight(skipHeight)
 		require.Error(t, err)
@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	sdkmath "cosmossdk.io/math"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
@@ -154,3 +156,57 @@ func (c *FinalityProviderServiceGRpcClient) UnsafeRemoveMerkleProof(
 
 	return nil
 }
+
+// SubscribeEvents - stream the events of the finality provider daemon
+func (c *FinalityProviderServiceGRpcClient) SubscribeEvents(
+	ctx context.Context, fpPk string, follow bool) (proto.FinalityProviders_SubscribeEventsClient, error) {
+	req := &proto.SubscribeEventsRequest{BtcPk: fpPk, Follow: follow}
+	stream, err := c.client.SubscribeEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// BackupDB - write a consistent snapshot of the database of the finality provider daemon to w
+func (c *FinalityProviderServiceGRpcClient) BackupDB(ctx context.Context, w io.Writer) error {
+	stream, err := c.client.BackupDB(ctx, &proto.BackupDBRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(res.Chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// ReloadConfig - reload the config file of the finality provider daemon
+func (c *FinalityProviderServiceGRpcClient) ReloadConfig(ctx context.Context) (*proto.ReloadConfigResponse, error) {
+	res, err := c.client.ReloadConfig(ctx, &proto.ReloadConfigRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetLogLevel - set the logging level of a subsystem of the finality provider
+// daemon, or the one of its root logger if the subsystem is empty
+func (c *FinalityProviderServiceGRpcClient) SetLogLevel(ctx context.Context, subsystem, level string) (*proto.SetLogLevelResponse, error) {
+	res, err := c.client.SetLogLevel(ctx, &proto.SetLogLevelRequest{Subsystem: subsystem, Level: level})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
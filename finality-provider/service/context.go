@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+)
+
+// contextWithQuit returns a copy of the parent context which is cancelled
+// once the given quit channel is closed, so that stopping a service aborts
+// its in-flight requests
+func contextWithQuit(parent context.Context, quit <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	go func() {
+		select {
+		case <-quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
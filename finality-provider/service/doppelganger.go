@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// checkDoppelganger observes the chain for the configured number of blocks
+// before the instance signs anything, and returns ErrDoppelgangerDetected if
+// the chain has votes missing from the local vote history or the highest
+// voted height of the finality provider increases meanwhile, i.e., another
+// signer is voting with the same key
+func (fp *FinalityProviderInstance) checkDoppelganger(ctx context.Context) error {
+	// the check is only run once per daemon start, as the in-flight votes of
+	// the instance land on the chain while it is restarted
+	if fp.doppelgangerChecked.Load() {
+		return nil
+	}
+
+	numBlocks := uint64(fp.cfg.DoppelgangerBlocks)
+
+	tipBlock, err := fp.getLatestBlockWithRetry(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the last block: %w", err)
+	}
+	initialVotedHeight, err := fp.highestVotedHeightWithRetry(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the highest voted height: %w", err)
+	}
+
+	// votes unknown to the local vote history are sent by another signer or
+	// before the local data is restored from an old backup, in which case
+	// the signing history of the EOTS manager cannot be trusted either
+	if lastVotedHeight := fp.GetLastVotedHeight(); initialVotedHeight > lastVotedHeight {
+		return fmt.Errorf("%w: the highest voted height %d on the chain is above the last voted height %d in the local vote history",
+			ErrDoppelgangerDetected, initialVotedHeight, lastVotedHeight)
+	}
+
+	targetHeight := tipBlock.Height + numBlocks
+	fp.logger.Info("observing the chain for votes from another signer before signing",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("tip_height", tipBlock.Height),
+		zap.Uint64("target_height", targetHeight),
+	)
+
+	for tipBlock.Height < targetHeight {
+		select {
+		case <-time.After(fp.pollInterval.Load()):
+		case <-ctx.Done():
+			return ErrFinalityProviderShutDown
+		}
+
+		tipBlock, err = fp.getLatestBlockWithRetry(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the last block: %w", err)
+		}
+
+		votedHeight, err := fp.highestVotedHeightWithRetry(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the highest voted height: %w", err)
+		}
+
+		// the instance has not voted yet, so the votes are from another signer
+		if votedHeight > initialVotedHeight {
+			return fmt.Errorf("%w: the highest voted height increased from %d to %d at height %d",
+				ErrDoppelgangerDetected, initialVotedHeight, votedHeight, tipBlock.Height)
+		}
+	}
+
+	fp.logger.Info("no votes from another signer are observed, start signing",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("tip_height", tipBlock.Height),
+	)
+	fp.doppelgangerChecked.Store(true)
+
+	return nil
+}
+
+// startLoopsAfterDoppelgangerCheck starts the submission loops once no other
+// signer is detected, and reports a critical error otherwise
+func (fp *FinalityProviderInstance) startLoopsAfterDoppelgangerCheck() {
+	defer fp.wg.Done()
+
+	ctx, cancel := contextWithQuit(context.Background(), fp.quit)
+	defer cancel()
+
+	if err := fp.checkDoppelganger(ctx); err != nil {
+		if ctx.Err() == nil {
+			fp.reportCriticalErr(err)
+		}
+
+		return
+	}
+
+	fp.wg.Add(2)
+	go fp.finalitySigSubmissionLoop()
+	go fp.randomnessCommitmentLoop()
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestCheckDoppelganger(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name         string
+		votedHeights []uint64
+		expDetected  bool
+	}{
+		{"no other signer", []uint64{50, 50, 50, 50}, false},
+		{"votes unknown to the local history", []uint64{80, 80, 80, 80}, true},
+		{"other signer", []uint64{50, 50, 51, 52}, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := rand.New(rand.NewSource(time.Now().UnixNano()))
+			_, btcPk, err := datagen.GenRandomBTCKeyPair(r)
+			require.NoError(t, err)
+
+			cfg := fpcfg.DefaultConfig()
+			cfg.DoppelgangerBlocks = 3
+			cfg.PollerConfig.PollInterval = time.Millisecond
+
+			// the tip advances by one block upon each query
+			ctl := gomock.NewController(t)
+			cc := mocks.NewMockClientController(ctl)
+			tipHeight := uint64(100)
+			cc.EXPECT().QueryBestBlock(gomock.Any()).DoAndReturn(func(_ context.Context) (*types.BlockInfo, error) {
+				tipHeight++
+
+				return &types.BlockInfo{Height: tipHeight}, nil
+			}).AnyTimes()
+			queries := 0
+			cc.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), btcPk).DoAndReturn(func(_ context.Context, _ any) (uint64, error) {
+				h := tc.votedHeights[min(queries, len(tc.votedHeights)-1)]
+				queries++
+
+				return h, nil
+			}).AnyTimes()
+
+			fp := &FinalityProviderInstance{
+				btcPk:        bbntypes.NewBIP340PubKeyFromBTCPK(btcPk),
+				fpState:      newFpState(&store.StoredFinalityProvider{BtcPk: btcPk, LastVotedHeight: 50}, nil),
+				cfg:          &cfg,
+				pollInterval: atomic.NewDuration(cfg.PollerConfig.PollInterval),
+				cc:           cc,
+				metrics:      metrics.NewFpMetrics(),
+				logger:       zap.NewNop(),
+
+				poller:              atomic.NewPointer[ChainPoller](nil),
+				doppelgangerChecked: atomic.NewBool(false),
+			}
+
+			err = fp.checkDoppelganger(context.Background())
+			if tc.expDetected {
+				require.ErrorIs(t, err, ErrDoppelgangerDetected)
+
+				return
+			}
+			require.NoError(t, err)
+
+			// the check is skipped once passed, so that the votes of the
+			// instance itself are not detected after a restart
+			queries = 0
+			tc.votedHeights = []uint64{50, 51, 52}
+			require.NoError(t, fp.checkDoppelganger(context.Background()))
+			require.Zero(t, queries)
+		})
+	}
+}
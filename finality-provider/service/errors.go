@@ -22,4 +22,16 @@ var (
 	ErrFinalityProviderShutDown = errors.New("the finality provider instance is shutting down")
 	ErrFinalityProviderJailed   = errors.New("the finality provider instance is jailed")
 	ErrFinalityProviderSlashed  = errors.New("the finality provider instance is slashed")
+	// ErrDoppelgangerDetected is reported if another signer votes with the key of the finality provider
+	ErrDoppelgangerDetected = errors.New("another signer of the finality provider is detected")
+	// ErrShadowMode is returned for the requests that would broadcast a transaction in shadow mode
+	ErrShadowMode = errors.New("the finality provider daemon is running in shadow mode")
+	// ErrChainPollerMaxFailedCycles is reported by the poller once it gives up retrieving blocks
+	ErrChainPollerMaxFailedCycles = errors.New("the chain poller has reached the max failed cycles")
+	// ErrConfigReloadDisabled is returned for the requests to reload the config if it is not enabled
+	ErrConfigReloadDisabled = errors.New("the config reload is not enabled")
+	// ErrLeaseNotHeld is returned for the votes of a daemon that does not hold the lease
+	ErrLeaseNotHeld = errors.New("the lease is not held")
+	// ErrLogLevelsDisabled is returned for the requests to set a logging level if the levels cannot be changed
+	ErrLogLevelsDisabled = errors.New("the logging levels cannot be changed")
 )
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -12,10 +13,13 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"go.uber.org/zap"
 
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/types"
 )
 
 type CreateFinalityProviderRequest struct {
+	ctx             context.Context
 	fpAddr          sdk.AccAddress
 	btcPubKey       *bbntypes.BIP340PubKey
 	pop             *btcstakingtypes.ProofOfPossessionBTC
@@ -35,6 +39,7 @@ type CreateFinalityProviderResult struct {
 }
 
 type UnjailFinalityProviderRequest struct {
+	ctx             context.Context
 	btcPubKey       *bbntypes.BIP340PubKey
 	errResponse     chan error
 	successResponse chan *UnjailFinalityProviderResponse
@@ -67,8 +72,8 @@ func (app *FinalityProviderApp) monitorCriticalErr() {
 
 				continue
 			}
-			app.logger.Fatal(instanceTerminatingMsg,
-				zap.String("pk", criticalErr.fpBtcPk.MarshalHex()), zap.Error(criticalErr.err))
+			app.notifier.Notify(notifier.KindCriticalError, fpi.GetBtcPkHex(), criticalErr.err.Error())
+			app.superviseCriticalErr(fpi, criticalErr.err)
 		case <-app.quit:
 			app.logger.Info("exiting monitor critical error loop")
 
@@ -85,7 +90,6 @@ func (app *FinalityProviderApp) registrationLoop() {
 		case req := <-app.createFinalityProviderRequestChan:
 			// we won't do any retries here to not block the loop for more important messages.
 			// Most probably it fails due so some user error so we just return the error to the user.
-			// TODO: need to start passing context here to be able to cancel the request in case of app quiting
 			popBytes, err := req.pop.Marshal()
 			if err != nil {
 				req.errResponse <- err
@@ -99,12 +103,16 @@ func (app *FinalityProviderApp) registrationLoop() {
 
 				continue
 			}
+			// the request is cancelled if either the caller gives up or the app is quiting
+			ctx, cancel := contextWithQuit(req.ctx, app.quit)
 			res, err := app.cc.RegisterFinalityProvider(
+				ctx,
 				req.btcPubKey.MustToBTCPK(),
 				popBytes,
 				req.commission,
 				desBytes,
 			)
+			cancel()
 
 			if err != nil {
 				app.logger.Error(
@@ -144,33 +152,10 @@ func (app *FinalityProviderApp) unjailFpLoop() {
 		select {
 		case req := <-app.unjailFinalityProviderRequestChan:
 			pkHex := req.btcPubKey.MarshalHex()
-			isSlashed, isJailed, err := app.cc.QueryFinalityProviderSlashedOrJailed(req.btcPubKey.MustToBTCPK())
+			ctx, cancel := contextWithQuit(req.ctx, app.quit)
+			res, err := app.checkAndUnjailFinalityProvider(ctx, req.btcPubKey)
+			cancel()
 			if err != nil {
-				req.errResponse <- fmt.Errorf("failed to query jailing status of the finality provider %s: %w", pkHex, err)
-
-				continue
-			}
-			if isSlashed {
-				req.errResponse <- fmt.Errorf("the finality provider %s is already slashed", pkHex)
-
-				continue
-			}
-			if !isJailed {
-				req.errResponse <- fmt.Errorf("the finality provider %s is not jailed", pkHex)
-
-				continue
-			}
-
-			res, err := app.cc.UnjailFinalityProvider(
-				req.btcPubKey.MustToBTCPK(),
-			)
-
-			if err != nil {
-				app.logger.Error(
-					"failed to unjail finality-provider",
-					zap.String("pk", req.btcPubKey.MarshalHex()),
-					zap.Error(err),
-				)
 				req.errResponse <- err
 
 				continue
@@ -178,7 +163,7 @@ func (app *FinalityProviderApp) unjailFpLoop() {
 
 			app.logger.Info(
 				"successfully unjailed finality-provider on babylon",
-				zap.String("btc_pk", req.btcPubKey.MarshalHex()),
+				zap.String("btc_pk", pkHex),
 				zap.String("txHash", res.TxHash),
 			)
 
@@ -198,6 +183,132 @@ func (app *FinalityProviderApp) unjailFpLoop() {
 	}
 }
 
+// checkAndUnjailFinalityProvider checks that the finality provider is jailed and
+// sends an unjail transaction to the consumer chain
+func (app *FinalityProviderApp) checkAndUnjailFinalityProvider(ctx context.Context, btcPubKey *bbntypes.BIP340PubKey) (*types.TxResponse, error) {
+	pkHex := btcPubKey.MarshalHex()
+	isSlashed, isJailed, err := app.cc.QueryFinalityProviderSlashedOrJailed(ctx, btcPubKey.MustToBTCPK())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jailing status of the finality provider %s: %w", pkHex, err)
+	}
+	if isSlashed {
+		return nil, fmt.Errorf("the finality provider %s is already slashed", pkHex)
+	}
+	if !isJailed {
+		return nil, fmt.Errorf("the finality provider %s is not jailed", pkHex)
+	}
+
+	res, err := app.cc.UnjailFinalityProvider(ctx, btcPubKey.MustToBTCPK())
+	if err != nil {
+		app.logger.Error(
+			"failed to unjail finality-provider",
+			zap.String("pk", pkHex),
+			zap.Error(err),
+		)
+
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// event loop for automatically unjailing the finality provider once its jail period elapses
+func (app *FinalityProviderApp) autoUnjailLoop() {
+	defer app.wg.Done()
+
+	ctx, cancel := contextWithQuit(context.Background(), app.quit)
+	defer cancel()
+
+	wait := app.config.AutoUnjailInterval
+	for {
+		select {
+		case <-time.After(wait):
+			wait = app.config.AutoUnjailInterval
+
+			fpi, err := app.GetFinalityProviderInstance()
+			if err != nil || !fpi.IsRunning() || !fpi.IsJailed() {
+				continue
+			}
+
+			remaining, err := app.autoUnjailFinalityProvider(ctx, fpi)
+			if err != nil {
+				app.logger.Warn("failed to automatically unjail the finality-provider",
+					zap.String("pk", fpi.GetBtcPkHex()), zap.Error(err))
+
+				continue
+			}
+			if remaining > 0 {
+				wait = remaining
+			}
+		case <-app.quit:
+			app.logger.Info("exiting auto unjailing fp loop")
+
+			return
+		}
+	}
+}
+
+// autoUnjailFinalityProvider sends an unjail request for the jailed finality provider
+// if its jail period has elapsed, otherwise it returns the remaining jail period
+func (app *FinalityProviderApp) autoUnjailFinalityProvider(ctx context.Context, fpi *FinalityProviderInstance) (time.Duration, error) {
+	pkHex := fpi.GetBtcPkHex()
+
+	jailedUntil, err := app.cc.QueryFinalityProviderJailedUntil(ctx, fpi.GetBtcPk())
+	if err != nil {
+		return 0, err
+	}
+	app.metrics.RecordFpJailedUntil(pkHex, jailedUntil)
+
+	if remaining := time.Until(jailedUntil); remaining > 0 {
+		app.logger.Info("waiting for the jail period of the finality-provider to elapse",
+			zap.String("pk", pkHex),
+			zap.Time("jailed_until", jailedUntil),
+			zap.Duration("remaining", remaining),
+		)
+
+		return remaining, nil
+	}
+
+	isSlashed, isJailed, err := app.cc.QueryFinalityProviderSlashedOrJailed(ctx, fpi.GetBtcPk())
+	if err != nil {
+		return 0, fmt.Errorf("failed to query jailing status: %w", err)
+	}
+	if isSlashed {
+		// the slashed instance is terminated by the critical error monitor
+		select {
+		case app.criticalErrChan <- &CriticalError{err: ErrFinalityProviderSlashed, fpBtcPk: fpi.GetBtcPkBIP340()}:
+		case <-app.quit:
+		}
+
+		return 0, nil
+	}
+	if !isJailed {
+		// the finality provider has been unjailed by other means
+		fpi.MustSetStatus(proto.FinalityProviderStatus_INACTIVE)
+		app.metrics.RecordFpStatus(pkHex, proto.FinalityProviderStatus_INACTIVE)
+		app.logger.Info("the finality-provider status is changed to INACTIVE",
+			zap.String("pk", pkHex),
+			zap.String("old_status", proto.FinalityProviderStatus_JAILED.String()),
+		)
+
+		return 0, nil
+	}
+
+	res, err := app.UnjailFinalityProvider(ctx, fpi.GetBtcPkBIP340())
+	if err != nil {
+		return 0, err
+	}
+
+	app.metrics.IncrementFpTotalAutoUnjails(pkHex)
+	app.logger.Info("the finality-provider is automatically unjailed and its status is changed to INACTIVE",
+		zap.String("pk", pkHex),
+		zap.String("old_status", proto.FinalityProviderStatus_JAILED.String()),
+		zap.String("tx_hash", res.TxHash),
+	)
+
+	return 0, nil
+}
+
 // event loop for metrics update
 func (app *FinalityProviderApp) metricsUpdateLoop() {
 	defer app.wg.Done()
@@ -210,8 +321,8 @@ func (app *FinalityProviderApp) metricsUpdateLoop() {
 	defer updateTicker.Stop()
 
 	for {
-		if app.fpIns != nil {
-			app.metrics.UpdateFpMetrics(app.fpIns.GetStoreFinalityProvider())
+		if fpi, err := app.GetFinalityProviderInstance(); err == nil {
+			app.metrics.UpdateFpMetrics(fpi.GetStoreFinalityProvider())
 		}
 		select {
 		case <-updateTicker.C:
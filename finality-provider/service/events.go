@@ -0,0 +1,176 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+const (
+	// eventHistorySize is the number of recent events kept for new subscribers
+	eventHistorySize = 100
+	// eventSubscriberBufferSize is the number of events buffered for each subscriber
+	eventSubscriberBufferSize = 100
+)
+
+// EventBus broadcasts the events of the finality provider daemon to its
+// subscribers and keeps the most recent ones
+type EventBus struct {
+	mu      sync.Mutex
+	recent  []*proto.FinalityProviderEvent
+	subs    map[uint64]chan *proto.FinalityProviderEvent
+	nextID  uint64
+	closed  bool
+	logger  *zap.Logger
+	dropped uint64
+}
+
+func NewEventBus(logger *zap.Logger) *EventBus {
+	return &EventBus{
+		subs:   make(map[uint64]chan *proto.FinalityProviderEvent),
+		logger: logger,
+	}
+}
+
+// Subscribe returns the recent events along with a channel of the events
+// published afterwards, which is closed once the subscription is cancelled
+// or the bus is closed
+func (b *EventBus) Subscribe() ([]*proto.FinalityProviderEvent, <-chan *proto.FinalityProviderEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	recent := make([]*proto.FinalityProviderEvent, len(b.recent))
+	copy(recent, b.recent)
+
+	ch := make(chan *proto.FinalityProviderEvent, eventSubscriberBufferSize)
+	if b.closed {
+		close(ch)
+
+		return recent, ch, func() {}
+	}
+
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = ch
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if ch, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(ch)
+		}
+	}
+
+	return recent, ch, cancel
+}
+
+// Publish sends the event to all the subscribers. The event is dropped for
+// the subscribers whose buffer is full so that a slow subscriber never
+// blocks the daemon
+func (b *EventBus) Publish(ev *proto.FinalityProviderEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	if len(b.recent) == eventHistorySize {
+		b.recent = b.recent[1:]
+	}
+	b.recent = append(b.recent, ev)
+
+	for id, ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+			b.dropped++
+			b.logger.Warn("dropping event for a slow subscriber",
+				zap.Uint64("subscriber_id", id),
+				zap.Uint64("total_dropped", b.dropped),
+			)
+		}
+	}
+}
+
+// Close ends all the subscriptions
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+
+	for id, ch := range b.subs {
+		delete(b.subs, id)
+		close(ch)
+	}
+}
+
+func newEvent(fpBtcPkHex string) *proto.FinalityProviderEvent {
+	return &proto.FinalityProviderEvent{
+		BtcPkHex:  fpBtcPkHex,
+		Timestamp: time.Now().UnixMilli(),
+	}
+}
+
+func newStatusChangedEvent(fpBtcPkHex string, oldStatus, newStatus string) *proto.FinalityProviderEvent {
+	ev := newEvent(fpBtcPkHex)
+	ev.Event = &proto.FinalityProviderEvent_StatusChanged{StatusChanged: &proto.StatusChangedEvent{
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+	}}
+
+	return ev
+}
+
+func newVotesSubmittedEvent(fpBtcPkHex string, startHeight, endHeight uint64, txHash string) *proto.FinalityProviderEvent {
+	ev := newEvent(fpBtcPkHex)
+	ev.Event = &proto.FinalityProviderEvent_VotesSubmitted{VotesSubmitted: &proto.VotesSubmittedEvent{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		TxHash:      txHash,
+	}}
+
+	return ev
+}
+
+func newVoteFailedEvent(fpBtcPkHex string, startHeight, endHeight uint64, err error) *proto.FinalityProviderEvent {
+	ev := newEvent(fpBtcPkHex)
+	ev.Event = &proto.FinalityProviderEvent_VoteFailed{VoteFailed: &proto.VoteFailedEvent{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Error:       err.Error(),
+	}}
+
+	return ev
+}
+
+func newPubRandCommittedEvent(fpBtcPkHex string, startHeight, numPubRand uint64, txHash string) *proto.FinalityProviderEvent {
+	ev := newEvent(fpBtcPkHex)
+	ev.Event = &proto.FinalityProviderEvent_PubRandCommitted{PubRandCommitted: &proto.PubRandCommittedEvent{
+		StartHeight: startHeight,
+		NumPubRand:  numPubRand,
+		TxHash:      txHash,
+	}}
+
+	return ev
+}
+
+func newPollerLagEvent(fpBtcPkHex string, tipHeight, nextHeight uint64) *proto.FinalityProviderEvent {
+	ev := newEvent(fpBtcPkHex)
+	ev.Event = &proto.FinalityProviderEvent_PollerLag{PollerLag: &proto.PollerLagEvent{
+		TipHeight:  tipHeight,
+		NextHeight: nextHeight,
+		Lag:        pollerLag(tipHeight, nextHeight),
+	}}
+
+	return ev
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+func TestEventBus(t *testing.T) {
+	t.Parallel()
+
+	bus := NewEventBus(zap.NewNop())
+	pkHex := "fp"
+
+	// the events published before subscribing are kept up to the history size
+	for i := uint64(0); i < eventHistorySize+1; i++ {
+		bus.Publish(newVotesSubmittedEvent(pkHex, i, i, ""))
+	}
+
+	recent, events, cancel := bus.Subscribe()
+	require.Len(t, recent, eventHistorySize)
+	require.Equal(t, uint64(1), recent[0].GetVotesSubmitted().StartHeight)
+	require.Equal(t, uint64(eventHistorySize), recent[eventHistorySize-1].GetVotesSubmitted().StartHeight)
+
+	// the events published afterwards are sent to the subscriber
+	bus.Publish(newStatusChangedEvent(pkHex, proto.FinalityProviderStatus_INACTIVE.String(), proto.FinalityProviderStatus_ACTIVE.String()))
+	ev := <-events
+	require.Equal(t, pkHex, ev.BtcPkHex)
+	require.Equal(t, proto.FinalityProviderStatus_ACTIVE.String(), ev.GetStatusChanged().NewStatus)
+
+	// a slow subscriber does not block publishing
+	for i := 0; i < eventSubscriberBufferSize+1; i++ {
+		bus.Publish(newVoteFailedEvent(pkHex, 1, 1, errors.New("failed")))
+	}
+	require.Len(t, events, eventSubscriberBufferSize)
+
+	// the channel is closed once the subscription is cancelled
+	cancel()
+	drained := 0
+	for range events {
+		drained++
+	}
+	require.Equal(t, eventSubscriberBufferSize, drained)
+
+	// the channels are closed once the bus is closed
+	_, events, _ = bus.Subscribe()
+	bus.Close()
+	_, ok := <-events
+	require.False(t, ok)
+
+	_, events, _ = bus.Subscribe()
+	_, ok = <-events
+	require.False(t, ok)
+}
+
+func TestPollerLagEvent(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, uint64(3), newPollerLagEvent("fp", 12, 10).GetPollerLag().Lag)
+	require.Equal(t, uint64(0), newPollerLagEvent("fp", 9, 10).GetPollerLag().Lag)
+}
@@ -0,0 +1,161 @@
+package service_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
+	"github.com/babylonlabs-io/finality-provider/faults"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+func newSimulatedChain(t *testing.T, cfg *simulation.Config) *simulation.Chain {
+	simChain, err := simulation.NewChain(cfg, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+	simChain.Start()
+	t.Cleanup(simChain.Stop)
+
+	return simChain
+}
+
+func newInjector(t *testing.T, rules ...*faults.Rule) *faults.Injector {
+	in, err := faults.NewInjector(&faults.Config{Seed: 1, Rules: rules}, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+
+	return in
+}
+
+func requireFinalizedHeight(t *testing.T, simChain *simulation.Chain, height uint64) {
+	require.Eventually(t, func() bool {
+		blocks, err := simChain.QueryLatestFinalizedBlocks(context.Background(), 1)
+		require.NoError(t, err)
+
+		return len(blocks) == 1 && blocks[0].Height >= height
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+}
+
+// TestFaultsRetrySubmission tests that the transient failures of signing and
+// submitting finality signatures are retried
+func TestFaultsRetrySubmission(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+
+	in := newInjector(t,
+		&faults.Rule{Target: faults.TargetClientController, Method: "SubmitBatchFinalitySigs", Count: 3, Error: "connection refused"},
+		&faults.Rule{Target: faults.TargetEOTSManager, Method: "SignEOTS", Count: 2, Latency: 10 * time.Millisecond, Error: "eots manager unavailable"},
+	)
+	app, _ := startFPAppOnSimulatedChain(t, r, newSimulatedChainFpConfig(t, simCfg), simChain, in)
+
+	requireFinalizedHeight(t, simChain, 5)
+	require.Equal(t, uint64(5), in.NumInjected())
+
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	require.True(t, fpIns.IsRunning())
+	require.Equal(t, proto.FinalityProviderStatus_ACTIVE, fpIns.GetStatus())
+}
+
+// TestFaultsJailAndUnjail tests that the finality provider is jailed for
+// missing blocks while the EOTS manager fails, and is automatically unjailed
+// to resume voting after the jail period
+func TestFaultsJailAndUnjail(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simCfg.FinalitySigTimeout = 3
+	simCfg.MaxMissedBlocks = 5
+	simCfg.JailDuration = 500 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+
+	fpCfg := newSimulatedChainFpConfig(t, simCfg)
+	// the submission is retried until the finality provider is jailed
+	fpCfg.RetryConfig.VoteSubmission.Attempts = 100
+	fpCfg.AutoUnjail = true
+	fpCfg.AutoUnjailInterval = 50 * time.Millisecond
+
+	in := newInjector(t)
+	app, fpPk := startFPAppOnSimulatedChain(t, r, fpCfg, simChain, in)
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastVotedHeight() > 0
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+
+	// the signing fails until the finality provider is jailed for missing
+	// blocks, and no failure is left to get it jailed again once unjailed.
+	// The jailing is observed on the chain, as the finality provider only
+	// finds out once its signatures are submitted again
+	require.NoError(t, in.AddRule(&faults.Rule{
+		Target: faults.TargetEOTSManager,
+		Method: "SignEOTS",
+		Error:  "eots manager unavailable",
+	}))
+	require.Eventually(t, func() bool {
+		_, jailed, err := simChain.QueryFinalityProviderSlashedOrJailed(context.Background(), fpPk.MustToBTCPK())
+		require.NoError(t, err)
+
+		return jailed
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+	in.ClearRules()
+	jailedVotedHeight, err := simChain.QueryFinalityProviderHighestVotedHeight(context.Background(), fpPk.MustToBTCPK())
+	require.NoError(t, err)
+
+	// the finality provider is unjailed and votes again
+	require.Eventually(t, func() bool {
+		_, jailed, err := simChain.QueryFinalityProviderSlashedOrJailed(context.Background(), fpPk.MustToBTCPK())
+		require.NoError(t, err)
+
+		return !jailed
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+	require.Eventually(t, func() bool {
+		votedHeight, err := simChain.QueryFinalityProviderHighestVotedHeight(context.Background(), fpPk.MustToBTCPK())
+		require.NoError(t, err)
+
+		return votedHeight > jailedVotedHeight
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+	require.Eventually(t, func() bool {
+		return fpIns.GetStatus() == proto.FinalityProviderStatus_ACTIVE
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+	require.True(t, fpIns.IsRunning())
+}
+
+// TestFaultsShutdownWhileHanging tests that the app stops while a call to the
+// consumer chain hangs
+func TestFaultsShutdownWhileHanging(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+
+	in := newInjector(t, &faults.Rule{Target: faults.TargetClientController, Method: "SubmitBatchFinalitySigs", Hang: true})
+	app, _ := startFPAppOnSimulatedChain(t, r, newSimulatedChainFpConfig(t, simCfg), simChain, in)
+
+	require.Eventually(t, func() bool {
+		return in.NumInjected() > 0
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- app.Stop()
+	}()
+	select {
+	case err := <-stopped:
+		require.NoError(t, err)
+	case <-time.After(eventuallyWaitTimeOut):
+		t.Fatal("the app did not stop while the submission hangs")
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -19,9 +20,12 @@ import (
 	"github.com/babylonlabs-io/finality-provider/clientcontroller"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/tracing"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
 
@@ -32,18 +36,38 @@ type FinalityProviderInstance struct {
 	pubRandState *pubRandState
 	cfg          *fpcfg.Config
 
-	logger  *zap.Logger
-	em      eotsmanager.EOTSManager
-	cc      clientcontroller.ClientController
-	poller  *ChainPoller
-	metrics *metrics.FpMetrics
+	// the settings that are reloaded while the instance is running
+	signatureSubmissionInterval *atomic.Duration
+	batchSubmissionSize         *atomic.Uint32
+	numPubRand                  *atomic.Uint32
+	pollInterval                *atomic.Duration
+
+	logger   *zap.Logger
+	em       eotsmanager.EOTSManager
+	cc       clientcontroller.ClientController
+	poller   *atomic.Pointer[ChainPoller]
+	metrics  *metrics.FpMetrics
+	events   *EventBus
+	notifier *notifier.Notifier
+
+	// the loggers of the finality signature submission loop and the
+	// randomness commitment loop, whose levels are set apart
+	submissionLogger *zap.Logger
+	randomnessLogger *zap.Logger
 
 	// passphrase is used to unlock private keys
 	passphrase string
 
 	criticalErrChan chan<- *CriticalError
 
+	// checkLease returns an error unless the daemon holds the lease shared
+	// with the standby daemons, and is nil if there is no lease
+	checkLease func(ctx context.Context) error
+
 	isStarted *atomic.Bool
+	// doppelgangerChecked is set once the doppelganger check passes, which
+	// is then skipped upon the restarts of the instance
+	doppelgangerChecked *atomic.Bool
 
 	wg   sync.WaitGroup
 	quit chan struct{}
@@ -59,6 +83,8 @@ func NewFinalityProviderInstance(
 	cc clientcontroller.ClientController,
 	em eotsmanager.EOTSManager,
 	metrics *metrics.FpMetrics,
+	events *EventBus,
+	notifier *notifier.Notifier,
 	passphrase string,
 	errChan chan<- *CriticalError,
 	logger *zap.Logger,
@@ -72,7 +98,7 @@ func NewFinalityProviderInstance(
 		return nil, fmt.Errorf("the finality provider instance is already slashed")
 	}
 
-	return newFinalityProviderInstanceFromStore(sfp, cfg, s, prStore, cc, em, metrics, passphrase, errChan, logger)
+	return newFinalityProviderInstanceFromStore(sfp, cfg, s, prStore, cc, em, metrics, events, notifier, passphrase, errChan, logger)
 }
 
 // Helper function to create FinalityProviderInstance from store data
@@ -84,36 +110,62 @@ func newFinalityProviderInstanceFromStore(
 	cc clientcontroller.ClientController,
 	em eotsmanager.EOTSManager,
 	metrics *metrics.FpMetrics,
+	events *EventBus,
+	notifier *notifier.Notifier,
 	passphrase string,
 	errChan chan<- *CriticalError,
 	logger *zap.Logger,
 ) (*FinalityProviderInstance, error) {
 	return &FinalityProviderInstance{
-		btcPk:           bbntypes.NewBIP340PubKeyFromBTCPK(sfp.BtcPk),
-		fpState:         newFpState(sfp, s),
-		pubRandState:    newPubRandState(prStore),
-		cfg:             cfg,
-		logger:          logger,
+		btcPk:        bbntypes.NewBIP340PubKeyFromBTCPK(sfp.BtcPk),
+		fpState:      newFpState(sfp, s),
+		pubRandState: newPubRandState(prStore),
+		cfg:          cfg,
+		logger:       logger,
+
+		submissionLogger: logger.Named(log.SubsystemSubmission),
+		randomnessLogger: logger.Named(log.SubsystemRandomness),
+
+		signatureSubmissionInterval: atomic.NewDuration(cfg.SignatureSubmissionInterval),
+		batchSubmissionSize:         atomic.NewUint32(cfg.BatchSubmissionSize),
+		numPubRand:                  atomic.NewUint32(cfg.NumPubRand),
+		pollInterval:                atomic.NewDuration(cfg.PollerConfig.PollInterval),
+
 		isStarted:       atomic.NewBool(false),
 		criticalErrChan: errChan,
 		passphrase:      passphrase,
 		em:              em,
 		cc:              cc,
 		metrics:         metrics,
+		events:          events,
+		notifier:        notifier,
+
+		poller:              atomic.NewPointer[ChainPoller](nil),
+		doppelgangerChecked: atomic.NewBool(false),
 	}, nil
 }
 
-func (fp *FinalityProviderInstance) Start() error {
+func (fp *FinalityProviderInstance) Start() (err error) {
 	if fp.isStarted.Swap(true) {
 		return fmt.Errorf("the finality-provider instance %s is already started", fp.GetBtcPkHex())
 	}
+	// allow the instance to be started again if it fails to start
+	defer func() {
+		if err != nil {
+			fp.isStarted.Store(false)
+		}
+	}()
 
 	if fp.IsJailed() {
 		fp.logger.Warn("the finality provider is jailed",
 			zap.String("pk", fp.GetBtcPkHex()))
 	}
 
-	startHeight, err := fp.DetermineStartHeight()
+	fp.quit = make(chan struct{})
+	ctx, cancel := contextWithQuit(context.Background(), fp.quit)
+	defer cancel()
+
+	startHeight, err := fp.DetermineStartHeight(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get the start height: %w", err)
 	}
@@ -121,14 +173,22 @@ func (fp *FinalityProviderInstance) Start() error {
 	fp.logger.Info("starting the finality provider instance",
 		zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", startHeight))
 
-	poller := NewChainPoller(fp.logger, fp.cfg.PollerConfig, fp.cc, fp.metrics)
+	poller := NewChainPoller(fp.logger.Named(log.SubsystemPoller), fp.cfg.PollerConfig, fp.cfg.RetryConfig.BlockPolling, fp.cc, fp.metrics)
+	poller.SetPollInterval(fp.pollInterval.Load())
 
 	if err := poller.Start(startHeight); err != nil {
 		return fmt.Errorf("failed to start the poller with start height %d: %w", startHeight, err)
 	}
 
-	fp.poller = poller
-	fp.quit = make(chan struct{})
+	fp.poller.Store(poller)
+
+	// the votes of the primary instance are expected in shadow mode
+	if fp.cfg.DoppelgangerBlocks > 0 && !fp.cfg.ShadowMode {
+		fp.wg.Add(1)
+		go fp.startLoopsAfterDoppelgangerCheck()
+
+		return nil
+	}
 
 	fp.wg.Add(2)
 	go fp.finalitySigSubmissionLoop()
@@ -142,8 +202,10 @@ func (fp *FinalityProviderInstance) Stop() error {
 		return fmt.Errorf("the finality-provider %s has already stopped", fp.GetBtcPkHex())
 	}
 
-	if err := fp.poller.Stop(); err != nil {
-		return fmt.Errorf("failed to stop the poller: %w", err)
+	if poller := fp.poller.Load(); poller != nil {
+		if err := poller.Stop(); err != nil {
+			return fmt.Errorf("failed to stop the poller: %w", err)
+		}
 	}
 
 	fp.logger.Info("stopping finality-provider instance", zap.String("pk", fp.GetBtcPkHex()))
@@ -156,6 +218,19 @@ func (fp *FinalityProviderInstance) Stop() error {
 	return nil
 }
 
+// applyReloadedConfig applies the reloadable settings of the given config to
+// the running instance
+func (fp *FinalityProviderInstance) applyReloadedConfig(cfg *fpcfg.Config) {
+	fp.signatureSubmissionInterval.Store(cfg.SignatureSubmissionInterval)
+	fp.batchSubmissionSize.Store(cfg.BatchSubmissionSize)
+	fp.numPubRand.Store(cfg.NumPubRand)
+	fp.pollInterval.Store(cfg.PollerConfig.PollInterval)
+
+	if poller := fp.poller.Load(); poller != nil {
+		poller.SetPollInterval(cfg.PollerConfig.PollInterval)
+	}
+}
+
 func (fp *FinalityProviderInstance) GetConfig() *fpcfg.Config {
 	return fp.cfg
 }
@@ -164,6 +239,16 @@ func (fp *FinalityProviderInstance) IsRunning() bool {
 	return fp.isStarted.Load()
 }
 
+// runningPoller returns the chain poller of the instance, or nil if it is not
+// running, e.g., while the instance is starting or after it is stopped
+func (fp *FinalityProviderInstance) runningPoller() *ChainPoller {
+	if poller := fp.poller.Load(); poller != nil && poller.IsRunning() {
+		return poller
+	}
+
+	return nil
+}
+
 // IsJailed returns true if fp is JAILED
 // NOTE: it retrieves the the status from the db to
 // ensure status is up-to-date
@@ -173,8 +258,9 @@ func (fp *FinalityProviderInstance) IsJailed() bool {
 		panic(fmt.Errorf("failed to retrieve the finality provider %s from db: %w", fp.GetBtcPkHex(), err))
 	}
 
+	// the status is changed by the app, which emits the status change event
 	if storedFp.Status != fp.GetStatus() {
-		fp.MustSetStatus(storedFp.Status)
+		fp.fpState.syncStatus(storedFp.Status)
 	}
 
 	return fp.GetStatus() == proto.FinalityProviderStatus_JAILED
@@ -183,17 +269,22 @@ func (fp *FinalityProviderInstance) IsJailed() bool {
 func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 	defer fp.wg.Done()
 
+	ctx, cancel := contextWithQuit(context.Background(), fp.quit)
+	defer cancel()
+
+	poller := fp.poller.Load()
+
 	for {
 		select {
-		case <-time.After(fp.cfg.SignatureSubmissionInterval):
+		case <-time.After(fp.signatureSubmissionInterval.Load()):
 			// start submission in the first iteration
-			pollerBlocks := fp.getBatchBlocksFromChan()
+			pollerBlocks := fp.getBatchBlocksFromChan(poller)
 			if len(pollerBlocks) == 0 {
 				continue
 			}
 
 			if fp.IsJailed() {
-				fp.logger.Warn("the finality-provider is jailed",
+				fp.submissionLogger.Warn("the finality-provider is jailed",
 					zap.String("pk", fp.GetBtcPkHex()),
 				)
 
@@ -201,29 +292,44 @@ func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 			}
 
 			targetHeight := pollerBlocks[len(pollerBlocks)-1].Height
-			fp.logger.Debug("the finality-provider received new block(s), start processing",
+			fp.submissionLogger.Debug("the finality-provider received new block(s), start processing",
 				zap.String("pk", fp.GetBtcPkHex()),
 				zap.Uint64("start_height", pollerBlocks[0].Height),
 				zap.Uint64("end_height", targetHeight),
 			)
 
-			processedBlocks, err := fp.processBlocksToVote(pollerBlocks)
+			// the stages of processing the blocks are traced as the children
+			// of the span of the batch
+			spanCtx, span := tracing.Start(ctx, "FinalityProviderInstance.submitFinalitySigs",
+				tracing.Uint64("start_height", pollerBlocks[0].Height), tracing.Uint64("end_height", targetHeight))
+
+			processedBlocks, err := fp.processBlocksToVote(spanCtx, pollerBlocks)
 			if err != nil {
+				tracing.End(span, err)
 				fp.reportCriticalErr(err)
 
 				continue
 			}
 
 			if len(processedBlocks) == 0 {
+				span.End()
+
 				continue
 			}
+			startHeight, endHeight := processedBlocks[0].Height, processedBlocks[len(processedBlocks)-1].Height
 
-			res, err := fp.retrySubmitSigsUntilFinalized(processedBlocks)
+			res, err := fp.retrySubmitSigsUntilFinalized(spanCtx, processedBlocks)
+			tracing.End(span, err)
 			if err != nil {
 				fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
+				if !errors.Is(err, ErrFinalityProviderShutDown) {
+					fp.events.Publish(newVoteFailedEvent(fp.GetBtcPkHex(), startHeight, endHeight, err))
+					fp.notifier.Notify(notifier.KindVoteFailed, fp.GetBtcPkHex(),
+						fmt.Sprintf("failed to submit finality signatures for blocks %d-%d: %v", startHeight, endHeight, err))
+				}
 				if errors.Is(err, ErrFinalityProviderJailed) {
 					fp.MustSetStatus(proto.FinalityProviderStatus_JAILED)
-					fp.logger.Debug("the finality-provider has been jailed",
+					fp.submissionLogger.Debug("the finality-provider has been jailed",
 						zap.String("pk", fp.GetBtcPkHex()))
 
 					continue
@@ -237,10 +343,10 @@ func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 			if res == nil {
 				// this can happen when a finality signature is not needed
 				// either if the block is already submitted or the signature
-				// is already submitted
+				// is already submitted, or in shadow mode
 				continue
 			}
-			fp.logger.Info(
+			fp.submissionLogger.Info(
 				"successfully submitted the finality signature to the consumer chain",
 				zap.String("consumer_id", string(fp.GetChainID())),
 				zap.String("pk", fp.GetBtcPkHex()),
@@ -248,8 +354,12 @@ func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 				zap.Uint64("end_height", targetHeight),
 				zap.String("tx_hash", res.TxHash),
 			)
+			fp.events.Publish(newVotesSubmittedEvent(fp.GetBtcPkHex(), startHeight, endHeight, res.TxHash))
+		case err := <-poller.GetErrChan():
+			// the poller has stopped, which is resolved by restarting the instance
+			fp.reportCriticalErr(err)
 		case <-fp.quit:
-			fp.logger.Info("the finality signature submission loop is closing")
+			fp.submissionLogger.Info("the finality signature submission loop is closing")
 
 			return
 		}
@@ -258,15 +368,17 @@ func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 
 // processBlocksToVote processes a batch a blocks and picks ones that need to vote
 // it also updates the fp instance status according to the block's voting power
-func (fp *FinalityProviderInstance) processBlocksToVote(blocks []*types.BlockInfo) ([]*types.BlockInfo, error) {
-	processedBlocks := make([]*types.BlockInfo, 0, len(blocks))
+func (fp *FinalityProviderInstance) processBlocksToVote(ctx context.Context, blocks []*types.BlockInfo) (processedBlocks []*types.BlockInfo, err error) {
+	ctx, span := tracing.Start(ctx, "FinalityProviderInstance.processBlocksToVote")
+	defer func() { tracing.End(span, err) }()
+
+	processedBlocks = make([]*types.BlockInfo, 0, len(blocks))
 
 	var power uint64
-	var err error
 	for _, b := range blocks {
 		blk := *b
 		if blk.Height <= fp.GetLastVotedHeight() {
-			fp.logger.Debug(
+			fp.submissionLogger.Debug(
 				"the block height is lower than last processed height",
 				zap.String("pk", fp.GetBtcPkHex()),
 				zap.Uint64("block_height", blk.Height),
@@ -277,12 +389,12 @@ func (fp *FinalityProviderInstance) processBlocksToVote(blocks []*types.BlockInf
 		}
 
 		// check whether the finality provider has voting power
-		power, err = fp.GetVotingPowerWithRetry(blk.Height)
+		power, err = fp.GetVotingPowerWithRetry(ctx, blk.Height)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get voting power for height %d: %w", blk.Height, err)
 		}
 		if power == 0 {
-			fp.logger.Debug(
+			fp.submissionLogger.Debug(
 				"the finality-provider does not have voting power",
 				zap.String("pk", fp.GetBtcPkHex()),
 				zap.Uint64("block_height", blk.Height),
@@ -310,13 +422,13 @@ func (fp *FinalityProviderInstance) processBlocksToVote(blocks []*types.BlockInf
 	return processedBlocks, nil
 }
 
-func (fp *FinalityProviderInstance) getBatchBlocksFromChan() []*types.BlockInfo {
+func (fp *FinalityProviderInstance) getBatchBlocksFromChan(poller *ChainPoller) []*types.BlockInfo {
 	var pollerBlocks []*types.BlockInfo
 	for {
 		select {
-		case b := <-fp.poller.GetBlockInfoChan():
+		case b := <-poller.GetBlockInfoChan():
 			pollerBlocks = append(pollerBlocks, b)
-			if len(pollerBlocks) == int(fp.cfg.BatchSubmissionSize) {
+			if len(pollerBlocks) == int(fp.batchSubmissionSize.Load()) {
 				return pollerBlocks
 			}
 		case <-fp.quit:
@@ -332,11 +444,14 @@ func (fp *FinalityProviderInstance) getBatchBlocksFromChan() []*types.BlockInfo
 func (fp *FinalityProviderInstance) randomnessCommitmentLoop() {
 	defer fp.wg.Done()
 
+	ctx, cancel := contextWithQuit(context.Background(), fp.quit)
+	defer cancel()
+
 	for {
 		select {
 		case <-time.After(fp.cfg.RandomnessCommitInterval):
 			// start randomness commit in the first iteration
-			should, startHeight, err := fp.ShouldCommitRandomness()
+			should, startHeight, err := fp.ShouldCommitRandomness(ctx)
 			if err != nil {
 				fp.reportCriticalErr(err)
 
@@ -346,23 +461,26 @@ func (fp *FinalityProviderInstance) randomnessCommitmentLoop() {
 				continue
 			}
 
-			txRes, err := fp.CommitPubRand(startHeight)
+			txRes, err := fp.CommitPubRand(ctx, startHeight)
 			if err != nil {
 				fp.metrics.IncrementFpTotalFailedRandomness(fp.GetBtcPkHex())
+				fp.notifier.Notify(notifier.KindRandomnessCommitFailed, fp.GetBtcPkHex(),
+					fmt.Sprintf("failed to commit public randomness from height %d: %v", startHeight, err))
 				fp.reportCriticalErr(err)
 
 				continue
 			}
 			// txRes could be nil if no need to commit more randomness
 			if txRes != nil {
-				fp.logger.Info(
+				fp.randomnessLogger.Info(
 					"successfully committed public randomness to the consumer chain",
 					zap.String("pk", fp.GetBtcPkHex()),
 					zap.String("tx_hash", txRes.TxHash),
 				)
+				fp.events.Publish(newPubRandCommittedEvent(fp.GetBtcPkHex(), startHeight, uint64(fp.numPubRand.Load()), txRes.TxHash))
 			}
 		case <-fp.quit:
-			fp.logger.Info("the randomness commitment loop is closing")
+			fp.randomnessLogger.Info("the randomness commitment loop is closing")
 
 			return
 		}
@@ -374,17 +492,18 @@ func (fp *FinalityProviderInstance) randomnessCommitmentLoop() {
 // to timestamping. Therefore, the start height of the commit should consider an
 // estimated delay.
 // If randomness should be committed, start height of the commit will be returned
-func (fp *FinalityProviderInstance) ShouldCommitRandomness() (bool, uint64, error) {
-	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+func (fp *FinalityProviderInstance) ShouldCommitRandomness(ctx context.Context) (bool, uint64, error) {
+	lastCommittedHeight, err := fp.GetLastCommittedHeight(ctx)
 	if err != nil {
 		return false, 0, fmt.Errorf("failed to get last committed height: %w", err)
 	}
 
-	tipBlock, err := fp.getLatestBlockWithRetry()
+	tipBlock, err := fp.getLatestBlockWithRetry(ctx)
 	if err != nil {
 		return false, 0, fmt.Errorf("failed to get the last block: %w", err)
 	}
 	tipHeight := tipBlock.Height
+	fp.metrics.RecordFpPubRandRunway(fp.GetBtcPkHex(), lastCommittedHeight, tipHeight)
 
 	tipHeightWithDelay := tipHeight + uint64(fp.cfg.TimestampingDelayBlocks)
 
@@ -394,11 +513,11 @@ func (fp *FinalityProviderInstance) ShouldCommitRandomness() (bool, uint64, erro
 		// the start height should consider the timestamping delay
 		// as it is only available to use after tip height + estimated timestamping delay
 		startHeight = tipHeightWithDelay
-	case lastCommittedHeight < tipHeightWithDelay+uint64(fp.cfg.NumPubRand):
+	case lastCommittedHeight < tipHeightWithDelay+uint64(fp.numPubRand.Load()):
 		startHeight = lastCommittedHeight + 1
 	default:
 		// the randomness is sufficient, no need to make another commit
-		fp.logger.Debug(
+		fp.randomnessLogger.Debug(
 			"the finality-provider has sufficient public randomness, skip committing more",
 			zap.String("pk", fp.GetBtcPkHex()),
 			zap.Uint64("tip_height", tipHeight),
@@ -408,14 +527,14 @@ func (fp *FinalityProviderInstance) ShouldCommitRandomness() (bool, uint64, erro
 		return false, 0, nil
 	}
 
-	fp.logger.Debug(
+	fp.randomnessLogger.Debug(
 		"the finality-provider should commit randomness",
 		zap.String("pk", fp.GetBtcPkHex()),
 		zap.Uint64("tip_height", tipHeight),
 		zap.Uint64("last_committed_height", lastCommittedHeight),
 	)
 
-	activationBlkHeight, err := fp.cc.QueryFinalityActivationBlockHeight()
+	activationBlkHeight, err := fp.cc.QueryFinalityActivationBlockHeight(ctx)
 	if err != nil {
 		return false, 0, err
 	}
@@ -427,16 +546,31 @@ func (fp *FinalityProviderInstance) ShouldCommitRandomness() (bool, uint64, erro
 	return true, startHeight, nil
 }
 
+// reportCriticalErr sends the error to the app unless the instance is
+// stopping, in which case the error is most likely caused by cancelling
+// the in-flight requests
 func (fp *FinalityProviderInstance) reportCriticalErr(err error) {
-	fp.criticalErrChan <- &CriticalError{
+	select {
+	case <-fp.quit:
+		return
+	default:
+	}
+
+	select {
+	case fp.criticalErrChan <- &CriticalError{
 		err:     err,
 		fpBtcPk: fp.GetBtcPkBIP340(),
+	}:
+	case <-fp.quit:
 	}
 }
 
 // retrySubmitSigsUntilFinalized periodically tries to submit finality signature until success or the block is finalized
 // error will be returned if maximum retries have been reached or the query to the consumer chain fails
-func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks []*types.BlockInfo) (*types.TxResponse, error) {
+func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(ctx context.Context, targetBlocks []*types.BlockInfo) (_ *types.TxResponse, err error) {
+	ctx, span := tracing.Start(ctx, "FinalityProviderInstance.retrySubmitSigsUntilFinalized")
+	defer func() { tracing.End(span, err) }()
+
 	if len(targetBlocks) == 0 {
 		return nil, fmt.Errorf("cannot send signatures for empty blocks")
 	}
@@ -450,9 +584,13 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 		// error will be returned if max retries have been reached
 		var res *types.TxResponse
 		var err error
-		res, err = fp.SubmitBatchFinalitySignatures(targetBlocks)
+		res, err = fp.SubmitBatchFinalitySignatures(ctx, targetBlocks)
 		if err != nil {
-			fp.logger.Debug(
+			if ctx.Err() != nil {
+				return nil, ErrFinalityProviderShutDown
+			}
+
+			fp.submissionLogger.Debug(
 				"failed to submit finality signature to the consumer chain",
 				zap.String("pk", fp.GetBtcPkHex()),
 				zap.Uint32("current_failures", failedCycles),
@@ -470,7 +608,7 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 			}
 
 			failedCycles++
-			if failedCycles > fp.cfg.MaxSubmissionRetries {
+			if failedCycles >= fp.cfg.RetryConfig.VoteSubmission.Attempts {
 				return nil, fmt.Errorf("reached max failed cycles with err: %w", err)
 			}
 		} else {
@@ -479,12 +617,12 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 		}
 
 		// periodically query the index block to be later checked whether it is Finalized
-		finalized, err := fp.checkBlockFinalization(targetHeight)
+		finalized, err := fp.checkBlockFinalization(ctx, targetHeight)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query block finalization at height %v: %w", targetHeight, err)
 		}
 		if finalized {
-			fp.logger.Debug(
+			fp.submissionLogger.Debug(
 				"the block is already finalized, skip submission",
 				zap.String("pk", fp.GetBtcPkHex()),
 				zap.Uint64("target_height", targetHeight),
@@ -496,19 +634,19 @@ func (fp *FinalityProviderInstance) retrySubmitSigsUntilFinalized(targetBlocks [
 
 		// Wait for the retry interval
 		select {
-		case <-time.After(fp.cfg.SubmissionRetryInterval):
+		case <-time.After(fp.cfg.RetryConfig.VoteSubmission.Delay(uint(failedCycles - 1))):
 			// Continue to next retry iteration
 			continue
 		case <-fp.quit:
-			fp.logger.Debug("the finality-provider instance is closing", zap.String("pk", fp.GetBtcPkHex()))
+			fp.submissionLogger.Debug("the finality-provider instance is closing", zap.String("pk", fp.GetBtcPkHex()))
 
 			return nil, ErrFinalityProviderShutDown
 		}
 	}
 }
 
-func (fp *FinalityProviderInstance) checkBlockFinalization(height uint64) (bool, error) {
-	b, err := fp.cc.QueryBlock(height)
+func (fp *FinalityProviderInstance) checkBlockFinalization(ctx context.Context, height uint64) (bool, error) {
+	b, err := fp.cc.QueryBlock(ctx, height)
 	if err != nil {
 		return false, err
 	}
@@ -517,12 +655,16 @@ func (fp *FinalityProviderInstance) checkBlockFinalization(height uint64) (bool,
 }
 
 // CommitPubRand commits a list of randomness from given start height
-func (fp *FinalityProviderInstance) CommitPubRand(startHeight uint64) (*types.TxResponse, error) {
+// In shadow mode, the commit is recorded instead of being sent and nil is returned
+func (fp *FinalityProviderInstance) CommitPubRand(ctx context.Context, startHeight uint64) (res *types.TxResponse, err error) {
+	ctx, span := tracing.Start(ctx, "FinalityProviderInstance.CommitPubRand", tracing.Uint64("start_height", startHeight))
+	defer func() { tracing.End(span, err) }()
+
 	// generate a list of Schnorr randomness pairs
 	// NOTE: currently, calling this will create and save a list of randomness
 	// in case of failure, randomness that has been created will be overwritten
 	// for safety reason as the same randomness must not be used twice
-	pubRandList, err := fp.getPubRandList(startHeight, fp.cfg.NumPubRand)
+	pubRandList, err := fp.getPubRandList(ctx, startHeight, fp.numPubRand.Load())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate randomness: %w", err)
 	}
@@ -531,19 +673,40 @@ func (fp *FinalityProviderInstance) CommitPubRand(startHeight uint64) (*types.Tx
 	// generate commitment and proof for each public randomness
 	commitment, proofList := types.GetPubRandCommitAndProofs(pubRandList)
 
-	// store them to database
-	if err := fp.pubRandState.addPubRandProofList(fp.btcPk.MustMarshal(), fp.GetChainID(), startHeight, uint64(fp.cfg.NumPubRand), proofList); err != nil {
-		return nil, fmt.Errorf("failed to save public randomness to DB: %w", err)
+	// store them to database, unless in shadow mode in which the proofs of
+	// the randomness committed by the primary instance are used
+	if !fp.cfg.ShadowMode {
+		if err := fp.pubRandState.addPubRandProofList(fp.btcPk.MustMarshal(), fp.GetChainID(), startHeight, numPubRand, proofList); err != nil {
+			return nil, fmt.Errorf("failed to save public randomness to DB: %w", err)
+		}
 	}
 
 	// sign the commitment
-	schnorrSig, err := fp.signPubRandCommit(startHeight, numPubRand, commitment)
+	schnorrSig, err := fp.signPubRandCommit(ctx, startHeight, numPubRand, commitment)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign the Schnorr signature: %w", err)
 	}
 
-	res, err := fp.cc.CommitPubRandList(fp.GetBtcPk(), startHeight, numPubRand, commitment, schnorrSig)
-	if err != nil {
+	if fp.cfg.ShadowMode {
+		return nil, fp.recordShadowPubRandCommit(startHeight, numPubRand, commitment, schnorrSig)
+	}
+
+	commitPolicy := fp.cfg.RetryConfig.PubRandCommit
+	if err := retry.Do(func() error {
+		var err error
+		res, err = fp.cc.CommitPubRandList(ctx, fp.GetBtcPk(), startHeight, numPubRand, commitment, schnorrSig)
+
+		return err
+	}, append(retryOptions(ctx, commitPolicy, func(n uint, err error) {
+		fp.randomnessLogger.Debug(
+			"failed to commit public randomness to the consumer chain",
+			zap.Uint("attempt", n+1),
+			zap.Uint32("max_attempts", commitPolicy.Attempts),
+			zap.Error(err),
+		)
+	}), retry.RetryIf(func(err error) bool {
+		return !clientcontroller.IsUnrecoverable(err)
+	}))...); err != nil {
 		return nil, fmt.Errorf("failed to commit public randomness to the consumer chain: %w", err)
 	}
 
@@ -562,10 +725,10 @@ func (fp *FinalityProviderInstance) CommitPubRand(startHeight uint64) (*types.Tx
 // - this function is similar to `CommitPubRand` but should not be used in the main pubrand submission loop.
 // - it will always start from the last committed height + 1
 // - if targetBlockHeight is too large, it will commit multiple fp.cfg.NumPubRand pairs in a loop until reaching the targetBlockHeight
-func (fp *FinalityProviderInstance) TestCommitPubRand(targetBlockHeight uint64) error {
+func (fp *FinalityProviderInstance) TestCommitPubRand(ctx context.Context, targetBlockHeight uint64) error {
 	var startHeight, lastCommittedHeight uint64
 
-	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+	lastCommittedHeight, err := fp.GetLastCommittedHeight(ctx)
 	if err != nil {
 		return err
 	}
@@ -587,18 +750,18 @@ func (fp *FinalityProviderInstance) TestCommitPubRand(targetBlockHeight uint64)
 		startHeight = lastCommittedHeight + 1
 	}
 
-	return fp.TestCommitPubRandWithStartHeight(startHeight, targetBlockHeight)
+	return fp.TestCommitPubRandWithStartHeight(ctx, startHeight, targetBlockHeight)
 }
 
 // TestCommitPubRandWithStartHeight is exposed for devops/testing purpose to allow manual committing public randomness
 // in cases where FP is stuck due to lack of public randomness.
-func (fp *FinalityProviderInstance) TestCommitPubRandWithStartHeight(startHeight uint64, targetBlockHeight uint64) error {
+func (fp *FinalityProviderInstance) TestCommitPubRandWithStartHeight(ctx context.Context, startHeight uint64, targetBlockHeight uint64) error {
 	if startHeight > targetBlockHeight {
 		return fmt.Errorf("start height should not be greater than target block height")
 	}
 
 	var lastCommittedHeight uint64
-	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+	lastCommittedHeight, err := fp.GetLastCommittedHeight(ctx)
 	if err != nil {
 		return err
 	}
@@ -614,11 +777,12 @@ func (fp *FinalityProviderInstance) TestCommitPubRandWithStartHeight(startHeight
 	fp.logger.Info("Start committing pubrand from block height", zap.Uint64("start_height", startHeight))
 
 	for startHeight <= targetBlockHeight {
-		_, err = fp.CommitPubRand(startHeight)
+		numPubRand := uint64(fp.numPubRand.Load())
+		_, err = fp.CommitPubRand(ctx, startHeight)
 		if err != nil {
 			return err
 		}
-		lastCommittedHeight = startHeight + uint64(fp.cfg.NumPubRand) - 1
+		lastCommittedHeight = startHeight + numPubRand - 1
 		startHeight = lastCommittedHeight + 1
 		fp.logger.Info("Committed pubrand to block height", zap.Uint64("height", lastCommittedHeight))
 	}
@@ -628,13 +792,17 @@ func (fp *FinalityProviderInstance) TestCommitPubRandWithStartHeight(startHeight
 }
 
 // SubmitFinalitySignature builds and sends a finality signature over the given block to the consumer chain
-func (fp *FinalityProviderInstance) SubmitFinalitySignature(b *types.BlockInfo) (*types.TxResponse, error) {
-	return fp.SubmitBatchFinalitySignatures([]*types.BlockInfo{b})
+func (fp *FinalityProviderInstance) SubmitFinalitySignature(ctx context.Context, b *types.BlockInfo) (*types.TxResponse, error) {
+	return fp.SubmitBatchFinalitySignatures(ctx, []*types.BlockInfo{b})
 }
 
 // SubmitBatchFinalitySignatures builds and sends a finality signature over the given block to the consumer chain
 // NOTE: the input blocks should be in the ascending order of height
-func (fp *FinalityProviderInstance) SubmitBatchFinalitySignatures(blocks []*types.BlockInfo) (*types.TxResponse, error) {
+// In shadow mode, the signatures are recorded instead of being sent and nil is returned
+func (fp *FinalityProviderInstance) SubmitBatchFinalitySignatures(ctx context.Context, blocks []*types.BlockInfo) (res *types.TxResponse, err error) {
+	ctx, span := tracing.Start(ctx, "FinalityProviderInstance.SubmitBatchFinalitySignatures")
+	defer func() { tracing.End(span, err) }()
+
 	if len(blocks) == 0 {
 		return nil, fmt.Errorf("should not submit batch finality signature with zero block")
 	}
@@ -646,45 +814,83 @@ func (fp *FinalityProviderInstance) SubmitBatchFinalitySignatures(blocks []*type
 	// get public randomness list
 	numPubRand := len(blocks)
 	// #nosec G115 -- performed the conversion check above
-	prList, err := fp.getPubRandList(blocks[0].Height, uint32(numPubRand))
+	prList, err := fp.getPubRandList(ctx, blocks[0].Height, uint32(numPubRand))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public randomness list: %w", err)
 	}
 	// get proof list
-	// TODO: how to recover upon having an error in getPubRandProofList?
 	proofBytesList, err := fp.pubRandState.getPubRandProofList(
 		fp.btcPk.MustMarshal(),
 		fp.GetChainID(),
 		blocks[0].Height,
 		uint64(numPubRand),
 	)
+	if errors.Is(err, store.ErrPubRandProofNotFound) {
+		// the randomness is committed by another daemon, e.g., the primary
+		// instance in shadow mode or the previous lease holder
+		if err = fp.recoverPubRandProofs(ctx, blocks[0].Height, blocks[len(blocks)-1].Height); err == nil {
+			proofBytesList, err = fp.pubRandState.getPubRandProofList(
+				fp.btcPk.MustMarshal(),
+				fp.GetChainID(),
+				blocks[0].Height,
+				uint64(numPubRand),
+			)
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public randomness inclusion proof list: %w", err)
 	}
 
 	// sign blocks
+	signCtx, signSpan := tracing.Start(ctx, "FinalityProviderInstance.signFinalitySigs")
 	sigList := make([]*btcec.ModNScalar, 0, len(blocks))
-	for _, b := range blocks {
-		eotsSig, err := fp.signFinalitySig(b)
+	for i, b := range blocks {
+		var eotsSig *bbntypes.SchnorrEOTSSig
+		if fp.cfg.ShadowMode {
+			eotsSig, err = fp.shadowSignFinalitySig(signCtx, b, prList[i])
+		} else {
+			eotsSig, err = fp.signFinalitySig(signCtx, b)
+		}
 		if err != nil {
+			tracing.End(signSpan, err)
+
 			return nil, err
 		}
 		sigList = append(sigList, eotsSig.ToModNScalar())
 	}
+	signSpan.End()
+	signedAt := time.Now()
 
-	// send finality signature to the consumer chain
-	res, err := fp.cc.SubmitBatchFinalitySigs(fp.GetBtcPk(), blocks, prList, proofBytesList, sigList)
-	if err != nil {
-		if strings.Contains(err.Error(), "jailed") {
-			return nil, ErrFinalityProviderJailed
-		}
-		if strings.Contains(err.Error(), "slashed") {
-			return nil, ErrFinalityProviderSlashed
+	if fp.cfg.ShadowMode {
+		fp.recordShadowFinalitySigs(blocks, sigList)
+
+		return nil, nil
+	}
+
+	// a daemon that lost the lease must not vote as a standby daemon may
+	// have taken over
+	if fp.checkLease != nil {
+		if err := fp.checkLease(ctx); err != nil {
+			return nil, err
 		}
+	}
 
-		return nil, err
+	// send finality signature to the consumer chain, which returns once the
+	// transaction is included in a block
+	res, err = fp.cc.SubmitBatchFinalitySigs(ctx, fp.GetBtcPk(), blocks, prList, proofBytesList, sigList)
+	if err != nil {
+		switch {
+		case errors.Is(err, clientcontroller.ErrFpJailed):
+			return nil, fmt.Errorf("%w: %w", ErrFinalityProviderJailed, err)
+		case errors.Is(err, clientcontroller.ErrFpSlashed):
+			return nil, fmt.Errorf("%w: %w", ErrFinalityProviderSlashed, err)
+		default:
+			return nil, err
+		}
 	}
 
+	fp.recordVoteLatency(blocks, signedAt, res.BroadcastAt, time.Now())
+
 	// update DB
 	highBlock := blocks[len(blocks)-1]
 	fp.MustUpdateStateAfterFinalitySigSubmission(highBlock.Height)
@@ -692,14 +898,36 @@ func (fp *FinalityProviderInstance) SubmitBatchFinalitySignatures(blocks []*type
 	return res, nil
 }
 
+// recordVoteLatency records the time from each of the polled blocks being seen
+// to the stages of the vote over it. The stages are those of the attempt that
+// lands, so the time of the failed attempts is counted. The broadcast stage is
+// skipped if the consumer chain does not report it
+func (fp *FinalityProviderInstance) recordVoteLatency(blocks []*types.BlockInfo, signedAt, broadcastAt, includedAt time.Time) {
+	for _, b := range blocks {
+		if b.SeenAt.IsZero() {
+			continue
+		}
+		fp.metrics.RecordFpVoteLatency(fp.GetBtcPkHex(), metrics.VoteStageSigned, signedAt.Sub(b.SeenAt))
+		if !broadcastAt.IsZero() {
+			fp.metrics.RecordFpVoteLatency(fp.GetBtcPkHex(), metrics.VoteStageBroadcast, broadcastAt.Sub(b.SeenAt))
+		}
+		fp.metrics.RecordFpVoteLatency(fp.GetBtcPkHex(), metrics.VoteStageIncluded, includedAt.Sub(b.SeenAt))
+	}
+}
+
 // TestSubmitFinalitySignatureAndExtractPrivKey is exposed for presentation/testing purpose to allow manual sending finality signature
 // this API is the same as SubmitBatchFinalitySignatures except that we don't constraint the voting height and update status
 // Note: this should not be used in the submission loop
 func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey(
+	ctx context.Context,
 	b *types.BlockInfo, useSafeEOTSFunc bool,
 ) (*types.TxResponse, *btcec.PrivateKey, error) {
+	if fp.cfg.ShadowMode {
+		return nil, nil, ErrShadowMode
+	}
+
 	// get public randomness
-	prList, err := fp.getPubRandList(b.Height, 1)
+	prList, err := fp.getPubRandList(ctx, b.Height, 1)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get public randomness list: %w", err)
 	}
@@ -711,9 +939,9 @@ func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey
 		return nil, nil, fmt.Errorf("failed to get public randomness inclusion proof: %w", err)
 	}
 
-	eotsSignerFunc := func(b *types.BlockInfo) (*bbntypes.SchnorrEOTSSig, error) {
+	eotsSignerFunc := func(ctx context.Context, b *types.BlockInfo) (*bbntypes.SchnorrEOTSSig, error) {
 		msgToSign := getMsgToSignForVote(b.Height, b.Hash)
-		sig, err := fp.em.UnsafeSignEOTS(fp.btcPk.MustMarshal(), fp.GetChainID(), msgToSign, b.Height, fp.passphrase)
+		sig, err := fp.em.UnsafeSignEOTS(ctx, fp.btcPk.MustMarshal(), fp.GetChainID(), msgToSign, b.Height, fp.passphrase)
 		if err != nil {
 			return nil, fmt.Errorf("failed to sign EOTS: %w", err)
 		}
@@ -726,13 +954,13 @@ func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey
 	}
 
 	// sign block
-	eotsSig, err := eotsSignerFunc(b)
+	eotsSig, err := eotsSignerFunc(ctx, b)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// send finality signature to the consumer chain
-	res, err := fp.cc.SubmitFinalitySig(fp.GetBtcPk(), b, pubRand, proofBytes, eotsSig.ToModNScalar())
+	res, err := fp.cc.SubmitFinalitySig(ctx, fp.GetBtcPk(), b, pubRand, proofBytes, eotsSig.ToModNScalar())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to send finality signature to the consumer chain: %w", err)
 	}
@@ -784,7 +1012,7 @@ func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey
 //
 // Note: Starting from lastFinalizedHeight when there's a gap to the last processed height
 // may result in missed rewards, depending on the consumer chain's reward distribution mechanism.
-func (fp *FinalityProviderInstance) DetermineStartHeight() (uint64, error) {
+func (fp *FinalityProviderInstance) DetermineStartHeight(ctx context.Context) (uint64, error) {
 	// start from a height from config if AutoChainScanningMode is disabled
 	if !fp.cfg.PollerConfig.AutoChainScanningMode {
 		fp.logger.Info("using static chain scanning mode",
@@ -794,12 +1022,12 @@ func (fp *FinalityProviderInstance) DetermineStartHeight() (uint64, error) {
 		return fp.cfg.PollerConfig.StaticChainScanningStartHeight, nil
 	}
 
-	highestVotedHeight, err := fp.highestVotedHeightWithRetry()
+	highestVotedHeight, err := fp.highestVotedHeightWithRetry(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get the highest voted height: %w", err)
 	}
 
-	lastFinalizedHeight, err := fp.latestFinalizedHeightWithRetry()
+	lastFinalizedHeight, err := fp.latestFinalizedHeightWithRetry(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get the last finalized height: %w", err)
 	}
@@ -812,7 +1040,7 @@ func (fp *FinalityProviderInstance) DetermineStartHeight() (uint64, error) {
 	// increasing order.
 	startHeight := max(fp.GetLastVotedHeight(), highestVotedHeight, lastFinalizedHeight) + 1
 
-	finalityActivationHeight, err := fp.getFinalityActivationHeightWithRetry()
+	finalityActivationHeight, err := fp.getFinalityActivationHeightWithRetry(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get finality activation height: %w", err)
 	}
@@ -831,8 +1059,8 @@ func (fp *FinalityProviderInstance) DetermineStartHeight() (uint64, error) {
 	return startHeight, nil
 }
 
-func (fp *FinalityProviderInstance) GetLastCommittedHeight() (uint64, error) {
-	pubRandCommitMap, err := fp.lastCommittedPublicRandWithRetry(1)
+func (fp *FinalityProviderInstance) GetLastCommittedHeight(ctx context.Context) (uint64, error) {
+	pubRandCommitMap, err := fp.lastCommittedPublicRandWithRetry(ctx, 1)
 	if err != nil {
 		return 0, err
 	}
@@ -853,34 +1081,34 @@ func (fp *FinalityProviderInstance) GetLastCommittedHeight() (uint64, error) {
 	return lastCommittedHeight, nil
 }
 
-func (fp *FinalityProviderInstance) lastCommittedPublicRandWithRetry(count uint64) (map[uint64]*ftypes.PubRandCommitResponse, error) {
+func (fp *FinalityProviderInstance) lastCommittedPublicRandWithRetry(ctx context.Context, count uint64) (map[uint64]*ftypes.PubRandCommitResponse, error) {
 	var response map[uint64]*ftypes.PubRandCommitResponse
 	if err := retry.Do(func() error {
-		resp, err := fp.cc.QueryLastCommittedPublicRand(fp.GetBtcPk(), count)
+		resp, err := fp.cc.QueryLastCommittedPublicRand(ctx, fp.GetBtcPk(), count)
 		if err != nil {
 			return err
 		}
 		response = resp
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, fp.cfg.RetryConfig.PubRandCommit, func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query babylon for the last committed public randomness",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", fp.cfg.RetryConfig.PubRandCommit.Attempts),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return nil, err
 	}
 
 	return response, nil
 }
 
-func (fp *FinalityProviderInstance) latestFinalizedHeightWithRetry() (uint64, error) {
+func (fp *FinalityProviderInstance) latestFinalizedHeightWithRetry(ctx context.Context) (uint64, error) {
 	var height uint64
 	if err := retry.Do(func() error {
-		blocks, err := fp.cc.QueryLatestFinalizedBlocks(1)
+		blocks, err := fp.cc.QueryLatestFinalizedBlocks(ctx, 1)
 		if err != nil {
 			return err
 		}
@@ -891,124 +1119,127 @@ func (fp *FinalityProviderInstance) latestFinalizedHeightWithRetry() (uint64, er
 		height = blocks[0].Height
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, fp.cfg.RetryConfig.BlockPolling, func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query babylon for the latest finalised height",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", fp.cfg.RetryConfig.BlockPolling.Attempts),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return 0, err
 	}
 
 	return height, nil
 }
 
-func (fp *FinalityProviderInstance) highestVotedHeightWithRetry() (uint64, error) {
+func (fp *FinalityProviderInstance) highestVotedHeightWithRetry(ctx context.Context) (uint64, error) {
 	var height uint64
 	if err := retry.Do(func() error {
-		h, err := fp.cc.QueryFinalityProviderHighestVotedHeight(fp.GetBtcPk())
+		h, err := fp.cc.QueryFinalityProviderHighestVotedHeight(ctx, fp.GetBtcPk())
 		if err != nil {
 			return err
 		}
 		height = h
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, fp.cfg.RetryConfig.VotingPower, func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query babylon for the highest voted height",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", fp.cfg.RetryConfig.VotingPower.Attempts),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return 0, err
 	}
 
 	return height, nil
 }
 
-func (fp *FinalityProviderInstance) getFinalityActivationHeightWithRetry() (uint64, error) {
+func (fp *FinalityProviderInstance) getFinalityActivationHeightWithRetry(ctx context.Context) (uint64, error) {
 	var response uint64
 	if err := retry.Do(func() error {
-		finalityActivationHeight, err := fp.cc.QueryFinalityActivationBlockHeight()
+		finalityActivationHeight, err := fp.cc.QueryFinalityActivationBlockHeight(ctx)
 		if err != nil {
 			return err
 		}
 		response = finalityActivationHeight
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, fp.cfg.RetryConfig.BlockPolling, func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query babylon for the finality activation height",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", fp.cfg.RetryConfig.BlockPolling.Attempts),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return 0, err
 	}
 
 	return response, nil
 }
 
-func (fp *FinalityProviderInstance) getLatestBlockWithRetry() (*types.BlockInfo, error) {
+func (fp *FinalityProviderInstance) getLatestBlockWithRetry(ctx context.Context) (*types.BlockInfo, error) {
 	var (
 		latestBlock *types.BlockInfo
 		err         error
 	)
 
 	if err := retry.Do(func() error {
-		latestBlock, err = fp.cc.QueryBestBlock()
+		latestBlock, err = fp.cc.QueryBestBlock(ctx)
 		if err != nil {
 			return err
 		}
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, fp.cfg.RetryConfig.BlockPolling, func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query the consumer chain for the latest block",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", fp.cfg.RetryConfig.BlockPolling.Attempts),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return nil, err
 	}
 	fp.metrics.RecordBabylonTipHeight(latestBlock.Height)
+	if poller := fp.runningPoller(); poller != nil {
+		fp.events.Publish(newPollerLagEvent(fp.GetBtcPkHex(), latestBlock.Height, poller.NextHeight()))
+	}
 
 	return latestBlock, nil
 }
 
-func (fp *FinalityProviderInstance) GetVotingPowerWithRetry(height uint64) (uint64, error) {
+func (fp *FinalityProviderInstance) GetVotingPowerWithRetry(ctx context.Context, height uint64) (uint64, error) {
 	var (
 		power uint64
 		err   error
 	)
 
 	if err := retry.Do(func() error {
-		power, err = fp.cc.QueryFinalityProviderVotingPower(fp.GetBtcPk(), height)
+		power, err = fp.cc.QueryFinalityProviderVotingPower(ctx, fp.GetBtcPk(), height)
 		if err != nil {
 			return err
 		}
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, fp.cfg.RetryConfig.VotingPower, func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query the voting power",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", fp.cfg.RetryConfig.VotingPower.Attempts),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return 0, err
 	}
 
 	return power, nil
 }
 
-func (fp *FinalityProviderInstance) GetFinalityProviderSlashedOrJailedWithRetry() (bool, bool, error) {
+func (fp *FinalityProviderInstance) GetFinalityProviderSlashedOrJailedWithRetry(ctx context.Context) (bool, bool, error) {
 	var (
 		slashed bool
 		jailed  bool
@@ -1016,20 +1247,20 @@ func (fp *FinalityProviderInstance) GetFinalityProviderSlashedOrJailedWithRetry(
 	)
 
 	if err := retry.Do(func() error {
-		slashed, jailed, err = fp.cc.QueryFinalityProviderSlashedOrJailed(fp.GetBtcPk())
+		slashed, jailed, err = fp.cc.QueryFinalityProviderSlashedOrJailed(ctx, fp.GetBtcPk())
 		if err != nil {
 			return err
 		}
 
 		return nil
-	}, RtyAtt, RtyDel, RtyErr, retry.OnRetry(func(n uint, err error) {
+	}, retryOptions(ctx, fp.cfg.RetryConfig.VotingPower, func(n uint, err error) {
 		fp.logger.Debug(
 			"failed to query the finality-provider",
 			zap.Uint("attempt", n+1),
-			zap.Uint("max_attempts", RtyAttNum),
+			zap.Uint32("max_attempts", fp.cfg.RetryConfig.VotingPower.Attempts),
 			zap.Error(err),
 		)
-	})); err != nil {
+	})...); err != nil {
 		return false, false, err
 	}
 
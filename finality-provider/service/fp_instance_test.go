@@ -1,16 +1,20 @@
 package service_test
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	ftypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
@@ -18,6 +22,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
 	"github.com/babylonlabs-io/finality-provider/metrics"
@@ -34,17 +39,17 @@ func FuzzCommitPubRandList(f *testing.F) {
 		currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
 		startingBlock := &types.BlockInfo{Height: randomStartingHeight, Hash: testutil.GenRandomByteArray(r, 32)}
 		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
-		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(uint64(0), nil).AnyTimes()
 		_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, true, randomStartingHeight, testutil.TestPubRandNum)
 		defer cleanUp()
 
 		expectedTxHash := testutil.GenRandomHexStr(r, 32)
 		mockClientController.EXPECT().
-			CommitPubRandList(fpIns.GetBtcPk(), startingBlock.Height, gomock.Any(), gomock.Any(), gomock.Any()).
+			CommitPubRandList(gomock.Any(), fpIns.GetBtcPk(), startingBlock.Height, gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(&types.TxResponse{TxHash: expectedTxHash}, nil).AnyTimes()
-		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
-		res, err := fpIns.CommitPubRand(startingBlock.Height)
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+		res, err := fpIns.CommitPubRand(context.Background(), startingBlock.Height)
 		require.NoError(t, err)
 		require.Equal(t, expectedTxHash, res.TxHash)
 	})
@@ -59,13 +64,13 @@ func FuzzSubmitFinalitySigs(f *testing.F) {
 		currentHeight := randomStartingHeight + uint64(r.Int63n(10)+1)
 		startingBlock := &types.BlockInfo{Height: randomStartingHeight, Hash: testutil.GenRandomByteArray(r, 32)}
 		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
-		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 		_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, true, randomStartingHeight, testutil.TestPubRandNum)
 		defer cleanUp()
 
 		// commit pub rand
-		mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
-		_, err := fpIns.CommitPubRand(startingBlock.Height)
+		mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		_, err := fpIns.CommitPubRand(context.Background(), startingBlock.Height)
 		require.NoError(t, err)
 
 		// mock committed pub rand
@@ -75,9 +80,9 @@ func FuzzSubmitFinalitySigs(f *testing.F) {
 			NumPubRand: 1000,
 			Commitment: datagen.GenRandomByteArray(r, 32),
 		}
-		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(lastCommittedPubRandMap, nil).AnyTimes()
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), uint64(1)).Return(lastCommittedPubRandMap, nil).AnyTimes()
 		// mock voting power and commit pub rand
-		mockClientController.EXPECT().QueryFinalityProviderVotingPower(fpIns.GetBtcPk(), gomock.Any()).
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), fpIns.GetBtcPk(), gomock.Any()).
 			Return(uint64(1), nil).AnyTimes()
 
 		// submit finality sig
@@ -87,9 +92,9 @@ func FuzzSubmitFinalitySigs(f *testing.F) {
 		}
 		expectedTxHash := testutil.GenRandomHexStr(r, 32)
 		mockClientController.EXPECT().
-			SubmitBatchFinalitySigs(fpIns.GetBtcPk(), []*types.BlockInfo{nextBlock}, gomock.Any(), gomock.Any(), gomock.Any()).
+			SubmitBatchFinalitySigs(gomock.Any(), fpIns.GetBtcPk(), []*types.BlockInfo{nextBlock}, gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(&types.TxResponse{TxHash: expectedTxHash}, nil).AnyTimes()
-		providerRes, err := fpIns.SubmitBatchFinalitySignatures([]*types.BlockInfo{nextBlock})
+		providerRes, err := fpIns.SubmitBatchFinalitySignatures(context.Background(), []*types.BlockInfo{nextBlock})
 		require.NoError(t, err)
 		require.Equal(t, expectedTxHash, providerRes.TxHash)
 
@@ -98,6 +103,56 @@ func FuzzSubmitFinalitySigs(f *testing.F) {
 	})
 }
 
+func TestShadowMode(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	randomStartingHeight := uint64(r.Int63n(100) + 1)
+	currentHeight := randomStartingHeight + uint64(r.Int63n(10)+1)
+	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, true, randomStartingHeight, testutil.TestPubRandNum)
+	defer cleanUp()
+
+	// the randomness is committed by the primary instance
+	var commitment []byte
+	mockClientController.EXPECT().
+		CommitPubRandList(gomock.Any(), fpIns.GetBtcPk(), randomStartingHeight, uint64(testutil.TestPubRandNum), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *btcec.PublicKey, _ uint64, _ uint64, c []byte, _ *schnorr.Signature) (*types.TxResponse, error) {
+			commitment = c
+
+			return &types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil
+		}).Times(1)
+	_, err := fpIns.CommitPubRand(context.Background(), randomStartingHeight)
+	require.NoError(t, err)
+	err = app.GetPubRandProofStore().RemovePubRandProofList(fpIns.GetChainID(), fpIns.GetBtcPkBIP340().MustMarshal(), randomStartingHeight+uint64(testutil.TestPubRandNum))
+	require.NoError(t, err)
+
+	fpIns.GetConfig().ShadowMode = true
+
+	// no randomness commit is sent in shadow mode
+	res, err := fpIns.CommitPubRand(context.Background(), randomStartingHeight+uint64(testutil.TestPubRandNum))
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	// the proofs are recovered from the on-chain commit and no vote is sent
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), fpIns.GetBtcPk(), gomock.Any()).
+		Return(map[uint64]*ftypes.PubRandCommitResponse{
+			randomStartingHeight: {NumPubRand: uint64(testutil.TestPubRandNum), Commitment: commitment},
+		}, nil).AnyTimes()
+	nextBlock := &types.BlockInfo{
+		Height: randomStartingHeight + 1,
+		Hash:   testutil.GenRandomByteArray(r, 32),
+	}
+	res, err = fpIns.SubmitBatchFinalitySignatures(context.Background(), []*types.BlockInfo{nextBlock})
+	require.NoError(t, err)
+	require.Nil(t, res)
+	require.Zero(t, fpIns.GetLastVotedHeight())
+
+	// the transactions requested through the daemon are rejected
+	_, _, err = fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(context.Background(), nextBlock, true)
+	require.ErrorIs(t, err, service.ErrShadowMode)
+}
+
 func FuzzDetermineStartHeight(f *testing.F) {
 	testutil.AddRandomSeedsToFuzzer(f, 10)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -115,19 +170,19 @@ func FuzzDetermineStartHeight(f *testing.F) {
 
 		// setup mocks
 		mockClientController.EXPECT().
-			QueryFinalityProviderHighestVotedHeight(gomock.Any()).
+			QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).
 			Return(highestVotedHeight, nil).
 			AnyTimes()
 		finalizedBlocks := []*types.BlockInfo{{
 			Height: lastFinalizedHeight,
 		}}
-		mockClientController.EXPECT().QueryLatestFinalizedBlocks(uint64(1)).Return(finalizedBlocks, nil).AnyTimes()
+		mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any(), uint64(1)).Return(finalizedBlocks, nil).AnyTimes()
 
 		_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, false, randomStartingHeight, testutil.TestPubRandNum)
 		defer cleanUp()
 		fpIns.MustUpdateStateAfterFinalitySigSubmission(lastVotedHeight)
 
-		startHeight, err := fpIns.DetermineStartHeight()
+		startHeight, err := fpIns.DetermineStartHeight(context.Background())
 		require.NoError(t, err)
 
 		require.Equal(t, startHeight, max(finalityActivationHeight, highestVotedHeight+1, lastFinalizedHeight+1, lastVotedHeight+1))
@@ -167,7 +222,7 @@ func startFinalityProviderAppWithRegisteredFp(
 	// create registered finality-provider
 	eotsKeyName := testutil.GenRandomHexStr(r, 4)
 	require.NoError(t, err)
-	eotsPkBz, err := em.CreateKey(eotsKeyName, passphrase, hdPath)
+	eotsPkBz, err := em.CreateKey(context.Background(), eotsKeyName, passphrase, hdPath)
 	require.NoError(t, err)
 	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
 	require.NoError(t, err)
@@ -197,7 +252,9 @@ func startFinalityProviderAppWithRegisteredFp(
 	)
 	require.NoError(t, err)
 	m := metrics.NewFpMetrics()
-	fpIns, err := service.NewFinalityProviderInstance(eotsPk, &fpCfg, fpStore, pubRandProofStore, cc, em, m, passphrase, make(chan *service.CriticalError), logger)
+	n, err := notifier.NewWithSinks(fpCfg.Notifier, logger)
+	require.NoError(t, err)
+	fpIns, err := service.NewFinalityProviderInstance(eotsPk, &fpCfg, fpStore, pubRandProofStore, cc, em, m, service.NewEventBus(logger), n, passphrase, make(chan *service.CriticalError), logger)
 	require.NoError(t, err)
 
 	cleanUp := func() {
@@ -228,7 +285,7 @@ func setupBenchmarkEnvironment(t *testing.T, seed int64, numPubRand uint32) (*ty
 
 	// Mock client controller setup
 	mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight, 0)
-	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(uint64(0), nil).AnyTimes()
 
 	// Set up finality provider app
@@ -237,9 +294,9 @@ func setupBenchmarkEnvironment(t *testing.T, seed int64, numPubRand uint32) (*ty
 	// Configure additional mocks
 	expectedTxHash := testutil.GenRandomHexStr(r, 32)
 	mockClientController.EXPECT().
-		CommitPubRandList(fpIns.GetBtcPk(), startingBlock.Height+1, gomock.Any(), gomock.Any(), gomock.Any()).
+		CommitPubRandList(gomock.Any(), fpIns.GetBtcPk(), startingBlock.Height+1, gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).AnyTimes()
-	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
 
 	return startingBlock, fpIns, cleanUp
 }
@@ -256,7 +313,7 @@ func BenchmarkCommitPubRand(b *testing.B) {
 
 			var totalTiming service.CommitPubRandTiming
 			for i := 0; i < b.N; i++ {
-				res, timing, err := fpIns.HelperCommitPubRand(startingBlock.Height)
+				res, timing, err := fpIns.HelperCommitPubRand(context.Background(), startingBlock.Height)
 				if err != nil {
 					b.Fatalf("unexpected error: %v", err)
 				}
@@ -1,12 +1,15 @@
 package service
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"go.uber.org/zap"
 
+	"github.com/babylonlabs-io/finality-provider/finality-provider/notifier"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 )
@@ -42,6 +45,13 @@ func (fps *fpState) setStatus(s proto.FinalityProviderStatus) error {
 	return fps.s.SetFpStatus(fps.fp.BtcPk, s)
 }
 
+// syncStatus updates the cached status with the one in the store
+func (fps *fpState) syncStatus(s proto.FinalityProviderStatus) {
+	fps.mu.Lock()
+	fps.fp.Status = s
+	fps.mu.Unlock()
+}
+
 func (fps *fpState) setLastVotedHeight(height uint64) error {
 	fps.mu.Lock()
 	fps.fp.LastVotedHeight = height
@@ -50,6 +60,15 @@ func (fps *fpState) setLastVotedHeight(height uint64) error {
 	return fps.s.SetFpLastVotedHeight(fps.fp.BtcPk, height)
 }
 
+func (fps *fpState) setLastCrash(reason string, crashTime time.Time) error {
+	fps.mu.Lock()
+	fps.fp.LastCrashReason = reason
+	fps.fp.LastCrashTime = crashTime
+	fps.mu.Unlock()
+
+	return fps.s.SetFpLastCrash(fps.fp.BtcPk, reason, crashTime)
+}
+
 func (fp *FinalityProviderInstance) GetStoreFinalityProvider() *store.StoredFinalityProvider {
 	return fp.fpState.getStoreFinalityProvider()
 }
@@ -79,7 +98,30 @@ func (fp *FinalityProviderInstance) GetChainID() []byte {
 }
 
 func (fp *FinalityProviderInstance) SetStatus(s proto.FinalityProviderStatus) error {
-	return fp.fpState.setStatus(s)
+	oldStatus := fp.GetStatus()
+	if err := fp.fpState.setStatus(s); err != nil {
+		return err
+	}
+
+	if oldStatus != s {
+		fp.events.Publish(newStatusChangedEvent(fp.GetBtcPkHex(), oldStatus.String(), s.String()))
+		fp.notifyStatusChange(oldStatus, s)
+	}
+
+	return nil
+}
+
+func (fp *FinalityProviderInstance) notifyStatusChange(oldStatus, newStatus proto.FinalityProviderStatus) {
+	kind := notifier.KindStatusChanged
+	switch newStatus {
+	case proto.FinalityProviderStatus_JAILED:
+		kind = notifier.KindJailed
+	case proto.FinalityProviderStatus_SLASHED:
+		kind = notifier.KindSlashed
+	}
+
+	fp.notifier.NotifyClass(kind, fp.GetBtcPkHex(), newStatus.String(),
+		fmt.Sprintf("the finality provider status changed from %s to %s", oldStatus, newStatus))
 }
 
 func (fp *FinalityProviderInstance) MustSetStatus(s proto.FinalityProviderStatus) {
@@ -89,6 +131,11 @@ func (fp *FinalityProviderInstance) MustSetStatus(s proto.FinalityProviderStatus
 	}
 }
 
+// recordCrash persists the critical error that stopped the instance
+func (fp *FinalityProviderInstance) recordCrash(crashErr error) error {
+	return fp.fpState.setLastCrash(crashErr.Error(), time.Now())
+}
+
 func (fp *FinalityProviderInstance) updateStateAfterFinalitySigSubmission(height uint64) error {
 	return fp.fpState.setLastVotedHeight(height)
 }
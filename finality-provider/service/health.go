@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/babylonlabs-io/finality-provider/health"
+)
+
+// pinger is implemented by the EOTS manager clients that can check the
+// reachability of a remote EOTS manager
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// addHealthChecks adds the readiness checks of the components the daemon
+// depends on
+func (app *FinalityProviderApp) addHealthChecks(checker *health.Checker) {
+	cfg := app.config.HealthConfig
+
+	if em, ok := app.eotsManager.(pinger); ok {
+		checker.AddReadinessCheck("eotsd", em.Ping)
+	}
+
+	checker.AddReadinessCheck("babylon", app.checkNodeStatus)
+
+	if cfg.MaxPollerLag > 0 {
+		checker.AddReadinessCheck("poller", app.checkPollerLag)
+	}
+
+	if cfg.MinPubRandRunway > 0 {
+		checker.AddReadinessCheck("pubrand", app.checkPubRandRunway)
+	}
+}
+
+// checkNodeStatus checks that the Babylon node is reachable and in sync
+func (app *FinalityProviderApp) checkNodeStatus(ctx context.Context) error {
+	status, err := app.cc.QueryNodeStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status.CatchingUp {
+		return fmt.Errorf("the node is catching up at height %d", status.LatestHeight)
+	}
+
+	maxBlockAge := app.config.HealthConfig.MaxBlockAge
+	if age := time.Since(status.LatestBlockTime); maxBlockAge > 0 && age > maxBlockAge {
+		return fmt.Errorf("the latest block %d is %v old, exceeding %v", status.LatestHeight, age.Round(time.Second), maxBlockAge)
+	}
+
+	return nil
+}
+
+// checkPollerLag checks that the chain poller of the running instance keeps
+// up with the tip
+func (app *FinalityProviderApp) checkPollerLag(ctx context.Context) error {
+	fpi, err := app.GetFinalityProviderInstance()
+	if err != nil || !fpi.IsRunning() {
+		return nil
+	}
+
+	// the poller is started after the start height is determined
+	poller := fpi.runningPoller()
+	if poller == nil {
+		return nil
+	}
+
+	lag, err := fpi.pollerLag(ctx, poller)
+	if err != nil {
+		return err
+	}
+
+	if maxLag := app.config.HealthConfig.MaxPollerLag; lag > maxLag {
+		return fmt.Errorf("the chain poller lags %d blocks behind the tip, exceeding %d", lag, maxLag)
+	}
+
+	return nil
+}
+
+// checkPubRandRunway checks that the committed public randomness of the
+// running instance covers enough blocks beyond the tip
+func (app *FinalityProviderApp) checkPubRandRunway(ctx context.Context) error {
+	fpi, err := app.GetFinalityProviderInstance()
+	if err != nil || !fpi.IsRunning() {
+		return nil
+	}
+
+	runway, err := fpi.pubRandRunway(ctx)
+	if err != nil {
+		return err
+	}
+
+	if minRunway := app.config.HealthConfig.MinPubRandRunway; runway < minRunway {
+		return fmt.Errorf("the committed public randomness covers %d blocks beyond the tip, below %d", runway, minRunway)
+	}
+
+	return nil
+}
+
+// pollerLag returns the number of blocks the given chain poller lags behind
+// the tip
+func (fp *FinalityProviderInstance) pollerLag(ctx context.Context, poller *ChainPoller) (uint64, error) {
+	tipBlock, err := fp.cc.QueryBestBlock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the tip block: %w", err)
+	}
+
+	return pollerLag(tipBlock.Height, poller.NextHeight()), nil
+}
+
+// pubRandRunway returns the number of blocks beyond the tip covered by the
+// committed public randomness
+func (fp *FinalityProviderInstance) pubRandRunway(ctx context.Context) (uint64, error) {
+	tipBlock, err := fp.cc.QueryBestBlock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the tip block: %w", err)
+	}
+
+	lastCommittedHeight, err := fp.GetLastCommittedHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if lastCommittedHeight <= tipBlock.Height {
+		return 0, nil
+	}
+
+	return lastCommittedHeight - tipBlock.Height, nil
+}
+
+// pollerLag returns the number of blocks from the next height to poll up to
+// the tip
+func pollerLag(tipHeight, nextHeight uint64) uint64 {
+	if tipHeight < nextHeight {
+		return 0
+	}
+
+	return tipHeight - nextHeight + 1
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestCheckNodeStatus(t *testing.T) {
+	t.Parallel()
+
+	cfg := fpcfg.DefaultConfig()
+	cfg.HealthConfig.MaxBlockAge = time.Minute
+
+	tcs := []struct {
+		name    string
+		status  *types.NodeStatus
+		healthy bool
+	}{
+		{"in sync", &types.NodeStatus{LatestHeight: 100, LatestBlockTime: time.Now()}, true},
+		{"catching up", &types.NodeStatus{LatestHeight: 100, LatestBlockTime: time.Now(), CatchingUp: true}, false},
+		{"stale block", &types.NodeStatus{LatestHeight: 100, LatestBlockTime: time.Now().Add(-2 * time.Minute)}, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctl := gomock.NewController(t)
+			cc := mocks.NewMockClientController(ctl)
+			cc.EXPECT().QueryNodeStatus(gomock.Any()).Return(tc.status, nil).Times(1)
+
+			app := &FinalityProviderApp{cc: cc, config: &cfg}
+			err := app.checkNodeStatus(context.Background())
+			if tc.healthy {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPollerLag(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, uint64(3), pollerLag(12, 10))
+	require.Equal(t, uint64(1), pollerLag(10, 10))
+	require.Equal(t, uint64(0), pollerLag(9, 10))
+}
+
+// TestCheckPollerLagWhileStarting tests that the poller lag is not checked
+// while the instance is starting, i.e., before its poller is started
+func TestCheckPollerLagWhileStarting(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	cfg := fpcfg.DefaultConfig()
+	cfg.HealthConfig.MaxPollerLag = 1
+	cfg.PollerConfig.AutoChainScanningMode = true
+	cfg.RetryConfig.VotingPower.Attempts = 1
+
+	// the start height is determined once the query is released
+	started := make(chan struct{})
+	release := make(chan struct{})
+	ctl := gomock.NewController(t)
+	cc := mocks.NewMockClientController(ctl)
+	cc.EXPECT().QueryFinalityProviderHighestVotedHeight(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *btcec.PublicKey) (uint64, error) {
+			close(started)
+			<-release
+
+			return 0, errors.New("connection refused")
+		}).Times(1)
+
+	db, err := fpcfg.DefaultDBConfigWithHomePath(t.TempDir()).GetDBBackend()
+	require.NoError(t, err)
+	defer db.Close()
+	fpStore, err := store.NewFinalityProviderStore(db)
+	require.NoError(t, err)
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+	require.NoError(t, err)
+	err = fpStore.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, fp.ChainID)
+	require.NoError(t, err)
+
+	logger := testutil.GetTestLogger(t)
+	fpi, err := NewFinalityProviderInstance(bbntypes.NewBIP340PubKeyFromBTCPK(fp.BtcPk), &cfg, fpStore, nil, cc, nil,
+		metrics.NewFpMetrics(), NewEventBus(logger), nil, "", make(chan *CriticalError), logger)
+	require.NoError(t, err)
+	app := &FinalityProviderApp{cc: cc, config: &cfg, fpIns: fpi}
+
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- fpi.Start()
+	}()
+	<-started
+
+	require.True(t, fpi.IsRunning())
+	require.NoError(t, app.checkPollerLag(context.Background()))
+
+	close(release)
+	require.Error(t, <-startErr)
+}
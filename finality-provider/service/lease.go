@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/lease"
+)
+
+const (
+	// leaseReleaseTimeout is the timeout of releasing the lease upon shutdown
+	leaseReleaseTimeout = 5 * time.Second
+)
+
+// newLease returns the configured lease along with the ID of the daemon as
+// its holder, or a nil lease if it is disabled
+func newLease(cfg *fpcfg.LeaseConfig) (lease.Lease, string, error) {
+	if !cfg.Enabled() {
+		return nil, "", nil
+	}
+
+	holder := cfg.HolderID
+	if holder == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get the hostname as the lease holder ID: %w", err)
+		}
+		holder = hostname
+	}
+
+	switch cfg.Backend {
+	case fpcfg.LeaseBackendFile:
+		return lease.NewFileLease(cfg.FilePath), holder, nil
+	default:
+		return nil, "", fmt.Errorf("unsupported lease backend %s", cfg.Backend)
+	}
+}
+
+// leaseLoop periodically acquires or renews the lease so that the finality
+// provider instance only runs while the daemon holds the lease
+func (app *FinalityProviderApp) leaseLoop(fpi *FinalityProviderInstance) {
+	defer app.wg.Done()
+
+	ctx, cancel := contextWithQuit(context.Background(), app.quit)
+	defer cancel()
+
+	ticker := time.NewTicker(app.config.LeaseConfig.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		app.renewLease(ctx, fpi)
+
+		select {
+		case <-ticker.C:
+		case <-app.quit:
+			app.logger.Info("exiting lease loop")
+
+			return
+		}
+	}
+}
+
+// renewLease acquires or renews the lease, and starts the instance once the
+// daemon becomes active or stops it once the daemon loses the lease. The
+// daemon steps down if the lease cannot be renewed for whatever reason, so
+// that it never votes after a standby daemon may have taken over
+func (app *FinalityProviderApp) renewLease(ctx context.Context, fpi *FinalityProviderInstance) {
+	pkHex := fpi.GetBtcPkHex()
+
+	// the instance stopped by the supervisor or removed does not vote, so
+	// the lease is released for a standby daemon to take over
+	app.leaseMu.Lock()
+	if app.isLeaseHolder && !fpi.IsRunning() {
+		app.logger.Warn("the finality-provider instance is not running, the finality provider becomes standby",
+			zap.String("pk", pkHex), zap.String("holder", app.leaseHolder))
+		app.stepDownLocked(ctx, pkHex)
+	}
+	app.leaseMu.Unlock()
+
+	// the lease is acquired again once the supervisor restarts the instance,
+	// so that the daemon does not bypass the restart backoff
+	if app.supervisor.restarting.Load() {
+		return
+	}
+	if cur, err := app.GetFinalityProviderInstance(); err != nil || cur != fpi {
+		return
+	}
+
+	acquired, err := app.lease.Acquire(ctx, app.leaseHolder, app.config.LeaseConfig.TTL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		app.logger.Warn("failed to acquire the lease",
+			zap.String("pk", pkHex), zap.String("holder", app.leaseHolder), zap.Error(err))
+	}
+
+	app.leaseMu.Lock()
+	defer app.leaseMu.Unlock()
+
+	switch {
+	case acquired && !app.isLeaseHolder:
+		app.logger.Info("acquired the lease, the finality provider becomes active",
+			zap.String("pk", pkHex), zap.String("holder", app.leaseHolder))
+
+		if err := fpi.Start(); err != nil {
+			// let another daemon take over
+			app.logger.Error("failed to start the finality-provider instance after acquiring the lease",
+				zap.String("pk", pkHex), zap.Error(err))
+			if err := app.lease.Release(ctx, app.leaseHolder); err != nil {
+				app.logger.Warn("failed to release the lease",
+					zap.String("pk", pkHex), zap.Error(err))
+			}
+
+			return
+		}
+
+		app.isLeaseHolder = true
+		app.metrics.RecordFpLeaseHeld(pkHex, true)
+	case !acquired && app.isLeaseHolder:
+		app.logger.Warn("lost the lease, the finality provider becomes standby",
+			zap.String("pk", pkHex), zap.String("holder", app.leaseHolder))
+
+		app.isLeaseHolder = false
+		app.metrics.RecordFpLeaseHeld(pkHex, false)
+
+		if fpi.IsRunning() {
+			if err := fpi.Stop(); err != nil {
+				app.logger.Error("failed to stop the finality-provider instance after losing the lease",
+					zap.String("pk", pkHex), zap.Error(err))
+			}
+		}
+	case !acquired:
+		app.metrics.RecordFpLeaseHeld(pkHex, false)
+	}
+}
+
+// restartInstance restarts the instance unless the lease is held by another
+// daemon, in which case false is returned and the instance is started once
+// the daemon acquires the lease
+func (app *FinalityProviderApp) restartInstance(fpi *FinalityProviderInstance) (bool, error) {
+	if app.lease == nil {
+		return true, fpi.Start()
+	}
+
+	app.leaseMu.Lock()
+	defer app.leaseMu.Unlock()
+
+	// the lease is released while the instance is stopped
+	if !app.isLeaseHolder {
+		ctx, cancel := contextWithQuit(context.Background(), app.quit)
+		defer cancel()
+
+		acquired, err := app.lease.Acquire(ctx, app.leaseHolder, app.config.LeaseConfig.TTL)
+		if err != nil || !acquired {
+			return false, nil
+		}
+		app.isLeaseHolder = true
+		app.metrics.RecordFpLeaseHeld(fpi.GetBtcPkHex(), true)
+	}
+
+	return true, fpi.Start()
+}
+
+// checkLeaseHeld returns ErrLeaseNotHeld unless the lease is held by the
+// daemon, which fences off the votes of a daemon that has lost the lease
+// before it notices
+func (app *FinalityProviderApp) checkLeaseHeld(ctx context.Context) error {
+	r, err := app.lease.Record(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read the lease: %w", err)
+	}
+
+	if !r.IsHeldBy(app.leaseHolder, time.Now()) {
+		return fmt.Errorf("%w by %s", ErrLeaseNotHeld, app.leaseHolder)
+	}
+
+	return nil
+}
+
+// stepDownLocked releases the lease so that a standby daemon takes over
+// without waiting for the lease to expire. It must be called with leaseMu
+func (app *FinalityProviderApp) stepDownLocked(ctx context.Context, pkHex string) {
+	app.isLeaseHolder = false
+	app.metrics.RecordFpLeaseHeld(pkHex, false)
+
+	if err := app.lease.Release(ctx, app.leaseHolder); err != nil {
+		app.logger.Warn("failed to release the lease", zap.Error(err))
+
+		return
+	}
+
+	app.logger.Info("released the lease", zap.String("holder", app.leaseHolder))
+}
+
+// releaseLease releases the lease upon shutdown so that a standby daemon
+// takes over without waiting for the lease to expire
+func (app *FinalityProviderApp) releaseLease() {
+	app.leaseMu.Lock()
+	defer app.leaseMu.Unlock()
+
+	if !app.isLeaseHolder {
+		return
+	}
+	app.isLeaseHolder = false
+
+	ctx, cancel := context.WithTimeout(context.Background(), leaseReleaseTimeout)
+	defer cancel()
+
+	if err := app.lease.Release(ctx, app.leaseHolder); err != nil {
+		app.logger.Warn("failed to release the lease", zap.Error(err))
+
+		return
+	}
+
+	app.logger.Info("released the lease", zap.String("holder", app.leaseHolder))
+}
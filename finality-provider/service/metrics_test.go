@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// TestVoteLatencyMetrics tests that the latency of the stages of the votes
+// and the public randomness runway are recorded for the finality provider
+func TestVoteLatencyMetrics(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+	fpCfg := newSimulatedChainFpConfig(t, simCfg)
+	_, fpPk := startFPAppOnSimulatedChain(t, r, fpCfg, simChain, nil)
+	requireFinalizedHeight(t, simChain, 3)
+
+	require.Eventually(t, func() bool {
+		for _, stage := range []string{metrics.VoteStageSigned, metrics.VoteStageBroadcast, metrics.VoteStageIncluded} {
+			m := gatherMetric(t, "fp_vote_latency_seconds", map[string]string{"fp_btc_pk_hex": fpPk.MarshalHex(), "stage": stage})
+			if m == nil || m.GetHistogram().GetSampleCount() == 0 {
+				return false
+			}
+		}
+		runway := gatherMetric(t, "fp_pub_rand_runway_blocks", map[string]string{"fp_btc_pk_hex": fpPk.MarshalHex()})
+
+		return runway != nil && runway.GetGauge().GetValue() > 0
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	signed := gatherMetric(t, "fp_vote_latency_seconds", map[string]string{"fp_btc_pk_hex": fpPk.MarshalHex(), "stage": metrics.VoteStageSigned})
+	included := gatherMetric(t, "fp_vote_latency_seconds", map[string]string{"fp_btc_pk_hex": fpPk.MarshalHex(), "stage": metrics.VoteStageIncluded})
+	require.LessOrEqual(t, signed.GetHistogram().GetSampleSum(), included.GetHistogram().GetSampleSum())
+}
+
+// gatherMetric returns the registered metric of the given name with the given
+// labels, or nil if it is not recorded yet
+func gatherMetric(t *testing.T, name string, labels map[string]string) *dto.Metric {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			matched := 0
+			for _, l := range m.GetLabel() {
+				if v, ok := labels[l.GetName()]; ok && v == l.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(labels) {
+				return m
+			}
+		}
+	}
+
+	return nil
+}
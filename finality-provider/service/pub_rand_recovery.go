@@ -0,0 +1,68 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// pubRandCommitLookback is the number of the last on-chain randomness commits
+// searched for the commit covering the blocks whose proofs are missing
+const pubRandCommitLookback = 10
+
+// recoverPubRandProofs rebuilds the inclusion proofs of the on-chain
+// randomness commits covering the given heights and saves them to the local
+// store, as these commits may be sent by another daemon of the finality
+// provider, i.e., the primary instance in shadow mode or the previous lease
+// holder after a failover. The commitments are checked against the on-chain
+// ones to make sure the EOTS manager derives the same randomness as the
+// daemon that sent them
+func (fp *FinalityProviderInstance) recoverPubRandProofs(ctx context.Context, startHeight, endHeight uint64) error {
+	commits, err := fp.lastCommittedPublicRandWithRetry(ctx, pubRandCommitLookback)
+	if err != nil {
+		return fmt.Errorf("failed to query the last committed public randomness: %w", err)
+	}
+
+	recovered := false
+	for commitStartHeight, commit := range commits {
+		if commit.NumPubRand == 0 || commitStartHeight > endHeight || commitStartHeight+commit.NumPubRand <= startHeight {
+			continue
+		}
+		if commit.NumPubRand > math.MaxUint32 {
+			return fmt.Errorf("too many public randomness in the commit from height %d", commitStartHeight)
+		}
+
+		// #nosec G115 -- performed the conversion check above
+		pubRandList, err := fp.getPubRandList(ctx, commitStartHeight, uint32(commit.NumPubRand))
+		if err != nil {
+			return fmt.Errorf("failed to generate randomness: %w", err)
+		}
+
+		commitment, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+		if !bytes.Equal(commitment, commit.Commitment) {
+			return fmt.Errorf("the public randomness derived from height %d does not match the commitment on the consumer chain", commitStartHeight)
+		}
+
+		if err := fp.pubRandState.addPubRandProofList(fp.btcPk.MustMarshal(), fp.GetChainID(), commitStartHeight, commit.NumPubRand, proofList); err != nil {
+			return fmt.Errorf("failed to save public randomness to DB: %w", err)
+		}
+
+		fp.logger.Info("recovered the inclusion proofs of the public randomness committed on the consumer chain",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", commitStartHeight),
+			zap.Uint64("num_pub_rand", commit.NumPubRand),
+		)
+		recovered = true
+	}
+
+	if !recovered {
+		return fmt.Errorf("no public randomness committed on the consumer chain for heights %d-%d", startHeight, endHeight)
+	}
+
+	return nil
+}
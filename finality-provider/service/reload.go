@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/log"
+)
+
+// ConfigLoader loads the config of the daemon, which is validated
+type ConfigLoader func() (*fpcfg.Config, error)
+
+// EnableConfigReload allows the config to be reloaded with the given loader,
+// applying the reloaded logging levels to the given levels, which can also be
+// changed with SetLogLevel
+func (app *FinalityProviderApp) EnableConfigReload(load ConfigLoader, logLevels *log.Levels) {
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	app.loadConfig = load
+	app.logLevels = logLevels
+}
+
+// ReloadConfig loads the config again and applies the changed fields that are
+// safe to change to the running finality provider. The other changed fields
+// are reported as requiring a restart
+func (app *FinalityProviderApp) ReloadConfig() (*fpcfg.ReloadResult, error) {
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	if app.loadConfig == nil {
+		return nil, ErrConfigReloadDisabled
+	}
+
+	newCfg, err := app.loadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the config: %w", err)
+	}
+
+	level, subsystemLevels, err := newCfg.LogLevels()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	reloaded, res := app.reloadedCfg.Reload(newCfg)
+
+	if app.logLevels != nil {
+		app.logLevels.Set(level, subsystemLevels)
+	}
+	if fpi, err := app.GetFinalityProviderInstance(); err == nil {
+		fpi.applyReloadedConfig(reloaded)
+	}
+	app.reloadedCfg = reloaded
+
+	app.logger.Info("reloaded the config", zap.Strings("applied", res.Applied))
+	if len(res.RestartRequired) > 0 {
+		app.logger.Warn("the changed fields of the config are only applied once the daemon is restarted",
+			zap.Strings("restart_required", res.RestartRequired))
+	}
+
+	return res, nil
+}
+
+// SetLogLevel sets the logging level of the given subsystem, or the one of the
+// root logger if the subsystem is empty, until the config is reloaded or the
+// daemon restarted. The resulting levels are returned
+func (app *FinalityProviderApp) SetLogLevel(subsystem, level string) (zapcore.Level, map[string]zapcore.Level, error) {
+	app.reloadMu.Lock()
+	defer app.reloadMu.Unlock()
+
+	if app.logLevels == nil {
+		return zapcore.InvalidLevel, nil, ErrLogLevelsDisabled
+	}
+
+	lvl, err := log.ParseLevel(level)
+	if err != nil {
+		return zapcore.InvalidLevel, nil, err
+	}
+
+	if err := app.logLevels.SetSubsystem(subsystem, lvl); err != nil {
+		return zapcore.InvalidLevel, nil, err
+	}
+	app.logger.Info("set the logging level", zap.String("subsystem", subsystem), zap.String("level", lvl.String()))
+
+	rootLevel, subsystemLevels := app.logLevels.Get()
+
+	return rootLevel, subsystemLevels, nil
+}
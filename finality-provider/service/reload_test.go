@@ -0,0 +1,118 @@
+package service_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/log"
+)
+
+// TestReloadConfig tests that the reloaded config is applied to the running
+// finality provider, and the changed fields requiring a restart are reported
+func TestReloadConfig(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+
+	fpCfg := newSimulatedChainFpConfig(t, simCfg)
+	app, _ := startFPAppOnSimulatedChain(t, r, fpCfg, simChain, nil)
+	requireFinalizedHeight(t, simChain, 3)
+
+	_, events, cancel := app.SubscribeEvents()
+	defer cancel()
+
+	_, err := app.ReloadConfig()
+	require.ErrorIs(t, err, service.ErrConfigReloadDisabled)
+	_, _, err = app.SetLogLevel(log.SubsystemPoller, "debug")
+	require.ErrorIs(t, err, service.ErrLogLevelsDisabled)
+
+	newCfg := *fpCfg
+	pollerCfg := *fpCfg.PollerConfig
+	newCfg.PollerConfig = &pollerCfg
+	newCfg.LogLevel = "debug"
+	newCfg.SubsystemLogLevels = []string{log.SubsystemPoller + "=warn"}
+	newCfg.NumPubRand = fpCfg.NumPubRand * 2
+	newCfg.BatchSubmissionSize = 5
+	newCfg.SignatureSubmissionInterval = 2 * simCfg.BlockInterval
+	newCfg.PollerConfig.PollInterval = 2 * simCfg.BlockInterval
+	newCfg.RPCListener = "127.0.0.1:1234"
+
+	var loadErr error
+	logLevels := log.NewLevels(zapcore.InfoLevel, nil)
+	app.EnableConfigReload(func() (*config.Config, error) {
+		return &newCfg, loadErr
+	}, logLevels)
+
+	res, err := app.ReloadConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"LogLevel",
+		"SubsystemLogLevels",
+		"NumPubRand",
+		"BatchSubmissionSize",
+		"SignatureSubmissionInterval",
+		"PollerConfig.PollInterval",
+	}, res.Applied)
+	require.Equal(t, []string{"RPCListener"}, res.RestartRequired)
+	level, subsystemLevels := logLevels.Get()
+	require.Equal(t, zapcore.DebugLevel, level)
+	require.Equal(t, map[string]zapcore.Level{log.SubsystemPoller: zapcore.WarnLevel}, subsystemLevels)
+
+	// the level of a subsystem is changed at runtime
+	level, subsystemLevels, err = app.SetLogLevel(log.SubsystemStore, "error")
+	require.NoError(t, err)
+	require.Equal(t, zapcore.DebugLevel, level)
+	require.Equal(t, map[string]zapcore.Level{
+		log.SubsystemPoller: zapcore.WarnLevel,
+		log.SubsystemStore:  zapcore.ErrorLevel,
+	}, subsystemLevels)
+	_, _, err = app.SetLogLevel("unknown", "error")
+	require.Error(t, err)
+	_, _, err = app.SetLogLevel(log.SubsystemStore, "verbose")
+	require.Error(t, err)
+
+	// the randomness is committed with the reloaded number of randomness
+	require.Eventually(t, func() bool {
+		for {
+			select {
+			case ev := <-events:
+				if commit := ev.GetPubRandCommitted(); commit != nil && commit.NumPubRand == uint64(newCfg.NumPubRand) {
+					return true
+				}
+			default:
+				return false
+			}
+		}
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+
+	// the applied fields are not reported again, unlike the fields that
+	// require a restart
+	res, err = app.ReloadConfig()
+	require.NoError(t, err)
+	require.Empty(t, res.Applied)
+	require.Equal(t, []string{"RPCListener"}, res.RestartRequired)
+
+	// nothing is applied if the config fails to load
+	loadErr = errors.New("invalid config")
+	newCfg.LogLevel = "error"
+	_, err = app.ReloadConfig()
+	require.ErrorIs(t, err, loadErr)
+	level, _ = logLevels.Get()
+	require.Equal(t, zapcore.DebugLevel, level)
+
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+	votedHeight := fpIns.GetLastVotedHeight()
+	requireFinalizedHeight(t, simChain, votedHeight+3)
+}
@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/replay"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestReplayRecordedSession tests that a finality provider with other keys
+// votes the same blocks when replaying the recording of another one
+func TestReplayRecordedSession(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+	recorder, err := replay.NewRecorder(simChain, path, testutil.GetTestLogger(t))
+	require.NoError(t, err)
+
+	fpCfg := newSimulatedChainFpConfig(t, simCfg)
+	app, fpPk := startFPAppOnSimulatedChain(t, r, fpCfg, recorder, nil)
+	requireFinalizedHeight(t, simChain, 5)
+	fpIns, err := app.GetFinalityProviderInstance()
+	require.NoError(t, err)
+
+	// the recording ends once the finality provider has committed enough
+	// randomness and voted the tip of the stopped chain, so that the replay
+	// ends in the same state
+	require.Eventually(t, func() bool {
+		commits, err := simChain.QueryLastCommittedPublicRand(context.Background(), fpPk.MustToBTCPK(), 1)
+		require.NoError(t, err)
+		for startHeight, commit := range commits {
+			tip, err := simChain.QueryBestBlock(context.Background())
+			require.NoError(t, err)
+
+			return startHeight+commit.NumPubRand > tip.Height+uint64(fpCfg.NumPubRand)
+		}
+
+		return false
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	simChain.Stop()
+	tip, err := simChain.QueryBestBlock(context.Background())
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return fpIns.GetLastVotedHeight() == tip.Height
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+	// the recording is closed along with the app
+	require.NoError(t, app.Stop())
+
+	replayer, err := replay.LoadReplayer(path)
+	require.NoError(t, err)
+	replayedApp, _ := startFPAppOnSimulatedChain(t, r, newSimulatedChainFpConfig(t, simCfg), replayer, nil)
+	replayedFpIns, err := replayedApp.GetFinalityProviderInstance()
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return replayedFpIns.GetLastVotedHeight() == tip.Height
+	}, 2*eventuallyWaitTimeOut, eventuallyPollTime)
+	require.Equal(t, proto.FinalityProviderStatus_ACTIVE, replayedFpIns.GetStatus())
+
+	// nothing is sent to the chain while replaying
+	votedHeight, err := simChain.QueryFinalityProviderHighestVotedHeight(context.Background(), replayedFpIns.GetBtcPk())
+	require.Error(t, err)
+	require.Zero(t, votedHeight)
+}
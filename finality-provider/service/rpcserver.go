@@ -10,9 +10,11 @@ import (
 	sdkmath "cosmossdk.io/math"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/lightningnetwork/lnd/kvdb"
 	"google.golang.org/grpc"
 	protobuf "google.golang.org/protobuf/proto"
 
+	"github.com/babylonlabs-io/finality-provider/backup"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/babylonlabs-io/finality-provider/version"
@@ -27,6 +29,7 @@ type rpcServer struct {
 	proto.UnimplementedFinalityProvidersServer
 
 	app *FinalityProviderApp
+	db  kvdb.Backend
 
 	quit chan struct{}
 	wg   sync.WaitGroup
@@ -35,10 +38,12 @@ type rpcServer struct {
 // newRPCServer creates a new RPC sever from the set of input dependencies.
 func newRPCServer(
 	fpa *FinalityProviderApp,
+	db kvdb.Backend,
 ) *rpcServer {
 	return &rpcServer{
 		quit: make(chan struct{}),
 		app:  fpa,
+		db:   db,
 	}
 }
 
@@ -83,7 +88,7 @@ func (r *rpcServer) GetInfo(context.Context, *proto.GetInfoRequest) (*proto.GetI
 
 // CreateFinalityProvider generates a finality-provider object and saves it in the database
 func (r *rpcServer) CreateFinalityProvider(
-	_ context.Context,
+	ctx context.Context,
 	req *proto.CreateFinalityProviderRequest,
 ) (*proto.CreateFinalityProviderResponse, error) {
 	commissionRate, err := sdkmath.LegacyNewDecFromStr(req.Commission)
@@ -102,6 +107,7 @@ func (r *rpcServer) CreateFinalityProvider(
 	}
 
 	result, err := r.app.CreateFinalityProvider(
+		ctx,
 		req.KeyName,
 		req.ChainId,
 		req.Passphrase,
@@ -122,7 +128,7 @@ func (r *rpcServer) CreateFinalityProvider(
 
 // AddFinalitySignature adds a manually constructed finality signature to Babylon
 // NOTE: this is only used for presentation/testing purposes
-func (r *rpcServer) AddFinalitySignature(_ context.Context, req *proto.AddFinalitySignatureRequest) (
+func (r *rpcServer) AddFinalitySignature(ctx context.Context, req *proto.AddFinalitySignatureRequest) (
 	*proto.AddFinalitySignatureResponse,
 	error,
 ) {
@@ -159,7 +165,7 @@ func (r *rpcServer) AddFinalitySignature(_ context.Context, req *proto.AddFinali
 			Hash:   req.AppHash,
 		}
 
-		txRes, privKey, err := fpi.TestSubmitFinalitySignatureAndExtractPrivKey(b, req.CheckDoubleSign)
+		txRes, privKey, err := fpi.TestSubmitFinalitySignatureAndExtractPrivKey(ctx, b, req.CheckDoubleSign)
 		if err != nil {
 			return nil, err
 		}
@@ -169,7 +175,7 @@ func (r *rpcServer) AddFinalitySignature(_ context.Context, req *proto.AddFinali
 		// if privKey is not empty, then this BTC finality-provider
 		// has voted for a fork and will be slashed
 		if privKey != nil {
-			localPrivKey, err := r.app.getFpPrivKey(fpPk.MustMarshal())
+			localPrivKey, err := r.app.getFpPrivKey(ctx, fpPk.MustMarshal())
 			if err != nil {
 				r.app.logger.Error(fmt.Sprintf("err get priv key %s", err.Error()))
 
@@ -200,14 +206,14 @@ func (r *rpcServer) AddFinalitySignature(_ context.Context, req *proto.AddFinali
 }
 
 // UnjailFinalityProvider unjails a finality-provider
-func (r *rpcServer) UnjailFinalityProvider(_ context.Context, req *proto.UnjailFinalityProviderRequest) (
+func (r *rpcServer) UnjailFinalityProvider(ctx context.Context, req *proto.UnjailFinalityProviderRequest) (
 	*proto.UnjailFinalityProviderResponse, error) {
 	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := r.app.UnjailFinalityProvider(fpPk)
+	res, err := r.app.UnjailFinalityProvider(ctx, fpPk)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unjail the finality-provider: %w", err)
 	}
@@ -230,7 +236,7 @@ func (r *rpcServer) QueryFinalityProvider(_ context.Context, req *proto.QueryFin
 	return &proto.QueryFinalityProviderResponse{FinalityProvider: fp}, nil
 }
 
-func (r *rpcServer) EditFinalityProvider(_ context.Context, req *proto.EditFinalityProviderRequest) (*proto.EmptyResponse, error) {
+func (r *rpcServer) EditFinalityProvider(ctx context.Context, req *proto.EditFinalityProviderRequest) (*proto.EmptyResponse, error) {
 	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
 	if err != nil {
 		return nil, err
@@ -247,7 +253,7 @@ func (r *rpcServer) EditFinalityProvider(_ context.Context, req *proto.EditFinal
 	}
 
 	fpPub := fpPk.MustToBTCPK()
-	updatedMsg, err := r.app.cc.EditFinalityProvider(fpPub, &rate, descBytes)
+	updatedMsg, err := r.app.cc.EditFinalityProvider(ctx, fpPub, &rate, descBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -284,6 +290,97 @@ func (r *rpcServer) UnsafeRemoveMerkleProof(_ context.Context, req *proto.Remove
 	return nil, nil
 }
 
+// ReloadConfig re-reads the config file and applies the changed fields that
+// are safe to change to the running finality provider
+func (r *rpcServer) ReloadConfig(_ context.Context, _ *proto.ReloadConfigRequest) (*proto.ReloadConfigResponse, error) {
+	res, err := r.app.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ReloadConfigResponse{
+		AppliedFields:         res.Applied,
+		RestartRequiredFields: res.RestartRequired,
+	}, nil
+}
+
+// SetLogLevel sets the logging level of the given subsystem, or the one of the
+// root logger if no subsystem is given
+func (r *rpcServer) SetLogLevel(_ context.Context, req *proto.SetLogLevelRequest) (*proto.SetLogLevelResponse, error) {
+	level, subsystemLevels, err := r.app.SetLogLevel(req.Subsystem, req.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.SetLogLevelResponse{
+		Level:           level.String(),
+		SubsystemLevels: make(map[string]string, len(subsystemLevels)),
+	}
+	for subsystem, subsystemLevel := range subsystemLevels {
+		res.SubsystemLevels[subsystem] = subsystemLevel.String()
+	}
+
+	return res, nil
+}
+
+// SubscribeEvents streams the recent events of the finality provider daemon,
+// followed by the upcoming ones if follow is set
+func (r *rpcServer) SubscribeEvents(req *proto.SubscribeEventsRequest, stream proto.FinalityProviders_SubscribeEventsServer) error {
+	var fpPkHex string
+	if req.BtcPk != "" {
+		fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+		if err != nil {
+			return err
+		}
+		fpPkHex = fpPk.MarshalHex()
+	}
+
+	send := func(ev *proto.FinalityProviderEvent) error {
+		if fpPkHex != "" && ev.BtcPkHex != fpPkHex {
+			return nil
+		}
+
+		return stream.Send(ev)
+	}
+
+	recent, events, cancel := r.app.SubscribeEvents()
+	defer cancel()
+
+	for _, ev := range recent {
+		if err := send(ev); err != nil {
+			return err
+		}
+	}
+
+	if !req.Follow {
+		return nil
+	}
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := send(ev); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// BackupDB streams a consistent snapshot of the database, which is taken
+// within a read transaction while the daemon keeps running
+func (r *rpcServer) BackupDB(_ *proto.BackupDBRequest, stream proto.FinalityProviders_BackupDBServer) error {
+	return backup.Stream(r.db, func(chunk []byte) error {
+		return stream.Send(&proto.BackupDBResponse{Chunk: chunk})
+	})
+}
+
 func parseEotsPk(eotsPkHex string) (*bbntypes.BIP340PubKey, error) {
 	if eotsPkHex == "" {
 		return nil, fmt.Errorf("eots-pk cannot be empty")
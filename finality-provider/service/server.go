@@ -4,17 +4,30 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"os/signal"
 	"sync"
 	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/lightningnetwork/lnd/kvdb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
+	"github.com/babylonlabs-io/finality-provider/backup"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/gateway"
+	"github.com/babylonlabs-io/finality-provider/health"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 )
 
+// gatewayShutdownTimeout is the time given to the in-flight REST requests
+// to complete upon shutdown
+const gatewayShutdownTimeout = 5 * time.Second
+
 // Server is the main daemon construct for the Finality Provider server. It handles
 // spinning up the RPC sever, the database, and any other components that the
 // Taproot Asset server needs to function.
@@ -35,7 +48,7 @@ func NewFinalityProviderServer(cfg *fpcfg.Config, l *zap.Logger, fpa *FinalityPr
 	return &Server{
 		cfg:       cfg,
 		logger:    l,
-		rpcServer: newRPCServer(fpa),
+		rpcServer: newRPCServer(fpa, db),
 		db:        db,
 		quit:      make(chan struct{}, 1),
 	}
@@ -48,12 +61,16 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		return nil
 	}
 
-	// Start the metrics server.
+	checker := health.NewChecker(s.cfg.HealthConfig.CheckTimeout)
+	checker.AddLivenessCheck("db", health.DBCheck(s.db))
+	s.rpcServer.app.addHealthChecks(checker)
+
+	// Start the metrics server, which also serves the health endpoints.
 	promAddr, err := s.cfg.Metrics.Address()
 	if err != nil {
 		return fmt.Errorf("failed to get prometheus address: %w", err)
 	}
-	metricsServer := metrics.Start(promAddr, s.logger)
+	metricsServer := metrics.Start(promAddr, s.logger, checker)
 
 	defer func() {
 		s.logger.Info("Shutdown complete")
@@ -70,6 +87,11 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 		s.logger.Info("Metrics server stopped")
 	}()
 
+	// The scheduled snapshots are stopped before the database is closed.
+	backupScheduler := backup.NewScheduler(s.cfg.Backup, s.db, s.cfg.DatabaseConfig.DBFileName, s.logger.Named(log.SubsystemStore))
+	backupScheduler.Start()
+	defer backupScheduler.Stop()
+
 	listenAddr := s.cfg.RPCListener
 	// we create listeners from the RPCListeners defined
 	// in the config.
@@ -87,18 +109,44 @@ func (s *Server) RunUntilShutdown(ctx context.Context) error {
 	if err := s.rpcServer.RegisterWithGrpcServer(grpcServer); err != nil {
 		return fmt.Errorf("failed to register gRPC server: %w", err)
 	}
+	checker.RegisterGrpcHealthServer(grpcServer, proto.FinalityProviders_ServiceDesc.ServiceName)
 
 	// All the necessary parts have been registered, so we can
 	// actually start listening for requests.
 	s.startGrpcListen(grpcServer, []net.Listener{lis})
 
-	s.logger.Info("Finality Provider Daemon is fully active!")
+	if s.cfg.HTTPListener != "" {
+		gw, err := gateway.Start(ctx, s.cfg.HTTPListener, lis.Addr().String(),
+			proto.OpenAPIDocument, proto.RegisterFinalityProvidersHandler, s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to start the REST gateway: %w", err)
+		}
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), gatewayShutdownTimeout)
+			defer cancel()
+			gw.Stop(stopCtx)
+		}()
+	}
 
-	// Wait for shutdown signal from either a graceful server stop or from
-	// the interrupt handler.
-	<-ctx.Done()
+	s.logger.Info("Finality Provider Daemon is fully active!")
 
-	return nil
+	// Reload the config upon SIGHUP until the shutdown signal is received
+	// from either a graceful server stop or from the interrupt handler.
+	reloadSig := make(chan os.Signal, 1)
+	signal.Notify(reloadSig, syscall.SIGHUP)
+	defer signal.Stop(reloadSig)
+
+	for {
+		select {
+		case <-reloadSig:
+			s.logger.Info("received SIGHUP, reloading the config")
+			if _, err := s.rpcServer.app.ReloadConfig(); err != nil {
+				s.logger.Error("failed to reload the config", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
 }
 
 // startGrpcListen starts the GRPC server on the passed listeners.
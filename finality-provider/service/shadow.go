@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/babylonlabs-io/babylon/crypto/eots"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// shadowSignFinalitySig signs the block without recording it in the signing
+// history of the EOTS manager, which only reflects the votes actually sent,
+// and verifies the signature against the public randomness of the block
+func (fp *FinalityProviderInstance) shadowSignFinalitySig(ctx context.Context, b *types.BlockInfo, pubRand *btcec.FieldVal) (*bbntypes.SchnorrEOTSSig, error) {
+	msgToSign := getMsgToSignForVote(b.Height, b.Hash)
+	sig, err := fp.em.UnsafeSignEOTS(ctx, fp.btcPk.MustMarshal(), fp.GetChainID(), msgToSign, b.Height, fp.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign EOTS: %w", err)
+	}
+
+	if err := eots.Verify(fp.GetBtcPk(), pubRand, msgToSign, sig); err != nil {
+		return nil, fmt.Errorf("invalid EOTS signature for height %d: %w", b.Height, err)
+	}
+
+	return bbntypes.NewSchnorrEOTSSigFromModNScalar(sig), nil
+}
+
+// recordShadowFinalitySigs logs and exposes the finality signatures that
+// would have been submitted to the consumer chain
+func (fp *FinalityProviderInstance) recordShadowFinalitySigs(blocks []*types.BlockInfo, sigList []*btcec.ModNScalar) {
+	startHeight, endHeight := blocks[0].Height, blocks[len(blocks)-1].Height
+
+	for i, b := range blocks {
+		fp.submissionLogger.Debug("shadow mode: would have submitted the finality signature",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("height", b.Height),
+			zap.String("block_hash", hex.EncodeToString(b.Hash)),
+			zap.String("signature", bbntypes.NewSchnorrEOTSSigFromModNScalar(sigList[i]).ToHexStr()),
+		)
+	}
+
+	fp.submissionLogger.Info("shadow mode: skipped submitting the finality signatures to the consumer chain",
+		zap.String("consumer_id", string(fp.GetChainID())),
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", startHeight),
+		zap.Uint64("end_height", endHeight),
+	)
+
+	fp.metrics.AddToFpTotalShadowVotedBlocks(fp.GetBtcPkHex(), float64(len(blocks)))
+	ev := newVotesSubmittedEvent(fp.GetBtcPkHex(), startHeight, endHeight, "")
+	ev.GetVotesSubmitted().Shadow = true
+	fp.events.Publish(ev)
+}
+
+// recordShadowPubRandCommit verifies the signature over the randomness commit
+// and logs and exposes the commit that would have been sent to the consumer chain
+func (fp *FinalityProviderInstance) recordShadowPubRandCommit(startHeight, numPubRand uint64, commitment []byte, sig *schnorr.Signature) error {
+	hash, err := getHashToSignForCommitPubRand(startHeight, numPubRand, commitment)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(hash, fp.GetBtcPk()) {
+		return fmt.Errorf("invalid signature over the public randomness commit from height %d", startHeight)
+	}
+
+	fp.randomnessLogger.Info("shadow mode: skipped committing public randomness to the consumer chain",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", startHeight),
+		zap.Uint64("num_pub_rand", numPubRand),
+		zap.String("commitment", hex.EncodeToString(commitment)),
+		zap.String("signature", hex.EncodeToString(sig.Serialize())),
+	)
+
+	fp.metrics.IncrementFpTotalShadowPubRandCommits(fp.GetBtcPkHex())
+	ev := newPubRandCommittedEvent(fp.GetBtcPkHex(), startHeight, numPubRand, "")
+	ev.GetPubRandCommitted().Shadow = true
+	fp.events.Publish(ev)
+
+	return nil
+}
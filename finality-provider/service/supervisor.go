@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// instanceSupervisor keeps track of the restarts of the finality provider
+// instance and decides when it should be restarted
+type instanceSupervisor struct {
+	cfg *fpcfg.SupervisorConfig
+
+	restarts        uint32
+	lastRestartTime time.Time
+
+	// restarting is set while a restart of the instance is scheduled
+	restarting atomic.Bool
+}
+
+func newInstanceSupervisor(cfg *fpcfg.SupervisorConfig) *instanceSupervisor {
+	return &instanceSupervisor{cfg: cfg}
+}
+
+// nextRestart returns the backoff before the next restart, which is doubled
+// upon each consecutive restart, and false if the restart budget is exhausted.
+// The budget is replenished once the instance has been running for
+// BudgetResetInterval since the last restart
+func (s *instanceSupervisor) nextRestart(now time.Time) (time.Duration, bool) {
+	if s.restarts > 0 && now.Sub(s.lastRestartTime) >= s.cfg.BudgetResetInterval {
+		s.restarts = 0
+	}
+
+	if s.restarts >= s.cfg.MaxRestarts {
+		return 0, false
+	}
+
+	backoff := s.cfg.InitialBackoff
+	for i := uint32(0); i < s.restarts && backoff < s.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, s.cfg.MaxBackoff)
+
+	s.restarts++
+	s.lastRestartTime = now.Add(backoff)
+
+	return backoff, true
+}
+
+// isTerminalErr returns true if restarting the instance cannot resolve the
+// critical error
+func isTerminalErr(err error) bool {
+	switch {
+	case errors.Is(err, ErrDoppelgangerDetected):
+		return true
+	case clientcontroller.IsUnrecoverable(err):
+		return true
+	case errors.Is(err, eotstypes.ErrDoubleSign):
+		return true
+	default:
+		return false
+	}
+}
+
+// superviseCriticalErr persists the critical error of the instance, stops
+// the instance and schedules its restart with backoff if the error is
+// transient. The process exits if the error is terminal
+func (app *FinalityProviderApp) superviseCriticalErr(fpi *FinalityProviderInstance, criticalErr error) {
+	pkHex := fpi.GetBtcPkHex()
+
+	if err := fpi.recordCrash(criticalErr); err != nil {
+		app.logger.Error("failed to persist the crash reason of the finality-provider instance",
+			zap.String("pk", pkHex), zap.Error(err))
+	}
+
+	if isTerminalErr(criticalErr) {
+		// deliver the pending notifications before exiting
+		app.notifier.Stop()
+		app.logger.Fatal(instanceTerminatingMsg,
+			zap.String("pk", pkHex), zap.Error(criticalErr))
+	}
+
+	// the restart is scheduled before stopping the instance so that the lease
+	// loop does not start the stopped instance ahead of the backoff
+	scheduled := app.supervisor.restarting.CompareAndSwap(false, true)
+
+	if fpi.IsRunning() {
+		if err := fpi.Stop(); err != nil {
+			app.logger.Error("failed to stop the finality-provider instance",
+				zap.String("pk", pkHex), zap.Error(err))
+		}
+	}
+
+	if !scheduled {
+		app.logger.Debug("the restart of the finality-provider instance is already scheduled",
+			zap.String("pk", pkHex), zap.Error(criticalErr))
+
+		return
+	}
+
+	// the backoff runs outside the critical error loop so that the senders
+	// of critical errors are not blocked
+	app.wg.Add(1)
+	go app.restartWithBackoff(fpi, criticalErr)
+}
+
+// restartWithBackoff restarts the instance after the backoff until it
+// succeeds. The process exits if the restart budget is exhausted
+func (app *FinalityProviderApp) restartWithBackoff(fpi *FinalityProviderInstance, criticalErr error) {
+	defer app.wg.Done()
+	defer app.supervisor.restarting.Store(false)
+
+	pkHex := fpi.GetBtcPkHex()
+
+	for {
+		backoff, ok := app.supervisor.nextRestart(time.Now())
+		if !ok {
+			app.notifier.Stop()
+			app.logger.Fatal(instanceTerminatingMsg,
+				zap.String("pk", pkHex),
+				zap.String("reason", "the restart budget is exhausted"),
+				zap.Uint32("max_restarts", app.config.SupervisorConfig.MaxRestarts),
+				zap.Error(criticalErr),
+			)
+		}
+
+		app.logger.Warn("restarting the finality-provider instance due to critical error",
+			zap.String("pk", pkHex),
+			zap.Uint32("restarts", app.supervisor.restarts),
+			zap.Duration("backoff", backoff),
+			zap.Error(criticalErr),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-app.quit:
+			timer.Stop()
+
+			return
+		}
+
+		// the instance might be removed during the backoff, e.g., slashed
+		if cur, err := app.GetFinalityProviderInstance(); err != nil || cur != fpi {
+			app.logger.Info("the finality-provider instance is not restarted as it is removed",
+				zap.String("pk", pkHex))
+
+			return
+		}
+
+		app.metrics.IncrementFpTotalInstanceRestarts(pkHex)
+
+		restarted, err := app.restartInstance(fpi)
+		if !restarted {
+			app.logger.Info("the finality-provider instance is not restarted as the daemon is standby",
+				zap.String("pk", pkHex))
+
+			return
+		}
+
+		if criticalErr = err; criticalErr == nil {
+			app.logger.Info("the finality-provider instance is restarted", zap.String("pk", pkHex))
+
+			return
+		}
+
+		app.logger.Error("failed to restart the finality-provider instance",
+			zap.String("pk", pkHex), zap.Error(criticalErr))
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/stretchr/testify/require"
+
+	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestInstanceSupervisorBackoff(t *testing.T) {
+	t.Parallel()
+
+	cfg := &fpcfg.SupervisorConfig{
+		MaxRestarts:         4,
+		InitialBackoff:      time.Second,
+		MaxBackoff:          3 * time.Second,
+		BudgetResetInterval: time.Minute,
+	}
+	s := newInstanceSupervisor(cfg)
+	now := time.Now()
+
+	// the backoff is doubled upon each restart up to the max backoff
+	for _, expBackoff := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		backoff, ok := s.nextRestart(now)
+		require.True(t, ok)
+		require.Equal(t, expBackoff, backoff)
+		now = now.Add(backoff)
+	}
+
+	// the budget is exhausted
+	_, ok := s.nextRestart(now)
+	require.False(t, ok)
+
+	// the budget is replenished after the instance has been running long enough
+	backoff, ok := s.nextRestart(now.Add(cfg.BudgetResetInterval))
+	require.True(t, ok)
+	require.Equal(t, cfg.InitialBackoff, backoff)
+
+	// restarts are disabled
+	s = newInstanceSupervisor(&fpcfg.SupervisorConfig{})
+	_, ok = s.nextRestart(now)
+	require.False(t, ok)
+}
+
+func TestIsTerminalErr(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name       string
+		err        error
+		isTerminal bool
+	}{
+		{"doppelganger", fmt.Errorf("failed to start: %w", ErrDoppelgangerDetected), true},
+		{"unrecoverable chain error", fmt.Errorf("failed to vote: %w", finalitytypes.ErrInvalidFinalitySig), true},
+		{"double sign", fmt.Errorf("failed to sign EOTS: %w", eotstypes.ErrDoubleSign), true},
+		{"poller failure", fmt.Errorf("%w: %w", ErrChainPollerMaxFailedCycles, errors.New("connection refused")), false},
+		{"query failure", errors.New("failed to get the last block: connection refused"), false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.isTerminal, isTerminalErr(tc.err))
+		})
+	}
+}
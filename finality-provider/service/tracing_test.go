@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/clientcontroller/simulation"
+	"github.com/babylonlabs-io/finality-provider/tracing"
+)
+
+// TestTracing tests that the stages of voting and committing randomness are
+// traced as the children of their spans, down to the calls to the consumer
+// chain. The global tracer provider is set, so the test is not run in parallel
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracing.NewTracerProvider(exporter, "fpd", 1)
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		require.NoError(t, tp.Shutdown(context.Background()))
+	})
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	simCfg := simulation.DefaultConfig()
+	simCfg.BlockInterval = 50 * time.Millisecond
+	simChain := newSimulatedChain(t, simCfg)
+	fpCfg := newSimulatedChainFpConfig(t, simCfg)
+	cc := clientcontroller.NewTracedClientController(simChain, "BabylonController")
+	startFPAppOnSimulatedChain(t, r, fpCfg, cc, nil)
+	requireFinalizedHeight(t, simChain, 3)
+
+	// the pairs of the names of a parent span and of one of its children
+	expected := [][2]string{
+		{"ChainPoller.pollBlock", "BabylonController.QueryBlock"},
+		{"FinalityProviderInstance.submitFinalitySigs", "FinalityProviderInstance.processBlocksToVote"},
+		{"FinalityProviderInstance.processBlocksToVote", "BabylonController.QueryFinalityProviderVotingPower"},
+		{"FinalityProviderInstance.submitFinalitySigs", "FinalityProviderInstance.retrySubmitSigsUntilFinalized"},
+		{"FinalityProviderInstance.retrySubmitSigsUntilFinalized", "FinalityProviderInstance.SubmitBatchFinalitySignatures"},
+		{"FinalityProviderInstance.SubmitBatchFinalitySignatures", "FinalityProviderInstance.signFinalitySigs"},
+		{"FinalityProviderInstance.SubmitBatchFinalitySignatures", "BabylonController.SubmitBatchFinalitySigs"},
+		{"FinalityProviderInstance.CommitPubRand", "BabylonController.CommitPubRandList"},
+	}
+	require.Eventually(t, func() bool {
+		require.NoError(t, tp.ForceFlush(context.Background()))
+		spans := exporter.GetSpans()
+
+		names := make(map[string]string, len(spans))
+		for _, s := range spans {
+			names[s.SpanContext.SpanID().String()] = s.Name
+		}
+		found := make(map[[2]string]bool)
+		for _, s := range spans {
+			if parent, ok := names[s.Parent.SpanID().String()]; ok {
+				found[[2]string{parent, s.Name}] = true
+			}
+		}
+		for _, pair := range expected {
+			if !found[pair] {
+				return false
+			}
+		}
+
+		return true
+	}, eventuallyWaitTimeOut, eventuallyPollTime)
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"fmt"
+	"time"
 
 	sdkmath "cosmossdk.io/math"
 	"github.com/btcsuite/btcd/btcec/v2"
@@ -27,21 +28,13 @@ type FinalityProviderStore struct {
 // NewFinalityProviderStore returns a new store backed by db
 func NewFinalityProviderStore(db kvdb.Backend) (*FinalityProviderStore, error) {
 	store := &FinalityProviderStore{db}
-	if err := store.initBuckets(); err != nil {
+	if _, err := MigrateDB(db); err != nil {
 		return nil, err
 	}
 
 	return store, nil
 }
 
-func (s *FinalityProviderStore) initBuckets() error {
-	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
-		_, err := tx.CreateTopLevelBucket(finalityProviderBucketName)
-
-		return err
-	})
-}
-
 func (s *FinalityProviderStore) CreateFinalityProvider(
 	fpAddr sdk.AccAddress,
 	btcPk *btcec.PublicKey,
@@ -153,6 +146,19 @@ func (s *FinalityProviderStore) SetFpLastVotedHeight(btcPk *btcec.PublicKey, las
 	return s.setFinalityProviderState(btcPk, setFpLastVotedHeight)
 }
 
+// SetFpLastCrash records the critical error that stopped the finality provider
+// instance so that it survives restarts of the daemon
+func (s *FinalityProviderStore) SetFpLastCrash(btcPk *btcec.PublicKey, reason string, crashTime time.Time) error {
+	setFpLastCrash := func(fp *proto.FinalityProvider) error {
+		fp.LastCrashReason = reason
+		fp.LastCrashTime = crashTime.Unix()
+
+		return nil
+	}
+
+	return s.setFinalityProviderState(btcPk, setFpLastCrash)
+}
+
 func (s *FinalityProviderStore) setFinalityProviderState(
 	btcPk *btcec.PublicKey,
 	stateTransitionFn func(provider *proto.FinalityProvider) error,
@@ -224,9 +230,13 @@ func (s *FinalityProviderStore) GetFinalityProvider(btcPk *btcec.PublicKey) (*St
 // pagination is probably not needed as the expected number of finality providers
 // in the store is small
 func (s *FinalityProviderStore) GetAllStoredFinalityProviders() ([]*StoredFinalityProvider, error) {
+	return listFinalityProviders(s.db)
+}
+
+func listFinalityProviders(db kvdb.Backend) ([]*StoredFinalityProvider, error) {
 	var storedFps []*StoredFinalityProvider
 
-	err := s.db.View(func(tx kvdb.RTx) error {
+	err := db.View(func(tx kvdb.RTx) error {
 		fpBucket := tx.ReadBucket(finalityProviderBucketName)
 		if fpBucket == nil {
 			return ErrCorruptedFinalityProviderDB
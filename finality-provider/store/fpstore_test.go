@@ -4,8 +4,10 @@ import (
 	"math/rand"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,6 +15,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/migration"
 	"github.com/babylonlabs-io/finality-provider/testutil"
 )
 
@@ -70,6 +73,18 @@ func FuzzFinalityProvidersStore(f *testing.F) {
 		actualFp, err := vs.GetFinalityProvider(fp.BtcPk)
 		require.NoError(t, err)
 		require.Equal(t, fp.BtcPk, actualFp.BtcPk)
+		require.Empty(t, actualFp.LastCrashReason)
+		require.True(t, actualFp.LastCrashTime.IsZero())
+
+		// record a crash of the finality provider instance
+		crashReason := testutil.GenRandomHexStr(r, 10)
+		crashTime := time.Unix(int64(r.Int31()), 0)
+		err = vs.SetFpLastCrash(fp.BtcPk, crashReason, crashTime)
+		require.NoError(t, err)
+		actualFp, err = vs.GetFinalityProvider(fp.BtcPk)
+		require.NoError(t, err)
+		require.Equal(t, crashReason, actualFp.LastCrashReason)
+		require.True(t, crashTime.Equal(actualFp.LastCrashTime))
 
 		_, randomBtcPk, err := datagen.GenRandomBTCKeyPair(r)
 		require.NoError(t, err)
@@ -198,3 +213,40 @@ func TestUpdateFpStatusFromVotingPower(t *testing.T) {
 		})
 	}
 }
+
+// TestFinalityProviderStoreSchemaVersion tests that the store migrates the
+// database to the latest schema version, and refuses a newer database
+func TestFinalityProviderStoreSchemaVersion(t *testing.T) {
+	t.Parallel()
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	fpdb, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, fpdb.Close())
+	}()
+
+	plan, err := fpstore.CheckDBMigrations(fpdb)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), plan.Current)
+	require.NotEmpty(t, plan.Pending)
+
+	_, err = fpstore.NewFinalityProviderStore(fpdb)
+	require.NoError(t, err)
+	plan, err = fpstore.CheckDBMigrations(fpdb)
+	require.NoError(t, err)
+	require.Equal(t, plan.Target, plan.Current)
+	require.Empty(t, plan.Pending)
+
+	// the database is migrated by a newer binary
+	newerMigrations := make([]migration.Migration, plan.Target+1)
+	for i := range newerMigrations {
+		newerMigrations[i] = migration.Migration{Description: "noop", Migrate: func(kvdb.RwTx) error { return nil }}
+	}
+	_, err = migration.Run(fpdb, newerMigrations)
+	require.NoError(t, err)
+
+	_, err = fpstore.NewFinalityProviderStore(fpdb)
+	require.ErrorIs(t, err, migration.ErrDBVersionTooNew)
+	_, err = fpstore.NewPubRandProofStore(fpdb)
+	require.ErrorIs(t, err, migration.ErrDBVersionTooNew)
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// Filter selects the records of the database to inspect, the zero value
+// selecting all of them
+type Filter struct {
+	// BtcPkHex is the hex of the BIP-340 BTC public key of the finality
+	// provider, or empty for all of them
+	BtcPkHex string
+	// ChainID is the chain of the records, or empty for all of them
+	ChainID string
+	// FromHeight is the lowest height of the records
+	FromHeight uint64
+	// ToHeight is the highest height of the records, or 0 for no bound
+	ToHeight uint64
+}
+
+func (f *Filter) matchesFp(chainID, btcPkHex string) bool {
+	return (f.ChainID == "" || f.ChainID == chainID) && (f.BtcPkHex == "" || f.BtcPkHex == btcPkHex)
+}
+
+func (f *Filter) matchesHeight(height uint64) bool {
+	return height >= f.FromHeight && (f.ToHeight == 0 || height <= f.ToHeight)
+}
+
+// PubRandProofRange is a range of consecutive heights of which the proofs of
+// the committed public randomness are stored
+type PubRandProofRange struct {
+	ChainID     string `json:"chain_id"`
+	BtcPkHex    string `json:"btc_pk_hex"`
+	StartHeight uint64 `json:"start_height"`
+	EndHeight   uint64 `json:"end_height"`
+}
+
+// ListFinalityProviders returns the finality providers of the database
+// selected by the filter, without changing the database
+func ListFinalityProviders(db kvdb.Backend, f *Filter) ([]*StoredFinalityProvider, error) {
+	fps, err := listFinalityProviders(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*StoredFinalityProvider
+	for _, fp := range fps {
+		if f.matchesFp(fp.ChainID, fp.GetBIP340BTCPK().MarshalHex()) {
+			selected = append(selected, fp)
+		}
+	}
+
+	return selected, nil
+}
+
+// ListPubRandProofRanges returns the ranges of heights of the proofs of the
+// public randomness stored in the database selected by the filter, without
+// changing the database
+func ListPubRandProofRanges(db kvdb.Backend, f *Filter) ([]*PubRandProofRange, error) {
+	// the ranges of each chain and finality provider, in order of height
+	ranges := make(map[string][]*PubRandProofRange)
+
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(pubRandProofBucketName)
+		if bucket == nil {
+			return ErrCorruptedPubRandProofDB
+		}
+
+		return bucket.ForEach(func(k, _ []byte) error {
+			// the key is (chainID || pk || height)
+			if len(k) < schnorr.PubKeyBytesLen+8 {
+				return ErrCorruptedPubRandProofDB
+			}
+			heightIdx := len(k) - 8
+			prefix := k[:heightIdx]
+			chainID := string(k[:heightIdx-schnorr.PubKeyBytesLen])
+			btcPkHex := hex.EncodeToString(k[heightIdx-schnorr.PubKeyBytesLen : heightIdx])
+			height := sdk.BigEndianToUint64(k[heightIdx:])
+
+			if !f.matchesFp(chainID, btcPkHex) || !f.matchesHeight(height) {
+				return nil
+			}
+
+			fpRanges := ranges[string(prefix)]
+			if n := len(fpRanges); n > 0 && fpRanges[n-1].EndHeight+1 == height {
+				fpRanges[n-1].EndHeight = height
+
+				return nil
+			}
+			ranges[string(prefix)] = append(fpRanges, &PubRandProofRange{
+				ChainID:     chainID,
+				BtcPkHex:    btcPkHex,
+				StartHeight: height,
+				EndHeight:   height,
+			})
+
+			return nil
+		})
+	}, func() {
+		ranges = make(map[string][]*PubRandProofRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*PubRandProofRange
+	for _, fpRanges := range ranges {
+		res = append(res, fpRanges...)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ChainID != res[j].ChainID {
+			return res[i].ChainID < res[j].ChainID
+		}
+		if res[i].BtcPkHex != res[j].BtcPkHex {
+			return res[i].BtcPkHex < res[j].BtcPkHex
+		}
+
+		return res[i].StartHeight < res[j].StartHeight
+	})
+
+	return res, nil
+}
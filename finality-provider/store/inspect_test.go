@@ -0,0 +1,77 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzListPubRandProofRanges tests listing the ranges of heights of the
+// stored proofs of the public randomness
+func FuzzListPubRandProofRanges(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		t.Parallel()
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDBBackend()
+		require.NoError(t, err)
+		defer db.Close()
+
+		vs, err := store.NewPubRandProofStore(db)
+		require.NoError(t, err)
+
+		chainID := []byte("test-chain")
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		pk := fp.GetBIP340BTCPK().MustMarshal()
+		pkHex := fp.GetBIP340BTCPK().MarshalHex()
+
+		// two commits of consecutive heights, followed by a gap
+		numPubRand := uint64(1 + r.Intn(100))
+		startHeight := uint64(1 + r.Intn(1000))
+		gapStartHeight := startHeight + 2*numPubRand + 1
+		for _, height := range []uint64{startHeight, startHeight + numPubRand, gapStartHeight} {
+			rl, err := datagen.GenRandomPubRandList(r, numPubRand)
+			require.NoError(t, err)
+			err = vs.AddPubRandProofList(chainID, pk, height, numPubRand, rl.ProofList)
+			require.NoError(t, err)
+		}
+
+		// the proofs of another chain are filtered out
+		rl, err := datagen.GenRandomPubRandList(r, numPubRand)
+		require.NoError(t, err)
+		err = vs.AddPubRandProofList([]byte("test-chain-2"), pk, startHeight, numPubRand, rl.ProofList)
+		require.NoError(t, err)
+
+		ranges, err := store.ListPubRandProofRanges(db, &store.Filter{ChainID: string(chainID)})
+		require.NoError(t, err)
+		require.Equal(t, []*store.PubRandProofRange{
+			{ChainID: string(chainID), BtcPkHex: pkHex, StartHeight: startHeight, EndHeight: startHeight + 2*numPubRand - 1},
+			{ChainID: string(chainID), BtcPkHex: pkHex, StartHeight: gapStartHeight, EndHeight: gapStartHeight + numPubRand - 1},
+		}, ranges)
+
+		// the ranges are clipped to the heights of the filter
+		ranges, err = store.ListPubRandProofRanges(db, &store.Filter{
+			BtcPkHex:   pkHex,
+			ChainID:    string(chainID),
+			FromHeight: startHeight + 1,
+			ToHeight:   gapStartHeight,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*store.PubRandProofRange{
+			{ChainID: string(chainID), BtcPkHex: pkHex, StartHeight: startHeight + 1, EndHeight: startHeight + 2*numPubRand - 1},
+			{ChainID: string(chainID), BtcPkHex: pkHex, StartHeight: gapStartHeight, EndHeight: gapStartHeight},
+		}, ranges)
+
+		ranges, err = store.ListPubRandProofRanges(db, &store.Filter{BtcPkHex: testutil.GenRandomHexStr(r, 32)})
+		require.NoError(t, err)
+		require.Empty(t, ranges)
+	})
+}
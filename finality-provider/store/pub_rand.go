@@ -23,21 +23,13 @@ type PubRandProofStore struct {
 // NewPubRandProofStore returns a new store backed by db
 func NewPubRandProofStore(db kvdb.Backend) (*PubRandProofStore, error) {
 	store := &PubRandProofStore{db}
-	if err := store.initBuckets(); err != nil {
+	if _, err := MigrateDB(db); err != nil {
 		return nil, err
 	}
 
 	return store, nil
 }
 
-func (s *PubRandProofStore) initBuckets() error {
-	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
-		_, err := tx.CreateTopLevelBucket(pubRandProofBucketName)
-
-		return err
-	})
-}
-
 // getKey key is (chainID || pk || height)
 func getKey(chainID, pk []byte, height uint64) []byte {
 	// Convert height to bytes
@@ -125,7 +117,8 @@ func (s *PubRandProofStore) GetPubRandProof(chainID []byte, pk []byte, height ui
 			return ErrCorruptedPubRandProofDB
 		}
 
-		proofBytes = bucket.Get(key)
+		// the value is only valid during the transaction
+		proofBytes = bytes.Clone(bucket.Get(key))
 		if proofBytes == nil {
 			return ErrPubRandProofNotFound
 		}
@@ -160,7 +153,8 @@ func (s *PubRandProofStore) GetPubRandProofList(chainID []byte,
 			if proofBytes == nil {
 				return ErrPubRandProofNotFound
 			}
-			proofBytesList = append(proofBytesList, proofBytes)
+			// the value is only valid during the transaction
+			proofBytesList = append(proofBytesList, bytes.Clone(proofBytes))
 		}
 
 		return nil
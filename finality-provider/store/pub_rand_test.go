@@ -48,3 +48,48 @@ func FuzzRemoveMerkleProof(f *testing.F) {
 		require.ErrorIs(t, err, store.ErrPubRandProofNotFound)
 	})
 }
+
+// TestGetPubRandProofAfterTx tests that the proofs returned stay the same
+// after the pages of the database they are read from are reused
+func TestGetPubRandProofAfterTx(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(10))
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	db, err := cfg.GetDBBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	vs, err := store.NewPubRandProofStore(db)
+	require.NoError(t, err)
+
+	chainID := []byte("test-chain")
+	fpPk := testutil.GenRandomFinalityProvider(r, t).GetBIP340BTCPK().MustMarshal()
+	numPubRand := uint64(100)
+	rl, err := datagen.GenRandomPubRandList(r, numPubRand)
+	require.NoError(t, err)
+	require.NoError(t, vs.AddPubRandProofList(chainID, fpPk, 1, numPubRand, rl.ProofList))
+
+	proof, err := vs.GetPubRandProof(chainID, fpPk, 1)
+	require.NoError(t, err)
+	proofList, err := vs.GetPubRandProofList(chainID, fpPk, 1, numPubRand)
+	require.NoError(t, err)
+
+	// the proofs are overwritten and the database grows
+	for i := 0; i < 20; i++ {
+		other, err := datagen.GenRandomPubRandList(r, numPubRand)
+		require.NoError(t, err)
+		require.NoError(t, vs.RemovePubRandProofList(chainID, fpPk, numPubRand+1))
+		require.NoError(t, vs.AddPubRandProofList(chainID, fpPk, 1, numPubRand, other.ProofList))
+	}
+
+	expected, err := rl.ProofList[0].ToProto().Marshal()
+	require.NoError(t, err)
+	require.Equal(t, expected, proof)
+	for i, p := range rl.ProofList {
+		expected, err := p.ToProto().Marshal()
+		require.NoError(t, err)
+		require.Equal(t, expected, proofList[i])
+	}
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"fmt"
+	"time"
 
 	sdkmath "cosmossdk.io/math"
 	bbn "github.com/babylonlabs-io/babylon/types"
@@ -20,6 +21,8 @@ type StoredFinalityProvider struct {
 	ChainID         string
 	LastVotedHeight uint64
 	Status          proto.FinalityProviderStatus
+	LastCrashReason string
+	LastCrashTime   time.Time
 }
 
 func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalityProvider, error) {
@@ -38,6 +41,11 @@ func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalit
 		return nil, fmt.Errorf("invalid commission: %w", err)
 	}
 
+	var lastCrashTime time.Time
+	if fp.LastCrashTime > 0 {
+		lastCrashTime = time.Unix(fp.LastCrashTime, 0)
+	}
+
 	return &StoredFinalityProvider{
 		FPAddr:          fp.FpAddr,
 		BtcPk:           btcPk,
@@ -46,6 +54,8 @@ func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalit
 		ChainID:         fp.ChainId,
 		LastVotedHeight: fp.LastVotedHeight,
 		Status:          fp.Status,
+		LastCrashReason: fp.LastCrashReason,
+		LastCrashTime:   lastCrashTime,
 	}, nil
 }
 
@@ -54,6 +64,11 @@ func (sfp *StoredFinalityProvider) GetBIP340BTCPK() *bbn.BIP340PubKey {
 }
 
 func (sfp *StoredFinalityProvider) ToFinalityProviderInfo() *proto.FinalityProviderInfo {
+	var lastCrashTime int64
+	if !sfp.LastCrashTime.IsZero() {
+		lastCrashTime = sfp.LastCrashTime.Unix()
+	}
+
 	return &proto.FinalityProviderInfo{
 		FpAddr:   sfp.FPAddr,
 		BtcPkHex: sfp.GetBIP340BTCPK().MarshalHex(),
@@ -67,5 +82,7 @@ func (sfp *StoredFinalityProvider) ToFinalityProviderInfo() *proto.FinalityProvi
 		Commission:      sfp.Commission.String(),
 		LastVotedHeight: sfp.LastVotedHeight,
 		Status:          sfp.Status.String(),
+		LastCrashReason: sfp.LastCrashReason,
+		LastCrashTime:   lastCrashTime,
 	}
 }
@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// OpenAPIPath is the path the OpenAPI document of the gateway is served at
+const OpenAPIPath = "/openapi.json"
+
+// RegisterFunc registers the handlers of a gRPC service with the gateway
+type RegisterFunc func(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error
+
+// Server serves the REST/JSON endpoints of a gRPC server by forwarding
+// the requests to it
+type Server struct {
+	httpServer *http.Server
+	conn       *grpc.ClientConn
+	logger     *zap.Logger
+}
+
+// Start listens on addr and serves the REST/JSON gateway of the gRPC server
+// listening on grpcAddr, along with its OpenAPI document. The requests are
+// forwarded through a regular gRPC connection, so they go through the same
+// checks as the ones sent to the gRPC server directly. As the gateway serves
+// plain HTTP, addr must be a loopback address
+func Start(
+	ctx context.Context,
+	addr, grpcAddr string,
+	openAPIDoc []byte,
+	register RegisterFunc,
+	logger *zap.Logger,
+) (*Server, error) {
+	tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway address %s: %w", addr, err)
+	}
+	if !tcpAddr.IP.IsLoopback() {
+		return nil, fmt.Errorf("the gateway address %s is not a loopback address", addr)
+	}
+
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the gRPC server %s: %w", grpcAddr, err)
+	}
+
+	gwMux := runtime.NewServeMux()
+	if err := register(ctx, gwMux, conn); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("failed to register the gateway handlers: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", gwMux)
+	mux.HandleFunc(OpenAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openAPIDoc)
+	})
+
+	lis, err := net.ListenTCP("tcp", tcpAddr)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s := &Server{
+		httpServer: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 2 * time.Second,
+			ReadTimeout:       5 * time.Second,
+			// no write timeout as the event subscriptions are long-lived
+			IdleTimeout: 30 * time.Second,
+		},
+		conn:   conn,
+		logger: logger,
+	}
+
+	go func() {
+		s.logger.Info("REST gateway is listening", zap.String("address", lis.Addr().String()))
+		if err := s.httpServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("REST gateway stopped unexpectedly", zap.Error(err))
+		}
+	}()
+
+	return s, nil
+}
+
+// Stop gracefully shuts down the gateway
+func (s *Server) Stop(ctx context.Context) {
+	s.logger.Info("Stopping REST gateway")
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.logger.Error("REST gateway shutdown failed", zap.Error(err))
+	}
+	if err := s.conn.Close(); err != nil {
+		s.logger.Error("failed to close the gRPC connection of the REST gateway", zap.Error(err))
+	}
+}
@@ -0,0 +1,83 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/gateway"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+type eotsManagerServer struct {
+	proto.UnimplementedEOTSManagerServer
+}
+
+func (s *eotsManagerServer) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	return &proto.PingResponse{}, nil
+}
+
+func TestGateway(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	proto.RegisterEOTSManagerServer(grpcServer, &eotsManagerServer{})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	addr := fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
+	gw, err := gateway.Start(context.Background(), addr, lis.Addr().String(),
+		proto.OpenAPIDocument, proto.RegisterEOTSManagerHandler, zap.NewNop())
+	require.NoError(t, err)
+	defer gw.Stop(context.Background())
+
+	get := func(path string) (int, []byte) {
+		resp, err := http.Get("http://" + addr + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, body
+	}
+
+	// the request is forwarded to the gRPC server
+	status, body := get("/v1/ping")
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{}`, string(body))
+
+	// the RPCs not exposed through the gateway are not found
+	status, _ = get("/v1/sign-eots")
+	require.Equal(t, http.StatusNotFound, status)
+
+	// the OpenAPI document is served
+	status, body = get(gateway.OpenAPIPath)
+	require.Equal(t, http.StatusOK, status)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &doc))
+	require.Contains(t, doc["paths"], "/v1/ping")
+	require.NotContains(t, doc["paths"], "/v1/keys")
+}
+
+func TestGatewayNonLoopbackAddr(t *testing.T) {
+	t.Parallel()
+
+	for _, addr := range []string{"0.0.0.0:12582", ":12582", "192.0.2.1:12582"} {
+		_, err := gateway.Start(context.Background(), addr, "127.0.0.1:12581",
+			proto.OpenAPIDocument, proto.RegisterEOTSManagerHandler, zap.NewNop())
+		require.ErrorContains(t, err, "not a loopback address", addr)
+	}
+}
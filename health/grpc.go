@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// grpcHealthServer implements the standard gRPC health service on top of the
+// readiness checks
+type grpcHealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	checker  *Checker
+	services map[string]struct{}
+}
+
+// RegisterGrpcHealthServer registers the standard gRPC health service, which
+// reports the readiness of the daemon for the overall server, i.e., the
+// empty service name, and the given services
+func (c *Checker) RegisterGrpcHealthServer(s *grpc.Server, services ...string) {
+	srv := &grpcHealthServer{
+		checker:  c,
+		services: map[string]struct{}{"": {}},
+	}
+	for _, service := range services {
+		srv.services[service] = struct{}{}
+	}
+
+	healthpb.RegisterHealthServer(s, srv)
+}
+
+func (s *grpcHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if _, ok := s.services[req.Service]; !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.Service)
+	}
+
+	if !s.checker.Readiness(ctx).Healthy() {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
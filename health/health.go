@@ -0,0 +1,190 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+const (
+	// DefaultCheckTimeout bounds each check if no timeout is given
+	DefaultCheckTimeout = 5 * time.Second
+
+	StatusOK   = "ok"
+	StatusFail = "fail"
+
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// CheckFunc returns an error if the checked component is unhealthy
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name     string
+	fn       CheckFunc
+	liveness bool
+}
+
+// CheckResult is the status of a single check
+type CheckResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the overall status along with the status of each check
+type Report struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+func (r *Report) Healthy() bool {
+	return r.Status == StatusOK
+}
+
+// Checker runs the health checks of a daemon. The liveness checks tell
+// whether the daemon should be restarted, while the readiness checks, which
+// include the liveness ones, tell whether it is able to do its work
+type Checker struct {
+	mu      sync.RWMutex
+	checks  []check
+	timeout time.Duration
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultCheckTimeout
+	}
+
+	return &Checker{timeout: timeout}
+}
+
+// AddLivenessCheck adds a check required for both liveness and readiness
+func (c *Checker) AddLivenessCheck(name string, fn CheckFunc) {
+	c.addCheck(check{name: name, fn: fn, liveness: true})
+}
+
+// AddReadinessCheck adds a check required for readiness only
+func (c *Checker) AddReadinessCheck(name string, fn CheckFunc) {
+	c.addCheck(check{name: name, fn: fn})
+}
+
+func (c *Checker) addCheck(ch check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, ch)
+}
+
+// Liveness runs the liveness checks
+func (c *Checker) Liveness(ctx context.Context) *Report {
+	return c.run(ctx, true)
+}
+
+// Readiness runs all the checks
+func (c *Checker) Readiness(ctx context.Context) *Report {
+	return c.run(ctx, false)
+}
+
+func (c *Checker) run(ctx context.Context, livenessOnly bool) *Report {
+	c.mu.RLock()
+	checks := make([]check, 0, len(c.checks))
+	for _, ch := range c.checks {
+		if !livenessOnly || ch.liveness {
+			checks = append(checks, ch)
+		}
+	}
+	c.mu.RUnlock()
+
+	report := &Report{
+		Status: StatusOK,
+		Checks: make([]CheckResult, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func(i int, ch check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			report.Checks[i] = CheckResult{Name: ch.name, Status: StatusOK}
+			if err := runCheck(checkCtx, ch.fn); err != nil {
+				report.Checks[i].Status = StatusFail
+				report.Checks[i].Error = err.Error()
+			}
+		}(i, ch)
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		if res.Status != StatusOK {
+			report.Status = StatusFail
+
+			break
+		}
+	}
+
+	return report
+}
+
+// runCheck runs the check and gives up once the context is done, in case
+// the check does not respect the context. A panic of the check fails it
+// instead of crashing the daemon
+func runCheck(ctx context.Context, fn CheckFunc) error {
+	errChan := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errChan <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		errChan <- fn(ctx)
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+}
+
+// LivenessHandler serves the liveness report, with status 503 if unhealthy
+func (c *Checker) LivenessHandler() http.Handler {
+	return reportHandler(c.Liveness)
+}
+
+// ReadinessHandler serves the readiness report, with status 503 if not ready
+func (c *Checker) ReadinessHandler() http.Handler {
+	return reportHandler(c.Readiness)
+}
+
+func reportHandler(run func(ctx context.Context) *Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := run(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Healthy() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// DBCheck checks that the database can be read
+func DBCheck(db kvdb.Backend) CheckFunc {
+	return func(_ context.Context) error {
+		return kvdb.View(db, func(_ kvdb.RTx) error {
+			return nil
+		}, func() {})
+	}
+}
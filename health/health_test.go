@@ -0,0 +1,120 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/health"
+)
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	checker := health.NewChecker(50 * time.Millisecond)
+	checker.AddLivenessCheck("db", func(context.Context) error { return nil })
+	checker.AddReadinessCheck("node", func(context.Context) error { return errors.New("catching up") })
+	checker.AddReadinessCheck("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	// the readiness checks do not affect the liveness
+	report := checker.Liveness(context.Background())
+	require.True(t, report.Healthy())
+	require.Len(t, report.Checks, 1)
+
+	report = checker.Readiness(context.Background())
+	require.False(t, report.Healthy())
+	require.Equal(t, []health.CheckResult{
+		{Name: "db", Status: health.StatusOK},
+		{Name: "node", Status: health.StatusFail, Error: "catching up"},
+		{Name: "slow", Status: health.StatusFail, Error: report.Checks[2].Error},
+	}, report.Checks)
+	require.Contains(t, report.Checks[2].Error, "timed out")
+
+	// the endpoints respond with 503 if not healthy
+	for path, expStatus := range map[string]int{
+		health.LivenessPath:  http.StatusOK,
+		health.ReadinessPath: http.StatusServiceUnavailable,
+	} {
+		mux := http.NewServeMux()
+		mux.Handle(health.LivenessPath, checker.LivenessHandler())
+		mux.Handle(health.ReadinessPath, checker.ReadinessHandler())
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, expStatus, rec.Code, path)
+
+		var report health.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		require.Equal(t, expStatus == http.StatusOK, report.Healthy())
+	}
+}
+
+// TestCheckerPanic tests that a panicking check fails instead of crashing
+// the daemon
+func TestCheckerPanic(t *testing.T) {
+	t.Parallel()
+
+	checker := health.NewChecker(time.Second)
+	checker.AddReadinessCheck("poller", func(context.Context) error { panic("nil poller") })
+
+	report := checker.Readiness(context.Background())
+	require.False(t, report.Healthy())
+	require.Contains(t, report.Checks[0].Error, "nil poller")
+}
+
+func TestGrpcHealthServer(t *testing.T) {
+	t.Parallel()
+
+	var ready atomic.Bool
+	checker := health.NewChecker(time.Second)
+	checker.AddReadinessCheck("ready", func(context.Context) error {
+		if !ready.Load() {
+			return errors.New("not ready")
+		}
+
+		return nil
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	checker.RegisterGrpcHealthServer(grpcServer, "proto.Service")
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+	ctx := context.Background()
+
+	res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "proto.Service"})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.Status)
+
+	ready.Store(true)
+	res, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -116,7 +116,7 @@ func TestDoubleSigning(t *testing.T) {
 	finalizedBlocks := tm.WaitForNFinalizedBlocks(t, 1)
 
 	// test duplicate vote which should be ignored
-	res, extractedKey, err := fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(finalizedBlocks[0], false)
+	res, extractedKey, err := fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(ctx, finalizedBlocks[0], false)
 	require.NoError(t, err)
 	require.Nil(t, extractedKey)
 	require.Empty(t, res)
@@ -131,11 +131,11 @@ func TestDoubleSigning(t *testing.T) {
 	}
 
 	// confirm we have double sign protection
-	_, _, err = fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(b, true)
+	_, _, err = fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(ctx, b, true)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "double sign")
 
-	_, extractedKey, err = fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(b, false)
+	_, extractedKey, err = fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(ctx, b, false)
 	require.NoError(t, err)
 	require.NotNil(t, extractedKey)
 	localKey := tm.GetFpPrivKey(t, fpIns.GetBtcPkBIP340().MustMarshal())
@@ -190,7 +190,7 @@ func TestCatchingUp(t *testing.T) {
 	t.Logf("the latest finalized block is at %v", finalizedHeight)
 
 	// check if the fast sync works by checking if the gap is not more than 1
-	currentHeaderRes, err := tm.BBNClient.QueryBestBlock()
+	currentHeaderRes, err := tm.BBNClient.QueryBestBlock(context.Background())
 	currentHeight := currentHeaderRes.Height
 	t.Logf("the current block is at %v", currentHeight)
 	require.NoError(t, err)
@@ -242,7 +242,7 @@ func TestFinalityProviderEditCmd(t *testing.T) {
 	err := cmd.Execute()
 	require.NoError(t, err)
 
-	gotFp, err := tm.BBNClient.QueryFinalityProvider(fpIns.GetBtcPk())
+	gotFp, err := tm.BBNClient.QueryFinalityProvider(ctx, fpIns.GetBtcPk())
 	require.NoError(t, err)
 
 	rate, err := sdkmath.LegacyNewDecFromStr(commissionRateStr)
@@ -268,7 +268,7 @@ func TestFinalityProviderEditCmd(t *testing.T) {
 	err = cmd.Execute()
 	require.NoError(t, err)
 
-	updatedFp, err := tm.BBNClient.QueryFinalityProvider(fpIns.GetBtcPk())
+	updatedFp, err := tm.BBNClient.QueryFinalityProvider(ctx, fpIns.GetBtcPk())
 	require.NoError(t, err)
 
 	updateFpDesc := updatedFp.FinalityProvider.Description
@@ -294,7 +294,7 @@ func TestFinalityProviderCreateCmd(t *testing.T) {
 	cmd := daemon.CommandCreateFP()
 
 	eotsKeyName := "eots-key-2"
-	eotsPkBz, err := tm.EOTSClient.CreateKey(eotsKeyName, passphrase, hdPath)
+	eotsPkBz, err := tm.EOTSClient.CreateKey(ctx, eotsKeyName, passphrase, hdPath)
 	require.NoError(t, err)
 	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
 	require.NoError(t, err)
@@ -344,7 +344,7 @@ func TestFinalityProviderCreateCmd(t *testing.T) {
 	err = cmd.Execute()
 	require.NoError(t, err)
 
-	fp, err := tm.BBNClient.QueryFinalityProvider(eotsPk.MustToBTCPK())
+	fp, err := tm.BBNClient.QueryFinalityProvider(ctx, eotsPk.MustToBTCPK())
 	require.NoError(t, err)
 	require.NotNil(t, fp)
 }
@@ -390,7 +390,7 @@ func TestPrintEotsCmd(t *testing.T) {
 	expected := make(map[string]string)
 	for i := 0; i < r.Intn(10); i++ {
 		eotsKeyName := fmt.Sprintf("eots-key-%s", datagen.GenRandomHexStr(r, 4))
-		ekey, err := tm.EOTSClient.CreateKey(eotsKeyName, passphrase, hdPath)
+		ekey, err := tm.EOTSClient.CreateKey(ctx, eotsKeyName, passphrase, hdPath)
 		require.NoError(t, err)
 		pk, err := schnorr.ParsePubKey(ekey)
 		require.NoError(t, err)
@@ -157,7 +157,7 @@ func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context) *s
 
 	// create eots key
 	eotsKeyName := fmt.Sprintf("eots-key-%s", datagen.GenRandomHexStr(r, 4))
-	eotsPkBz, err := tm.EOTSClient.CreateKey(eotsKeyName, passphrase, hdPath)
+	eotsPkBz, err := tm.EOTSClient.CreateKey(ctx, eotsKeyName, passphrase, hdPath)
 	require.NoError(t, err)
 	eotsPk, err := bbntypes.NewBIP340PubKey(eotsPkBz)
 	require.NoError(t, err)
@@ -191,7 +191,7 @@ func (tm *TestManager) AddFinalityProvider(t *testing.T, ctx context.Context) *s
 	// create and register the finality provider
 	commission := sdkmath.LegacyZeroDec()
 	desc := newDescription(testMoniker)
-	_, err = fpApp.CreateFinalityProvider(cfg.BabylonConfig.Key, testChainID, passphrase, eotsPk, desc, &commission)
+	_, err = fpApp.CreateFinalityProvider(ctx, cfg.BabylonConfig.Key, testChainID, passphrase, eotsPk, desc, &commission)
 	require.NoError(t, err)
 
 	cfg.RPCListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
@@ -266,7 +266,7 @@ func (tm *TestManager) WaitForFpPubRandTimestamped(t *testing.T, fpIns *service.
 	var err error
 
 	require.Eventually(t, func() bool {
-		lastCommittedHeight, err = fpIns.GetLastCommittedHeight()
+		lastCommittedHeight, err = fpIns.GetLastCommittedHeight(context.Background())
 		if err != nil {
 			return false
 		}
@@ -358,7 +358,7 @@ func (tm *TestManager) CheckBlockFinalization(t *testing.T, height uint64, num i
 
 	// as the votes have been collected, the block should be finalized
 	require.Eventually(t, func() bool {
-		b, err := tm.BBNClient.QueryBlock(height)
+		b, err := tm.BBNClient.QueryBlock(context.Background(), height)
 		if err != nil {
 			t.Logf("failed to query block at height %v: %s", height, err.Error())
 			return false
@@ -387,7 +387,7 @@ func (tm *TestManager) WaitForNFinalizedBlocks(t *testing.T, n int) []*types.Blo
 		err    error
 	)
 	require.Eventually(t, func() bool {
-		blocks, err = tm.BBNClient.QueryLatestFinalizedBlocks(uint64(n))
+		blocks, err = tm.BBNClient.QueryLatestFinalizedBlocks(context.Background(), uint64(n))
 		if err != nil {
 			t.Logf("failed to get the latest finalized block: %s", err.Error())
 			return false
@@ -401,13 +401,13 @@ func (tm *TestManager) WaitForNFinalizedBlocks(t *testing.T, n int) []*types.Blo
 }
 
 func (tm *TestManager) StopAndRestartFpAfterNBlocks(t *testing.T, n int, fpIns *service.FinalityProviderInstance) {
-	blockBeforeStop, err := tm.BBNClient.QueryBestBlock()
+	blockBeforeStop, err := tm.BBNClient.QueryBestBlock(context.Background())
 	require.NoError(t, err)
 	err = fpIns.Stop()
 	require.NoError(t, err)
 
 	require.Eventually(t, func() bool {
-		headerAfterStop, err := tm.BBNClient.QueryBestBlock()
+		headerAfterStop, err := tm.BBNClient.QueryBestBlock(context.Background())
 		if err != nil {
 			return false
 		}
@@ -422,7 +422,7 @@ func (tm *TestManager) StopAndRestartFpAfterNBlocks(t *testing.T, n int, fpIns *
 }
 
 func (tm *TestManager) GetFpPrivKey(t *testing.T, fpPk []byte) *btcec.PrivateKey {
-	record, err := tm.EOTSClient.KeyRecord(fpPk, passphrase)
+	record, err := tm.EOTSClient.KeyRecord(context.Background(), fpPk, passphrase)
 	require.NoError(t, err)
 	return record.PrivKey
 }
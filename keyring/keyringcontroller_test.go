@@ -1,6 +1,7 @@
 package keyring_test
 
 import (
+	"context"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -50,7 +51,7 @@ func FuzzCreatePoP(f *testing.F) {
 		}()
 		require.NoError(t, err)
 
-		btcPkBytes, err := em.CreateKey(keyName, passphrase, hdPath)
+		btcPkBytes, err := em.CreateKey(context.Background(), keyName, passphrase, hdPath)
 		require.NoError(t, err)
 		btcPk, err := types.NewBIP340PubKey(btcPkBytes)
 		require.NoError(t, err)
@@ -58,7 +59,7 @@ func FuzzCreatePoP(f *testing.F) {
 		require.NoError(t, err)
 
 		fpAddr := keyInfo.AccAddress
-		fpRecord, err := em.KeyRecord(btcPk.MustMarshal(), passphrase)
+		fpRecord, err := em.KeyRecord(context.Background(), btcPk.MustMarshal(), passphrase)
 		require.NoError(t, err)
 		pop, err := kc.CreatePop(fpAddr, fpRecord.PrivKey)
 		require.NoError(t, err)
@@ -0,0 +1,49 @@
+package log
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultMaxSize    = 100
+	defaultMaxBackups = 10
+	defaultCompress   = true
+)
+
+// Config defines the rotation and the retention of the log file
+type Config struct {
+	MaxSize        int           `long:"maxsize" description:"The size in megabytes beyond which the log file is rotated; the rotation by size is disabled if 0"`
+	RotateInterval time.Duration `long:"rotateinterval" description:"The interval between two rotations of the log file; the rotation by time is disabled if 0"`
+	MaxBackups     int           `long:"maxbackups" description:"The number of rotated log files to keep; all of them are kept if 0"`
+	MaxAge         int           `long:"maxage" description:"The number of days the rotated log files are kept; they are kept regardless of their age if 0"`
+	Compress       bool          `long:"compress" description:"Compress the rotated log files with gzip"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		MaxSize:    defaultMaxSize,
+		MaxBackups: defaultMaxBackups,
+		Compress:   defaultCompress,
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxSize < 0 {
+		return fmt.Errorf("the max size of the log file should not be negative")
+	}
+
+	if cfg.RotateInterval < 0 {
+		return fmt.Errorf("the rotate interval of the log file should not be negative")
+	}
+
+	if cfg.MaxBackups < 0 {
+		return fmt.Errorf("the max backups of the log file should not be negative")
+	}
+
+	if cfg.MaxAge < 0 {
+		return fmt.Errorf("the max age of the log file should not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package log
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// The subsystems whose logging level can be set apart from the one of the
+// root logger, which are the names of their loggers
+const (
+	SubsystemPoller           = "poller"
+	SubsystemSubmission       = "submission"
+	SubsystemRandomness       = "randomness"
+	SubsystemClientController = "clientcontroller"
+	SubsystemEOTSRPC          = "eotsrpc"
+	SubsystemStore            = "store"
+)
+
+// Subsystems are the subsystems whose logging level can be set
+var Subsystems = []string{
+	SubsystemPoller,
+	SubsystemSubmission,
+	SubsystemRandomness,
+	SubsystemClientController,
+	SubsystemEOTSRPC,
+	SubsystemStore,
+}
+
+// ParseSubsystemLevels parses the logging levels of the subsystems in the
+// form subsystem=level
+func ParseSubsystemLevels(levels []string) (map[string]zapcore.Level, error) {
+	res := make(map[string]zapcore.Level, len(levels))
+	for _, l := range levels {
+		subsystem, levelStr, ok := strings.Cut(l, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid subsystem logging level %q, expected subsystem=level", l)
+		}
+
+		subsystem = strings.TrimSpace(subsystem)
+		if !slices.Contains(Subsystems, subsystem) {
+			return nil, fmt.Errorf("unknown logging subsystem %q, expected one of %s",
+				subsystem, strings.Join(Subsystems, ", "))
+		}
+
+		level, err := ParseLevel(strings.TrimSpace(levelStr))
+		if err != nil {
+			return nil, err
+		}
+		res[subsystem] = level
+	}
+
+	return res, nil
+}
+
+// Levels are the logging level of the root logger and the ones of its
+// subsystems, which can be changed while the logger is in use
+type Levels struct {
+	mu         sync.RWMutex
+	level      zapcore.Level
+	subsystems map[string]zapcore.Level
+}
+
+func NewLevels(level zapcore.Level, subsystems map[string]zapcore.Level) *Levels {
+	l := &Levels{}
+	l.Set(level, subsystems)
+
+	return l
+}
+
+// Set sets the logging level of the root logger and the ones of the
+// subsystems, replacing the previous ones
+func (l *Levels) Set(level zapcore.Level, subsystems map[string]zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.level = level
+	l.subsystems = make(map[string]zapcore.Level, len(subsystems))
+	for subsystem, subsystemLevel := range subsystems {
+		l.subsystems[subsystem] = subsystemLevel
+	}
+}
+
+// SetSubsystem sets the logging level of the given subsystem, or the one of
+// the root logger if the subsystem is empty
+func (l *Levels) SetSubsystem(subsystem string, level zapcore.Level) error {
+	if subsystem != "" && !slices.Contains(Subsystems, subsystem) {
+		return fmt.Errorf("unknown logging subsystem %q, expected one of %s",
+			subsystem, strings.Join(Subsystems, ", "))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if subsystem == "" {
+		l.level = level
+	} else {
+		l.subsystems[subsystem] = level
+	}
+
+	return nil
+}
+
+// Get returns the logging level of the root logger and the ones of the
+// subsystems set apart from it
+func (l *Levels) Get() (zapcore.Level, map[string]zapcore.Level) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	subsystems := make(map[string]zapcore.Level, len(l.subsystems))
+	for subsystem, level := range l.subsystems {
+		subsystems[subsystem] = level
+	}
+
+	return l.level, subsystems
+}
+
+// Enabled returns whether the given level is enabled for any logger
+func (l *Levels) Enabled(level zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.level.Enabled(level) {
+		return true
+	}
+	for _, subsystemLevel := range l.subsystems {
+		if subsystemLevel.Enabled(level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enabledFor returns whether the given level is enabled for the logger of the
+// given name, whose subsystem is the first element of the name
+func (l *Levels) enabledFor(loggerName string, level zapcore.Level) bool {
+	subsystem, _, _ := strings.Cut(loggerName, ".")
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if subsystemLevel, ok := l.subsystems[subsystem]; ok {
+		return subsystemLevel.Enabled(level)
+	}
+
+	return l.level.Enabled(level)
+}
+
+// levelsCore filters the entries of the wrapped core by the logging level of
+// the subsystem of their logger
+type levelsCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelsCore) Enabled(level zapcore.Level) bool {
+	return c.levels.Enabled(level)
+}
+
+func (c *levelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelsCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabledFor(ent.LoggerName, ent.Level) {
+		return ce
+	}
+
+	return c.Core.Check(ent, ce)
+}
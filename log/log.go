@@ -16,6 +16,35 @@ import (
 )
 
 func NewRootLogger(format string, level string, w io.Writer) (*zap.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRootLogger(format, lvl, w)
+}
+
+// ParseLevel returns the zap level of the given logging level
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch strings.ToLower(level) {
+	case "panic":
+		return zap.PanicLevel, nil
+	case "fatal":
+		return zap.FatalLevel, nil
+	case "error":
+		return zap.ErrorLevel, nil
+	case "warn", "warning":
+		return zap.WarnLevel, nil
+	case "info":
+		return zap.InfoLevel, nil
+	case "debug":
+		return zap.DebugLevel, nil
+	default:
+		return zapcore.InvalidLevel, fmt.Errorf("unsupported log level: %s", level)
+	}
+}
+
+func newRootLogger(format string, lvl zapcore.LevelEnabler, w io.Writer) (*zap.Logger, error) {
 	cfg := zap.NewProductionEncoderConfig()
 	cfg.EncodeTime = func(ts time.Time, encoder zapcore.PrimitiveArrayEncoder) {
 		encoder.AppendString(ts.UTC().Format("2006-01-02T15:04:05.000000Z07:00"))
@@ -34,24 +63,6 @@ func NewRootLogger(format string, level string, w io.Writer) (*zap.Logger, error
 		return nil, fmt.Errorf("unrecognized log format %q", format)
 	}
 
-	var lvl zapcore.Level
-	switch strings.ToLower(level) {
-	case "panic":
-		lvl = zap.PanicLevel
-	case "fatal":
-		lvl = zap.FatalLevel
-	case "error":
-		lvl = zap.ErrorLevel
-	case "warn", "warning":
-		lvl = zap.WarnLevel
-	case "info":
-		lvl = zap.InfoLevel
-	case "debug":
-		lvl = zap.DebugLevel
-	default:
-		return nil, fmt.Errorf("unsupported log level: %s", level)
-	}
-
 	return zap.New(
 		zapcore.NewCore(
 			enc,
@@ -62,6 +73,11 @@ func NewRootLogger(format string, level string, w io.Writer) (*zap.Logger, error
 }
 
 func NewRootLoggerWithFile(logFile string, level string) (*zap.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := util.MakeDirectory(filepath.Dir(logFile)); err != nil {
 		return nil, err
 	}
@@ -72,10 +88,44 @@ func NewRootLoggerWithFile(logFile string, level string) (*zap.Logger, error) {
 	}
 	mw := io.MultiWriter(os.Stdout, f)
 
-	logger, err := NewRootLogger("console", level, mw)
+	logger, err := newRootLogger("console", lvl, mw)
 	if err != nil {
 		return nil, err
 	}
 
 	return logger, nil
 }
+
+// NewRootLoggerWithRotation returns a root logger writing to the stdout and
+// to the log file rotated as configured, whose levels can be changed while it
+// is in use. The returned file is closed once the logger is no longer used
+func NewRootLoggerWithRotation(logFile string, cfg *Config, levels *Levels) (*zap.Logger, *RotatingFile, error) {
+	if err := util.MakeDirectory(filepath.Dir(logFile)); err != nil {
+		return nil, nil, err
+	}
+	// the log file is created beforehand, as the rotated files keep its mode
+	// #nosec G304 - The log file path is provided by the user and not externally
+	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	rf := NewRotatingFile(logFile, cfg)
+	mw := io.MultiWriter(os.Stdout, rf)
+
+	// the entries are filtered by the levels, so the wrapped core enables
+	// all of them
+	logger, err := newRootLogger("console", zapcore.DebugLevel, mw)
+	if err != nil {
+		_ = rf.Close()
+
+		return nil, nil, err
+	}
+
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelsCore{Core: core, levels: levels}
+	})), rf, nil
+}
@@ -0,0 +1,103 @@
+package log_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/babylonlabs-io/finality-provider/log"
+)
+
+func TestParseSubsystemLevels(t *testing.T) {
+	t.Parallel()
+
+	levels, err := log.ParseSubsystemLevels([]string{"poller=debug", " store = error "})
+	require.NoError(t, err)
+	require.Equal(t, map[string]zapcore.Level{
+		log.SubsystemPoller: zapcore.DebugLevel,
+		log.SubsystemStore:  zapcore.ErrorLevel,
+	}, levels)
+
+	_, err = log.ParseSubsystemLevels([]string{"poller"})
+	require.ErrorContains(t, err, "expected subsystem=level")
+	_, err = log.ParseSubsystemLevels([]string{"unknown=debug"})
+	require.ErrorContains(t, err, "unknown logging subsystem")
+	_, err = log.ParseSubsystemLevels([]string{"poller=verbose"})
+	require.Error(t, err)
+}
+
+// TestSubsystemLevels tests that the entries are filtered by the logging
+// level of the subsystem of their logger, which can be changed at runtime
+func TestSubsystemLevels(t *testing.T) {
+	t.Parallel()
+
+	logFile := filepath.Join(t.TempDir(), "test.log")
+	levels := log.NewLevels(zapcore.InfoLevel, map[string]zapcore.Level{
+		log.SubsystemPoller: zapcore.DebugLevel,
+		log.SubsystemStore:  zapcore.ErrorLevel,
+	})
+	logger, f, err := log.NewRootLoggerWithRotation(logFile, log.DefaultConfig(), levels)
+	require.NoError(t, err)
+	defer f.Close()
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.Named(log.SubsystemPoller).Debug("poller debug")
+	logger.Named(log.SubsystemPoller).Named("sub").Debug("poller sub debug")
+	logger.Named(log.SubsystemStore).Warn("store warn")
+	logger.Named(log.SubsystemStore).Error("store error")
+
+	require.NoError(t, levels.SetSubsystem(log.SubsystemStore, zapcore.WarnLevel))
+	require.NoError(t, levels.SetSubsystem("", zapcore.WarnLevel))
+	require.Error(t, levels.SetSubsystem("unknown", zapcore.WarnLevel))
+	logger.Info("root info after")
+	logger.Named(log.SubsystemStore).With().Warn("store warn after")
+
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	for _, msg := range []string{"root info", "poller debug", "poller sub debug", "store error", "store warn after"} {
+		require.Contains(t, string(content), msg)
+	}
+	for _, msg := range []string{"root debug", "store warn\n", "root info after"} {
+		require.NotContains(t, string(content), msg)
+	}
+}
+
+// TestRotateByTime tests that the log file is rotated at the configured
+// interval and the rotated files are compressed
+func TestRotateByTime(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "test.log")
+	cfg := log.DefaultConfig()
+	cfg.RotateInterval = 50 * time.Millisecond
+	cfg.MaxBackups = 2
+	logger, f, err := log.NewRootLoggerWithRotation(logFile, cfg, log.NewLevels(zapcore.InfoLevel, nil))
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.Eventually(t, func() bool {
+		logger.Info("entry")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		compressed := 0
+		for _, e := range entries {
+			if strings.HasSuffix(e.Name(), ".log.gz") {
+				compressed++
+			}
+		}
+
+		return compressed == cfg.MaxBackups
+	}, 5*time.Second, 20*time.Millisecond)
+
+	info, err := os.Stat(logFile)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
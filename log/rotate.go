@@ -0,0 +1,72 @@
+package log
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// RotatingFile is a log file rotated by size and by time, of which the
+// rotated files are kept as configured
+type RotatingFile struct {
+	*lumberjack.Logger
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewRotatingFile returns the log file at the given path rotated as
+// configured. The file is created upon the first write
+func NewRotatingFile(path string, cfg *Config) *RotatingFile {
+	maxSize := cfg.MaxSize
+	if maxSize == 0 {
+		// lumberjack rotates by size in any case, defaulting to 100 megabytes
+		maxSize = math.MaxInt32
+	}
+
+	f := &RotatingFile{
+		Logger: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    maxSize,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAge,
+			Compress:   cfg.Compress,
+		},
+		quit: make(chan struct{}),
+	}
+
+	if cfg.RotateInterval > 0 {
+		f.wg.Add(1)
+		go f.rotateLoop(cfg.RotateInterval)
+	}
+
+	return f
+}
+
+func (f *RotatingFile) rotateLoop(interval time.Duration) {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// a failed rotation is retried at the next tick, while the
+			// entries keep being written to the current file
+			_ = f.Rotate()
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// Close stops the rotation by time and closes the log file
+func (f *RotatingFile) Close() error {
+	close(f.quit)
+	f.wg.Wait()
+
+	return f.Logger.Close()
+}
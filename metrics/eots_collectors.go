@@ -13,6 +13,7 @@ type EotsMetrics struct {
 	EotsFpTotalEotsSignCounter            *prometheus.CounterVec
 	EotsFpLastEotsSignHeight              *prometheus.GaugeVec
 	EotsFpTotalSchnorrSignCounter         *prometheus.CounterVec
+	EotsRPCDuration                       *prometheus.HistogramVec
 }
 
 var eotsMetricsRegisterOnce sync.Once
@@ -61,6 +62,14 @@ func NewEotsMetrics() *EotsMetrics {
 				},
 				[]string{"fp_btc_pk_hex"},
 			),
+			EotsRPCDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "eots_server_rpc_duration_seconds",
+					Help:    "Duration of the calls served by the EOTS manager",
+					Buckets: prometheus.DefBuckets,
+				},
+				[]string{"method", "code"},
+			),
 		}
 
 		// Register the EOTS metrics with Prometheus
@@ -70,6 +79,7 @@ func NewEotsMetrics() *EotsMetrics {
 		prometheus.MustRegister(eotsMetricsInstance.EotsFpTotalEotsSignCounter)
 		prometheus.MustRegister(eotsMetricsInstance.EotsFpLastEotsSignHeight)
 		prometheus.MustRegister(eotsMetricsInstance.EotsFpTotalSchnorrSignCounter)
+		prometheus.MustRegister(eotsMetricsInstance.EotsRPCDuration)
 	})
 
 	return eotsMetricsInstance
@@ -10,6 +10,22 @@ import (
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 )
 
+// The stages of a vote, from the block being seen by the poller
+const (
+	// VoteStageSigned is when the EOTS signature over the block is made
+	VoteStageSigned = "signed"
+	// VoteStageBroadcast is when the transaction of the signature is
+	// accepted into the mempool of the consumer chain node
+	VoteStageBroadcast = "broadcast"
+	// VoteStageIncluded is when the transaction of the signature is included
+	// in a block
+	VoteStageIncluded = "included"
+)
+
+// voteLatencyBuckets range from 50ms to about 100s, as a vote lands within
+// a few blocks
+var voteLatencyBuckets = prometheus.ExponentialBuckets(0.05, 2, 12)
+
 type FpMetrics struct {
 	// all finality provider metrics
 	runningFpGauge prometheus.Gauge
@@ -17,6 +33,11 @@ type FpMetrics struct {
 	babylonTipHeight     prometheus.Gauge
 	lastPolledHeight     prometheus.Gauge
 	pollerStartingHeight prometheus.Gauge
+	// babylon endpoint metrics
+	babylonActiveEndpoint   *prometheus.GaugeVec
+	babylonEndpointHealthy  *prometheus.GaugeVec
+	babylonEndpointLatency  *prometheus.GaugeVec
+	babylonEndpointFailover prometheus.Counter
 	// single finality provider metrics
 	fpStatus                        *prometheus.GaugeVec
 	fpSecondsSinceLastVote          *prometheus.GaugeVec
@@ -29,6 +50,16 @@ type FpMetrics struct {
 	fpTotalCommittedRandomness      *prometheus.GaugeVec
 	fpTotalFailedVotes              *prometheus.CounterVec
 	fpTotalFailedRandomness         *prometheus.CounterVec
+	fpTotalInstanceRestarts         *prometheus.CounterVec
+	fpJailedUntil                   *prometheus.GaugeVec
+	fpTotalAutoUnjails              *prometheus.CounterVec
+	fpTotalShadowVotedBlocks        *prometheus.CounterVec
+	fpTotalShadowPubRandCommits     *prometheus.CounterVec
+	fpLeaseHeld                     *prometheus.GaugeVec
+	fpPubRandRunway                 *prometheus.GaugeVec
+	// latency metrics
+	fpVoteLatency   *prometheus.HistogramVec
+	eotsRPCDuration *prometheus.HistogramVec
 	// time keeper
 	mu                     sync.Mutex
 	previousVoteByFp       map[string]*time.Time
@@ -65,6 +96,22 @@ func NewFpMetrics() *FpMetrics {
 				Name: "poller_starting_height",
 				Help: "The initial block height when the poller started operation",
 			}),
+			babylonActiveEndpoint: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "babylon_active_endpoint",
+				Help: "Whether the Babylon rpc endpoint is the one in use (1) or not (0)",
+			}, []string{"rpc_address"}),
+			babylonEndpointHealthy: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "babylon_endpoint_healthy",
+				Help: "Whether the Babylon rpc endpoint passed the last health check (1) or not (0)",
+			}, []string{"rpc_address"}),
+			babylonEndpointLatency: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "babylon_endpoint_latency_seconds",
+				Help: "The latency of the last health check of the Babylon rpc endpoint",
+			}, []string{"rpc_address"}),
+			babylonEndpointFailover: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "babylon_endpoint_failovers",
+				Help: "The total number of times the active Babylon rpc endpoint was switched",
+			}),
 			fpSecondsSinceLastVote: prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
 					Name: "fp_seconds_since_last_vote",
@@ -135,6 +182,71 @@ func NewFpMetrics() *FpMetrics {
 				},
 				[]string{"fp_btc_pk_hex"},
 			),
+			fpTotalInstanceRestarts: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_instance_restarts",
+					Help: "The total number of restarts of a finality provider instance due to critical errors.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpJailedUntil: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_jailed_until",
+					Help: "The unix time in seconds until which a jailed finality provider is jailed.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalAutoUnjails: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_auto_unjails",
+					Help: "The total number of times a finality provider is automatically unjailed.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalShadowVotedBlocks: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_shadow_voted_blocks",
+					Help: "The total number of blocks a finality provider in shadow mode would have voted.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpTotalShadowPubRandCommits: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_shadow_pub_rand_commits",
+					Help: "The total number of public randomness commits a finality provider in shadow mode would have sent.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpLeaseHeld: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_lease_held",
+					Help: "Whether the daemon holds the lease and runs the finality provider (1) or stands by (0).",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpPubRandRunway: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "fp_pub_rand_runway_blocks",
+					Help: "The last height with committed public randomness minus the tip height, negative if the tip is beyond it.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
+			fpVoteLatency: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "fp_vote_latency_seconds",
+					Help:    "The seconds from a block being seen by the poller to the vote of a finality provider reaching a stage.",
+					Buckets: voteLatencyBuckets,
+				},
+				[]string{"fp_btc_pk_hex", "stage"},
+			),
+			eotsRPCDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Name:    "eots_client_rpc_duration_seconds",
+					Help:    "The duration of the calls to the EOTS manager.",
+					Buckets: prometheus.DefBuckets,
+				},
+				[]string{"method", "code"},
+			),
 			mu: sync.Mutex{},
 		}
 
@@ -144,6 +256,10 @@ func NewFpMetrics() *FpMetrics {
 		prometheus.MustRegister(fpMetricsInstance.babylonTipHeight)
 		prometheus.MustRegister(fpMetricsInstance.lastPolledHeight)
 		prometheus.MustRegister(fpMetricsInstance.pollerStartingHeight)
+		prometheus.MustRegister(fpMetricsInstance.babylonActiveEndpoint)
+		prometheus.MustRegister(fpMetricsInstance.babylonEndpointHealthy)
+		prometheus.MustRegister(fpMetricsInstance.babylonEndpointLatency)
+		prometheus.MustRegister(fpMetricsInstance.babylonEndpointFailover)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastVote)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastRandomness)
 		prometheus.MustRegister(fpMetricsInstance.fpLastVotedHeight)
@@ -154,6 +270,15 @@ func NewFpMetrics() *FpMetrics {
 		prometheus.MustRegister(fpMetricsInstance.fpLastCommittedRandomnessHeight)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedVotes)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedRandomness)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalInstanceRestarts)
+		prometheus.MustRegister(fpMetricsInstance.fpJailedUntil)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalAutoUnjails)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalShadowVotedBlocks)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalShadowPubRandCommits)
+		prometheus.MustRegister(fpMetricsInstance.fpLeaseHeld)
+		prometheus.MustRegister(fpMetricsInstance.fpPubRandRunway)
+		prometheus.MustRegister(fpMetricsInstance.fpVoteLatency)
+		prometheus.MustRegister(fpMetricsInstance.eotsRPCDuration)
 	})
 
 	return fpMetricsInstance
@@ -189,6 +314,22 @@ func (fm *FpMetrics) RecordPollerStartingHeight(height uint64) {
 	fm.pollerStartingHeight.Set(float64(height))
 }
 
+// RecordBabylonActiveEndpoint records which Babylon rpc endpoint is in use
+func (fm *FpMetrics) RecordBabylonActiveEndpoint(rpcAddr string, active bool) {
+	fm.babylonActiveEndpoint.WithLabelValues(rpcAddr).Set(boolToFloat64(active))
+}
+
+// RecordBabylonEndpointHealth records the result of a health check of a Babylon rpc endpoint
+func (fm *FpMetrics) RecordBabylonEndpointHealth(rpcAddr string, healthy bool, latency time.Duration) {
+	fm.babylonEndpointHealthy.WithLabelValues(rpcAddr).Set(boolToFloat64(healthy))
+	fm.babylonEndpointLatency.WithLabelValues(rpcAddr).Set(latency.Seconds())
+}
+
+// IncrementBabylonEndpointFailover increments the number of switches of the active Babylon rpc endpoint
+func (fm *FpMetrics) IncrementBabylonEndpointFailover() {
+	fm.babylonEndpointFailover.Inc()
+}
+
 // RecordFpSecondsSinceLastVote records the seconds since the last finality sig vote by a finality provider
 func (fm *FpMetrics) RecordFpSecondsSinceLastVote(fpBtcPkHex string, seconds float64) {
 	fm.fpSecondsSinceLastVote.WithLabelValues(fpBtcPkHex).Set(seconds)
@@ -244,6 +385,48 @@ func (fm *FpMetrics) IncrementFpTotalFailedRandomness(fpBtcPkHex string) {
 	fm.fpTotalFailedRandomness.WithLabelValues(fpBtcPkHex).Inc()
 }
 
+// IncrementFpTotalInstanceRestarts increments the total number of restarts of a finality provider instance
+func (fm *FpMetrics) IncrementFpTotalInstanceRestarts(fpBtcPkHex string) {
+	fm.fpTotalInstanceRestarts.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// RecordFpJailedUntil records the time until which a finality provider is jailed
+func (fm *FpMetrics) RecordFpJailedUntil(fpBtcPkHex string, jailedUntil time.Time) {
+	fm.fpJailedUntil.WithLabelValues(fpBtcPkHex).Set(float64(jailedUntil.Unix()))
+}
+
+// IncrementFpTotalAutoUnjails increments the total number of times a finality provider is automatically unjailed
+func (fm *FpMetrics) IncrementFpTotalAutoUnjails(fpBtcPkHex string) {
+	fm.fpTotalAutoUnjails.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// AddToFpTotalShadowVotedBlocks adds a number to the total number of blocks a finality provider in shadow mode would have voted
+func (fm *FpMetrics) AddToFpTotalShadowVotedBlocks(fpBtcPkHex string, num float64) {
+	fm.fpTotalShadowVotedBlocks.WithLabelValues(fpBtcPkHex).Add(num)
+}
+
+// IncrementFpTotalShadowPubRandCommits increments the total number of randomness commits a finality provider in shadow mode would have sent
+func (fm *FpMetrics) IncrementFpTotalShadowPubRandCommits(fpBtcPkHex string) {
+	fm.fpTotalShadowPubRandCommits.WithLabelValues(fpBtcPkHex).Inc()
+}
+
+// RecordFpLeaseHeld records whether the daemon holds the lease of a finality provider
+func (fm *FpMetrics) RecordFpLeaseHeld(fpBtcPkHex string, held bool) {
+	fm.fpLeaseHeld.WithLabelValues(fpBtcPkHex).Set(boolToFloat64(held))
+}
+
+// RecordFpPubRandRunway records the last height with committed public randomness
+// of a finality provider minus the tip height
+func (fm *FpMetrics) RecordFpPubRandRunway(fpBtcPkHex string, lastCommittedHeight, tipHeight uint64) {
+	fm.fpPubRandRunway.WithLabelValues(fpBtcPkHex).Set(float64(lastCommittedHeight) - float64(tipHeight))
+}
+
+// RecordFpVoteLatency records the time from a block being seen by the poller to
+// the vote of a finality provider over it reaching the given stage
+func (fm *FpMetrics) RecordFpVoteLatency(fpBtcPkHex string, stage string, latency time.Duration) {
+	fm.fpVoteLatency.WithLabelValues(fpBtcPkHex, stage).Observe(latency.Seconds())
+}
+
 // RecordFpVoteTime records the time of a finality sig vote by a finality provider
 func (fm *FpMetrics) RecordFpVoteTime(fpBtcPkHex string) {
 	fm.mu.Lock()
@@ -284,3 +467,11 @@ func (fm *FpMetrics) UpdateFpMetrics(fp *store.StoredFinalityProvider) {
 		fm.RecordFpSecondsSinceLastRandomness(fp.GetBIP340BTCPK().MarshalHex(), time.Since(*lastRandomnessTime).Seconds())
 	}
 }
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// EotsRPCClientInterceptor records the duration of the calls to the EOTS
+// manager by their method and status code
+func (fm *FpMetrics) EotsRPCClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+	) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		fm.eotsRPCDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// RPCServerInterceptor records the duration of the calls served by the EOTS
+// manager by their method and status code
+func (em *EotsMetrics) RPCServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		em.EotsRPCDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
@@ -0,0 +1,63 @@
+package metrics_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// TestRPCInterceptors tests that the durations of the calls to the EOTS
+// manager are recorded on both the client and the server side
+func TestRPCInterceptors(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(metrics.NewEotsMetrics().RPCServerInterceptor()))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(metrics.NewFpMetrics().EotsRPCClientInterceptor()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.Error(t, err)
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	counts := make(map[string]uint64)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["method"] == healthpb.Health_Check_FullMethodName {
+				counts[family.GetName()+" "+labels["code"]] += m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	for _, name := range []string{"eots_client_rpc_duration_seconds", "eots_server_rpc_duration_seconds"} {
+		require.Equal(t, uint64(1), counts[name+" OK"], name)
+		require.Equal(t, uint64(1), counts[name+" NotFound"], name)
+	}
+}
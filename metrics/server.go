@@ -7,6 +7,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/health"
 )
 
 // Server represents the metrics server.
@@ -15,9 +17,15 @@ type Server struct {
 	logger     *zap.Logger
 }
 
-func Start(addr string, logger *zap.Logger) *Server {
+// Start starts the metrics server, which also serves the liveness and
+// readiness endpoints if the health checker is given
+func Start(addr string, logger *zap.Logger, checker *health.Checker) *Server {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
+	if checker != nil {
+		mux.Handle(health.LivenessPath, checker.LivenessHandler())
+		mux.Handle(health.ReadinessPath, checker.ReadinessHandler())
+	}
 
 	// Create the HTTP server with the custom ServeMux as the handler
 	server := &http.Server{
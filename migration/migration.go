@@ -0,0 +1,197 @@
+package migration
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// metadataBucketName is the bucket holding the schema version
+	metadataBucketName = []byte("metadata")
+	schemaVersionKey   = []byte("schemaVersion")
+)
+
+var (
+	// ErrDBVersionTooNew is returned for a database of a schema version that
+	// is unknown to the binary, i.e., written by a newer binary
+	ErrDBVersionTooNew = errors.New("the database schema version is newer than supported")
+	// ErrCorruptedMetadata is returned if the schema version cannot be decoded
+	ErrCorruptedMetadata = errors.New("the database metadata is corrupted")
+	// ErrMissingBucket is returned for a database missing a bucket of its
+	// layout, e.g., the database of another daemon
+	ErrMissingBucket = errors.New("the database is missing a bucket")
+)
+
+// Migration upgrades the layout of a database by one schema version
+type Migration struct {
+	// Description is a short description of the change of the layout
+	Description string
+	// Migrate upgrades the layout within the transaction of all the pending
+	// migrations
+	Migrate func(tx kvdb.RwTx) error
+}
+
+// Plan is the migrations to run to bring a database to the latest schema
+// version
+type Plan struct {
+	// Current is the schema version of the database, which is 0 for a new
+	// database or one written before the schema was versioned
+	Current uint32
+	// Target is the latest schema version known to the binary
+	Target uint32
+	// Pending are the migrations from the current version to the target one
+	Pending []Migration
+}
+
+// String describes the versions and the pending migrations of the plan
+func (p *Plan) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "schema version: %d, latest schema version: %d\n", p.Current, p.Target)
+	if len(p.Pending) == 0 {
+		sb.WriteString("no pending migration\n")
+
+		return sb.String()
+	}
+
+	sb.WriteString("pending migrations:\n")
+	for i, m := range p.Pending {
+		fmt.Fprintf(&sb, "  %d: %s\n", p.Current+uint32(i)+1, m.Description)
+	}
+
+	return sb.String()
+}
+
+// Check returns the plan to migrate the database with the given migrations,
+// without changing the database. The i-th migration upgrades the database
+// to schema version i+1
+func Check(db kvdb.Backend, migrations []Migration) (*Plan, error) {
+	var plan *Plan
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		current, err := readVersion(tx)
+		if err != nil {
+			return err
+		}
+
+		plan, err = newPlan(current, migrations)
+
+		return err
+	}, func() {})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// Run runs the pending migrations of the database in order within a single
+// transaction, so that the database is left unchanged if any of them fails,
+// and returns the plan it ran
+func Run(db kvdb.Backend, migrations []Migration) (*Plan, error) {
+	var plan *Plan
+	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
+		current, err := readVersion(tx)
+		if err != nil {
+			return err
+		}
+
+		plan, err = newPlan(current, migrations)
+		if err != nil {
+			return err
+		}
+
+		if len(plan.Pending) == 0 {
+			return nil
+		}
+
+		for i, m := range plan.Pending {
+			version := plan.Current + uint32(i) + 1
+			if err := m.Migrate(tx); err != nil {
+				return fmt.Errorf("failed to migrate the database to schema version %d (%s): %w",
+					version, m.Description, err)
+			}
+		}
+
+		return writeVersion(tx, plan.Target)
+	}, func() {
+		plan = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// CheckBuckets checks that the database has the given top level buckets
+func CheckBuckets(db kvdb.Backend, names ...[]byte) error {
+	return kvdb.View(db, func(tx kvdb.RTx) error {
+		for _, name := range names {
+			if tx.ReadBucket(name) == nil {
+				return fmt.Errorf("%w: %s", ErrMissingBucket, name)
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+func newPlan(current uint32, migrations []Migration) (*Plan, error) {
+	target := uint32(len(migrations))
+	if current > target {
+		return nil, fmt.Errorf("%w: the database is at version %d while version %d is the latest supported, "+
+			"please upgrade the binary", ErrDBVersionTooNew, current, target)
+	}
+
+	return &Plan{
+		Current: current,
+		Target:  target,
+		Pending: migrations[current:],
+	}, nil
+}
+
+func readVersion(tx kvdb.RTx) (uint32, error) {
+	bucket := tx.ReadBucket(metadataBucketName)
+	if bucket == nil {
+		return 0, nil
+	}
+
+	v := bucket.Get(schemaVersionKey)
+	if v == nil {
+		return 0, nil
+	}
+	if len(v) != 4 {
+		return 0, ErrCorruptedMetadata
+	}
+
+	return binary.BigEndian.Uint32(v), nil
+}
+
+func writeVersion(tx kvdb.RwTx, version uint32) error {
+	bucket, err := tx.CreateTopLevelBucket(metadataBucketName)
+	if err != nil {
+		return err
+	}
+
+	return bucket.Put(schemaVersionKey, binary.BigEndian.AppendUint32(nil, version))
+}
+
+// CreateBuckets returns a migration creating the given top level buckets,
+// which is the first migration of a database
+func CreateBuckets(description string, names ...[]byte) Migration {
+	return Migration{
+		Description: description,
+		Migrate: func(tx kvdb.RwTx) error {
+			for _, name := range names {
+				if _, err := tx.CreateTopLevelBucket(name); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,154 @@
+package migration_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/migration"
+)
+
+var (
+	bucketA = []byte("a")
+	bucketB = []byte("b")
+	keyA    = []byte("key")
+)
+
+func newTestDB(t *testing.T) kvdb.Backend {
+	db, err := config.DefaultDBConfigWithHomePath(t.TempDir()).GetDBBackend()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
+
+func bucketExists(t *testing.T, db kvdb.Backend, name []byte) bool {
+	var exists bool
+	err := kvdb.View(db, func(tx kvdb.RTx) error {
+		exists = tx.ReadBucket(name) != nil
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+
+	return exists
+}
+
+// moveKey returns a migration moving the value of keyA to bucket b
+func moveKey() migration.Migration {
+	return migration.Migration{
+		Description: "move the key to bucket b",
+		Migrate: func(tx kvdb.RwTx) error {
+			a := tx.ReadWriteBucket(bucketA)
+			b, err := tx.CreateTopLevelBucket(bucketB)
+			if err != nil {
+				return err
+			}
+			if v := a.Get(keyA); v != nil {
+				if err := b.Put(keyA, v); err != nil {
+					return err
+				}
+			}
+
+			return a.Delete(keyA)
+		},
+	}
+}
+
+func TestMigrateNewDB(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	migrations := []migration.Migration{
+		migration.CreateBuckets("create bucket a", bucketA),
+		moveKey(),
+	}
+
+	plan, err := migration.Check(db, migrations)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), plan.Current)
+	require.Equal(t, uint32(2), plan.Target)
+	require.Len(t, plan.Pending, 2)
+	// the check does not change the database
+	require.False(t, bucketExists(t, db, bucketA))
+
+	plan, err = migration.Run(db, migrations)
+	require.NoError(t, err)
+	require.Len(t, plan.Pending, 2)
+	require.True(t, bucketExists(t, db, bucketA))
+	require.True(t, bucketExists(t, db, bucketB))
+
+	plan, err = migration.Check(db, migrations)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), plan.Current)
+	require.Empty(t, plan.Pending)
+}
+
+func TestMigrateExistingDB(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+
+	// the database is written by a binary knowing the first migration only
+	_, err := migration.Run(db, []migration.Migration{migration.CreateBuckets("create bucket a", bucketA)})
+	require.NoError(t, err)
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		return tx.ReadWriteBucket(bucketA).Put(keyA, []byte("value"))
+	}, func() {})
+	require.NoError(t, err)
+
+	plan, err := migration.Run(db, []migration.Migration{
+		migration.CreateBuckets("create bucket a", bucketA),
+		moveKey(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), plan.Current)
+	require.Equal(t, uint32(2), plan.Target)
+	require.Len(t, plan.Pending, 1)
+
+	err = kvdb.View(db, func(tx kvdb.RTx) error {
+		require.Nil(t, tx.ReadBucket(bucketA).Get(keyA))
+		require.Equal(t, []byte("value"), tx.ReadBucket(bucketB).Get(keyA))
+
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+}
+
+func TestMigrateFailureRollsBack(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+	errMigration := errors.New("failed migration")
+
+	_, err := migration.Run(db, []migration.Migration{
+		migration.CreateBuckets("create bucket a", bucketA),
+		{Description: "fail", Migrate: func(kvdb.RwTx) error { return errMigration }},
+	})
+	require.ErrorIs(t, err, errMigration)
+
+	// neither the first migration nor the version is written
+	require.False(t, bucketExists(t, db, bucketA))
+	plan, err := migration.Check(db, []migration.Migration{migration.CreateBuckets("create bucket a", bucketA)})
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), plan.Current)
+}
+
+func TestMigrateNewerDB(t *testing.T) {
+	t.Parallel()
+	db := newTestDB(t)
+
+	_, err := migration.Run(db, []migration.Migration{
+		migration.CreateBuckets("create bucket a", bucketA),
+		moveKey(),
+	})
+	require.NoError(t, err)
+
+	older := []migration.Migration{migration.CreateBuckets("create bucket a", bucketA)}
+	_, err = migration.Check(db, older)
+	require.ErrorIs(t, err, migration.ErrDBVersionTooNew)
+	_, err = migration.Run(db, older)
+	require.ErrorIs(t, err, migration.ErrDBVersionTooNew)
+}
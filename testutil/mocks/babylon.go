@@ -5,7 +5,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	math "cosmossdk.io/math"
 	types "github.com/babylonlabs-io/babylon/x/btcstaking/types"
@@ -54,144 +56,159 @@ func (mr *MockClientControllerMockRecorder) Close() *gomock.Call {
 }
 
 // CommitPubRandList mocks base method.
-func (m *MockClientController) CommitPubRandList(fpPk *btcec.PublicKey, startHeight, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types1.TxResponse, error) {
+func (m *MockClientController) CommitPubRandList(ctx context.Context, fpPk *btcec.PublicKey, startHeight, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types1.TxResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CommitPubRandList", fpPk, startHeight, numPubRand, commitment, sig)
+	ret := m.ctrl.Call(m, "CommitPubRandList", ctx, fpPk, startHeight, numPubRand, commitment, sig)
 	ret0, _ := ret[0].(*types1.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CommitPubRandList indicates an expected call of CommitPubRandList.
-func (mr *MockClientControllerMockRecorder) CommitPubRandList(fpPk, startHeight, numPubRand, commitment, sig interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) CommitPubRandList(ctx, fpPk, startHeight, numPubRand, commitment, sig interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitPubRandList", reflect.TypeOf((*MockClientController)(nil).CommitPubRandList), fpPk, startHeight, numPubRand, commitment, sig)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitPubRandList", reflect.TypeOf((*MockClientController)(nil).CommitPubRandList), ctx, fpPk, startHeight, numPubRand, commitment, sig)
 }
 
 // EditFinalityProvider mocks base method.
-func (m *MockClientController) EditFinalityProvider(fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*types.MsgEditFinalityProvider, error) {
+func (m *MockClientController) EditFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*types.MsgEditFinalityProvider, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditFinalityProvider", fpPk, commission, description)
+	ret := m.ctrl.Call(m, "EditFinalityProvider", ctx, fpPk, commission, description)
 	ret0, _ := ret[0].(*types.MsgEditFinalityProvider)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EditFinalityProvider indicates an expected call of EditFinalityProvider.
-func (mr *MockClientControllerMockRecorder) EditFinalityProvider(fpPk, commission, description interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) EditFinalityProvider(ctx, fpPk, commission, description interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditFinalityProvider", reflect.TypeOf((*MockClientController)(nil).EditFinalityProvider), fpPk, commission, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditFinalityProvider", reflect.TypeOf((*MockClientController)(nil).EditFinalityProvider), ctx, fpPk, commission, description)
 }
 
 // QueryActivatedHeight mocks base method.
-func (m *MockClientController) QueryActivatedHeight() (uint64, error) {
+func (m *MockClientController) QueryActivatedHeight(ctx context.Context) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryActivatedHeight")
+	ret := m.ctrl.Call(m, "QueryActivatedHeight", ctx)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryActivatedHeight indicates an expected call of QueryActivatedHeight.
-func (mr *MockClientControllerMockRecorder) QueryActivatedHeight() *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryActivatedHeight(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryActivatedHeight", reflect.TypeOf((*MockClientController)(nil).QueryActivatedHeight))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryActivatedHeight", reflect.TypeOf((*MockClientController)(nil).QueryActivatedHeight), ctx)
 }
 
 // QueryBestBlock mocks base method.
-func (m *MockClientController) QueryBestBlock() (*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBestBlock(ctx context.Context) (*types1.BlockInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryBestBlock")
+	ret := m.ctrl.Call(m, "QueryBestBlock", ctx)
 	ret0, _ := ret[0].(*types1.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryBestBlock indicates an expected call of QueryBestBlock.
-func (mr *MockClientControllerMockRecorder) QueryBestBlock() *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryBestBlock(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBestBlock", reflect.TypeOf((*MockClientController)(nil).QueryBestBlock))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBestBlock", reflect.TypeOf((*MockClientController)(nil).QueryBestBlock), ctx)
 }
 
 // QueryBlock mocks base method.
-func (m *MockClientController) QueryBlock(height uint64) (*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBlock(ctx context.Context, height uint64) (*types1.BlockInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryBlock", height)
+	ret := m.ctrl.Call(m, "QueryBlock", ctx, height)
 	ret0, _ := ret[0].(*types1.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryBlock indicates an expected call of QueryBlock.
-func (mr *MockClientControllerMockRecorder) QueryBlock(height interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryBlock(ctx, height interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBlock", reflect.TypeOf((*MockClientController)(nil).QueryBlock), height)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBlock", reflect.TypeOf((*MockClientController)(nil).QueryBlock), ctx, height)
 }
 
 // QueryBlocks mocks base method.
-func (m *MockClientController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBlocks(ctx context.Context, startHeight, endHeight uint64, limit uint32) ([]*types1.BlockInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryBlocks", startHeight, endHeight, limit)
+	ret := m.ctrl.Call(m, "QueryBlocks", ctx, startHeight, endHeight, limit)
 	ret0, _ := ret[0].([]*types1.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryBlocks indicates an expected call of QueryBlocks.
-func (mr *MockClientControllerMockRecorder) QueryBlocks(startHeight, endHeight, limit interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryBlocks(ctx, startHeight, endHeight, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBlocks", reflect.TypeOf((*MockClientController)(nil).QueryBlocks), startHeight, endHeight, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBlocks", reflect.TypeOf((*MockClientController)(nil).QueryBlocks), ctx, startHeight, endHeight, limit)
 }
 
 // QueryFinalityActivationBlockHeight mocks base method.
-func (m *MockClientController) QueryFinalityActivationBlockHeight() (uint64, error) {
+func (m *MockClientController) QueryFinalityActivationBlockHeight(ctx context.Context) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryFinalityActivationBlockHeight")
+	ret := m.ctrl.Call(m, "QueryFinalityActivationBlockHeight", ctx)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryFinalityActivationBlockHeight indicates an expected call of QueryFinalityActivationBlockHeight.
-func (mr *MockClientControllerMockRecorder) QueryFinalityActivationBlockHeight() *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryFinalityActivationBlockHeight(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityActivationBlockHeight", reflect.TypeOf((*MockClientController)(nil).QueryFinalityActivationBlockHeight))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityActivationBlockHeight", reflect.TypeOf((*MockClientController)(nil).QueryFinalityActivationBlockHeight), ctx)
 }
 
 // QueryFinalityProvider mocks base method.
-func (m *MockClientController) QueryFinalityProvider(fpPk *btcec.PublicKey) (*types.QueryFinalityProviderResponse, error) {
+func (m *MockClientController) QueryFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types.QueryFinalityProviderResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryFinalityProvider", fpPk)
+	ret := m.ctrl.Call(m, "QueryFinalityProvider", ctx, fpPk)
 	ret0, _ := ret[0].(*types.QueryFinalityProviderResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryFinalityProvider indicates an expected call of QueryFinalityProvider.
-func (mr *MockClientControllerMockRecorder) QueryFinalityProvider(fpPk interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryFinalityProvider(ctx, fpPk interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProvider", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProvider), fpPk)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProvider", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProvider), ctx, fpPk)
 }
 
 // QueryFinalityProviderHighestVotedHeight mocks base method.
-func (m *MockClientController) QueryFinalityProviderHighestVotedHeight(fpPk *btcec.PublicKey) (uint64, error) {
+func (m *MockClientController) QueryFinalityProviderHighestVotedHeight(ctx context.Context, fpPk *btcec.PublicKey) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryFinalityProviderHighestVotedHeight", fpPk)
+	ret := m.ctrl.Call(m, "QueryFinalityProviderHighestVotedHeight", ctx, fpPk)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryFinalityProviderHighestVotedHeight indicates an expected call of QueryFinalityProviderHighestVotedHeight.
-func (mr *MockClientControllerMockRecorder) QueryFinalityProviderHighestVotedHeight(fpPk interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderHighestVotedHeight(ctx, fpPk interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderHighestVotedHeight", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderHighestVotedHeight), fpPk)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderHighestVotedHeight", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderHighestVotedHeight), ctx, fpPk)
+}
+
+// QueryFinalityProviderJailedUntil mocks base method.
+func (m *MockClientController) QueryFinalityProviderJailedUntil(ctx context.Context, fpPk *btcec.PublicKey) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityProviderJailedUntil", ctx, fpPk)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityProviderJailedUntil indicates an expected call of QueryFinalityProviderJailedUntil.
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderJailedUntil(ctx, fpPk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderJailedUntil", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderJailedUntil), ctx, fpPk)
 }
 
 // QueryFinalityProviderSlashedOrJailed mocks base method.
-func (m *MockClientController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (bool, bool, error) {
+func (m *MockClientController) QueryFinalityProviderSlashedOrJailed(ctx context.Context, fpPk *btcec.PublicKey) (bool, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryFinalityProviderSlashedOrJailed", fpPk)
+	ret := m.ctrl.Call(m, "QueryFinalityProviderSlashedOrJailed", ctx, fpPk)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -199,112 +216,127 @@ func (m *MockClientController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.
 }
 
 // QueryFinalityProviderSlashedOrJailed indicates an expected call of QueryFinalityProviderSlashedOrJailed.
-func (mr *MockClientControllerMockRecorder) QueryFinalityProviderSlashedOrJailed(fpPk interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderSlashedOrJailed(ctx, fpPk interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderSlashedOrJailed", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderSlashedOrJailed), fpPk)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderSlashedOrJailed", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderSlashedOrJailed), ctx, fpPk)
 }
 
 // QueryFinalityProviderVotingPower mocks base method.
-func (m *MockClientController) QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+func (m *MockClientController) QueryFinalityProviderVotingPower(ctx context.Context, fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryFinalityProviderVotingPower", fpPk, blockHeight)
+	ret := m.ctrl.Call(m, "QueryFinalityProviderVotingPower", ctx, fpPk, blockHeight)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryFinalityProviderVotingPower indicates an expected call of QueryFinalityProviderVotingPower.
-func (mr *MockClientControllerMockRecorder) QueryFinalityProviderVotingPower(fpPk, blockHeight interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderVotingPower(ctx, fpPk, blockHeight interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderVotingPower", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderVotingPower), fpPk, blockHeight)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderVotingPower", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderVotingPower), ctx, fpPk, blockHeight)
 }
 
 // QueryLastCommittedPublicRand mocks base method.
-func (m *MockClientController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*types0.PubRandCommitResponse, error) {
+func (m *MockClientController) QueryLastCommittedPublicRand(ctx context.Context, fpPk *btcec.PublicKey, count uint64) (map[uint64]*types0.PubRandCommitResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryLastCommittedPublicRand", fpPk, count)
+	ret := m.ctrl.Call(m, "QueryLastCommittedPublicRand", ctx, fpPk, count)
 	ret0, _ := ret[0].(map[uint64]*types0.PubRandCommitResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryLastCommittedPublicRand indicates an expected call of QueryLastCommittedPublicRand.
-func (mr *MockClientControllerMockRecorder) QueryLastCommittedPublicRand(fpPk, count interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryLastCommittedPublicRand(ctx, fpPk, count interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastCommittedPublicRand", reflect.TypeOf((*MockClientController)(nil).QueryLastCommittedPublicRand), fpPk, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastCommittedPublicRand", reflect.TypeOf((*MockClientController)(nil).QueryLastCommittedPublicRand), ctx, fpPk, count)
 }
 
 // QueryLatestFinalizedBlocks mocks base method.
-func (m *MockClientController) QueryLatestFinalizedBlocks(count uint64) ([]*types1.BlockInfo, error) {
+func (m *MockClientController) QueryLatestFinalizedBlocks(ctx context.Context, count uint64) ([]*types1.BlockInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "QueryLatestFinalizedBlocks", count)
+	ret := m.ctrl.Call(m, "QueryLatestFinalizedBlocks", ctx, count)
 	ret0, _ := ret[0].([]*types1.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // QueryLatestFinalizedBlocks indicates an expected call of QueryLatestFinalizedBlocks.
-func (mr *MockClientControllerMockRecorder) QueryLatestFinalizedBlocks(count interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) QueryLatestFinalizedBlocks(ctx, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLatestFinalizedBlocks", reflect.TypeOf((*MockClientController)(nil).QueryLatestFinalizedBlocks), ctx, count)
+}
+
+// QueryNodeStatus mocks base method.
+func (m *MockClientController) QueryNodeStatus(ctx context.Context) (*types1.NodeStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryNodeStatus", ctx)
+	ret0, _ := ret[0].(*types1.NodeStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryNodeStatus indicates an expected call of QueryNodeStatus.
+func (mr *MockClientControllerMockRecorder) QueryNodeStatus(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLatestFinalizedBlocks", reflect.TypeOf((*MockClientController)(nil).QueryLatestFinalizedBlocks), count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryNodeStatus", reflect.TypeOf((*MockClientController)(nil).QueryNodeStatus), ctx)
 }
 
 // RegisterFinalityProvider mocks base method.
-func (m *MockClientController) RegisterFinalityProvider(fpPk *btcec.PublicKey, pop []byte, commission *math.LegacyDec, description []byte) (*types1.TxResponse, error) {
+func (m *MockClientController) RegisterFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey, pop []byte, commission *math.LegacyDec, description []byte) (*types1.TxResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterFinalityProvider", fpPk, pop, commission, description)
+	ret := m.ctrl.Call(m, "RegisterFinalityProvider", ctx, fpPk, pop, commission, description)
 	ret0, _ := ret[0].(*types1.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterFinalityProvider indicates an expected call of RegisterFinalityProvider.
-func (mr *MockClientControllerMockRecorder) RegisterFinalityProvider(fpPk, pop, commission, description interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) RegisterFinalityProvider(ctx, fpPk, pop, commission, description interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFinalityProvider", reflect.TypeOf((*MockClientController)(nil).RegisterFinalityProvider), fpPk, pop, commission, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFinalityProvider", reflect.TypeOf((*MockClientController)(nil).RegisterFinalityProvider), ctx, fpPk, pop, commission, description)
 }
 
 // SubmitBatchFinalitySigs mocks base method.
-func (m *MockClientController) SubmitBatchFinalitySigs(fpPk *btcec.PublicKey, blocks []*types1.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types1.TxResponse, error) {
+func (m *MockClientController) SubmitBatchFinalitySigs(ctx context.Context, fpPk *btcec.PublicKey, blocks []*types1.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types1.TxResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubmitBatchFinalitySigs", fpPk, blocks, pubRandList, proofList, sigs)
+	ret := m.ctrl.Call(m, "SubmitBatchFinalitySigs", ctx, fpPk, blocks, pubRandList, proofList, sigs)
 	ret0, _ := ret[0].(*types1.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SubmitBatchFinalitySigs indicates an expected call of SubmitBatchFinalitySigs.
-func (mr *MockClientControllerMockRecorder) SubmitBatchFinalitySigs(fpPk, blocks, pubRandList, proofList, sigs interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) SubmitBatchFinalitySigs(ctx, fpPk, blocks, pubRandList, proofList, sigs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitBatchFinalitySigs", reflect.TypeOf((*MockClientController)(nil).SubmitBatchFinalitySigs), fpPk, blocks, pubRandList, proofList, sigs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitBatchFinalitySigs", reflect.TypeOf((*MockClientController)(nil).SubmitBatchFinalitySigs), ctx, fpPk, blocks, pubRandList, proofList, sigs)
 }
 
 // SubmitFinalitySig mocks base method.
-func (m *MockClientController) SubmitFinalitySig(fpPk *btcec.PublicKey, block *types1.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types1.TxResponse, error) {
+func (m *MockClientController) SubmitFinalitySig(ctx context.Context, fpPk *btcec.PublicKey, block *types1.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types1.TxResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubmitFinalitySig", fpPk, block, pubRand, proof, sig)
+	ret := m.ctrl.Call(m, "SubmitFinalitySig", ctx, fpPk, block, pubRand, proof, sig)
 	ret0, _ := ret[0].(*types1.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SubmitFinalitySig indicates an expected call of SubmitFinalitySig.
-func (mr *MockClientControllerMockRecorder) SubmitFinalitySig(fpPk, block, pubRand, proof, sig interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) SubmitFinalitySig(ctx, fpPk, block, pubRand, proof, sig interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitFinalitySig", reflect.TypeOf((*MockClientController)(nil).SubmitFinalitySig), fpPk, block, pubRand, proof, sig)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitFinalitySig", reflect.TypeOf((*MockClientController)(nil).SubmitFinalitySig), ctx, fpPk, block, pubRand, proof, sig)
 }
 
 // UnjailFinalityProvider mocks base method.
-func (m *MockClientController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types1.TxResponse, error) {
+func (m *MockClientController) UnjailFinalityProvider(ctx context.Context, fpPk *btcec.PublicKey) (*types1.TxResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnjailFinalityProvider", fpPk)
+	ret := m.ctrl.Call(m, "UnjailFinalityProvider", ctx, fpPk)
 	ret0, _ := ret[0].(*types1.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnjailFinalityProvider indicates an expected call of UnjailFinalityProvider.
-func (mr *MockClientControllerMockRecorder) UnjailFinalityProvider(fpPk interface{}) *gomock.Call {
+func (mr *MockClientControllerMockRecorder) UnjailFinalityProvider(ctx, fpPk interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnjailFinalityProvider", reflect.TypeOf((*MockClientController)(nil).UnjailFinalityProvider), fpPk)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnjailFinalityProvider", reflect.TypeOf((*MockClientController)(nil).UnjailFinalityProvider), ctx, fpPk)
 }
@@ -28,7 +28,7 @@ func PrepareMockedClientController(t *testing.T, r *rand.Rand, startHeight, curr
 			Height: currentHeight,
 			Hash:   GenRandomByteArray(r, 32),
 		}
-		mockClientController.EXPECT().QueryBlock(i).Return(resBlock, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBlock(gomock.Any(), i).Return(resBlock, nil).AnyTimes()
 	}
 
 	currentBlockRes := &types.BlockInfo{
@@ -37,9 +37,9 @@ func PrepareMockedClientController(t *testing.T, r *rand.Rand, startHeight, curr
 	}
 
 	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
-	mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
-	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
-	mockClientController.EXPECT().QueryFinalityActivationBlockHeight().Return(finalityActivationBlkHeight, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBestBlock(gomock.Any()).Return(currentBlockRes, nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight(gomock.Any()).Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityActivationBlockHeight(gomock.Any()).Return(finalityActivationBlkHeight, nil).AnyTimes()
 
 	return mockClientController
 }
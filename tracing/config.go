@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"fmt"
+)
+
+const (
+	defaultSampleRatio = 1.0
+)
+
+// Config defines the export of the spans to an OpenTelemetry collector
+type Config struct {
+	Endpoint    string  `long:"endpoint" description:"The address of the OTLP gRPC collector the spans are exported to, e.g., 127.0.0.1:4317; the tracing is disabled if empty"`
+	Insecure    bool    `long:"insecure" description:"Connect to the collector without TLS"`
+	SampleRatio float64 `long:"sampleratio" description:"The ratio of the traces sampled, from 0 to 1; the traces started by a caller over gRPC are sampled as decided by it"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		SampleRatio: defaultSampleRatio,
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return fmt.Errorf("the sample ratio should be between 0 and 1, got %v", cfg.SampleRatio)
+	}
+
+	return nil
+}
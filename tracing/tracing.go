@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+const (
+	// TracerName is the name of the tracer of the spans of the finality
+	// provider and the EOTS manager
+	TracerName = "github.com/babylonlabs-io/finality-provider"
+
+	// shutdownTimeout is the time given to the pending spans to be exported
+	// upon shutdown
+	shutdownTimeout = 5 * time.Second
+)
+
+// Setup sets the global tracer provider, which exports the spans of the given
+// service to the configured collector, and the propagation of the trace
+// context over gRPC. The returned function exports the pending spans and
+// stops the export
+func Setup(ctx context.Context, cfg *Config, serviceName string) (func() error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	if cfg.Endpoint == "" {
+		return func() error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+
+	tp := NewTracerProvider(exporter, serviceName, cfg.SampleRatio)
+	otel.SetTracerProvider(tp)
+
+	return func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		return tp.Shutdown(ctx)
+	}, nil
+}
+
+// NewTracerProvider returns a tracer provider exporting the sampled spans of
+// the given service in batches with the given exporter, e.g., an in-memory
+// one in tests
+func NewTracerProvider(exporter sdktrace.SpanExporter, serviceName string, sampleRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+}
+
+// Start starts a span of the given name with the tracer of the global tracer
+// provider, which is the child of the span of the given context if any
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the given error, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Uint64 returns the attribute of the given unsigned value, e.g., a height,
+// which is saturated at the max int64
+func Uint64(key string, value uint64) attribute.KeyValue {
+	if value > math.MaxInt64 {
+		return attribute.Int64(key, math.MaxInt64)
+	}
+
+	// #nosec G115 -- performed the conversion check above
+	return attribute.Int64(key, int64(value))
+}
+
+// DialOption traces the calls of a gRPC client, propagating the trace context
+// to the server
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// ServerOption traces the calls to a gRPC server as the children of the
+// spans propagated by the clients
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
@@ -0,0 +1,79 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/babylonlabs-io/finality-provider/tracing"
+)
+
+// TestPropagation tests that the spans of the calls over gRPC are the
+// children of the span of the caller, across the client and the server.
+// The global tracer provider is set, so the test is not run in parallel
+func TestPropagation(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), tracing.DefaultConfig(), "test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdown())
+	}()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := tracing.NewTracerProvider(exporter, "test", 1)
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(tracing.ServerOption())
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()), tracing.DialOption())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, span := tracing.Start(context.Background(), "caller", tracing.Uint64("height", 10))
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	tracing.End(span, errors.New("failed"))
+
+	// the span of the server ends once the response is sent
+	byKind := make(map[trace.SpanKind]tracetest.SpanStub)
+	require.Eventually(t, func() bool {
+		require.NoError(t, tp.ForceFlush(context.Background()))
+		for _, s := range exporter.GetSpans() {
+			byKind[s.SpanKind] = s
+		}
+
+		return len(byKind) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	caller, clientSpan, serverSpan := byKind[trace.SpanKindInternal], byKind[trace.SpanKindClient], byKind[trace.SpanKindServer]
+
+	require.Equal(t, "caller", caller.Name)
+	require.Equal(t, codes.Error, caller.Status.Code)
+	require.Len(t, caller.Events, 1)
+	require.Equal(t, caller.SpanContext.SpanID(), clientSpan.Parent.SpanID())
+	require.Equal(t, clientSpan.SpanContext.SpanID(), serverSpan.Parent.SpanID())
+	require.True(t, serverSpan.Parent.IsRemote())
+	require.Equal(t, caller.SpanContext.TraceID(), serverSpan.SpanContext.TraceID())
+}
@@ -1,7 +1,12 @@
 package types
 
+import "time"
+
 type BlockInfo struct {
 	Height    uint64
 	Hash      []byte
 	Finalized bool
+	// SeenAt is when the block is retrieved by the poller, from which the
+	// latency of the vote is measured; zero if the block is not polled
+	SeenAt time.Time
 }
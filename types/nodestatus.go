@@ -0,0 +1,10 @@
+package types
+
+import "time"
+
+// NodeStatus is the sync status of the consumer chain node
+type NodeStatus struct {
+	LatestHeight    uint64
+	LatestBlockTime time.Time
+	CatchingUp      bool
+}
@@ -1,10 +1,15 @@
 package types
 
 import (
+	"time"
+
 	"github.com/cosmos/relayer/v2/relayer/provider"
 )
 
 type TxResponse struct {
 	TxHash string
 	Events []provider.RelayerEvent
+	// BroadcastAt is when the tx is accepted into the mempool of the node,
+	// zero if unknown
+	BroadcastAt time.Time
 }